@@ -4,7 +4,9 @@
 package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 )
@@ -24,25 +26,48 @@ type Config struct {
 	// The dimensions will be fetched from the span's attributes. Examples of some conventionally used attributes:
 	// https://github.com/open-telemetry/opentelemetry-collector/blob/main/model/semconv/opentelemetry.go.
 	Dimensions []Dimension `mapstructure:"dimensions"`
+
+	// Fingerprint adds the exception.fingerprint dimension, identifying exceptions by their type and the
+	// frames of their stacktrace regardless of their message and of the numbers in the stacktrace.
+	// It replaces the exception.message dimension of the metrics.
+	Fingerprint bool `mapstructure:"fingerprint"`
+
+	// LogsDeduplication configures logs to only be emitted for the first occurrence of an exception per interval.
+	LogsDeduplication LogsDeduplication `mapstructure:"logs_deduplication"`
+}
+
+// LogsDeduplication defines the configuration to deduplicate the logs of exceptions sharing the same fingerprint.
+type LogsDeduplication struct {
+	// Enabled holds back the logs of exceptions until the end of the interval, emitting a single log
+	// per service and fingerprint with the exception.count attribute set to the number of occurrences.
+	Enabled bool `mapstructure:"enabled"`
+	// Interval is the time during which the occurrences of an exception are deduplicated.
+	Interval time.Duration `mapstructure:"interval"`
 }
 
 var _ component.ConfigValidator = (*Config)(nil)
 
 // Validate checks if the connector configuration is valid
 func (c Config) Validate() error {
-	err := validateDimensions(c.Dimensions)
+	err := validateDimensions(c.Dimensions, c.Fingerprint)
 	if err != nil {
 		return err
 	}
+	if c.LogsDeduplication.Enabled && c.LogsDeduplication.Interval <= 0 {
+		return errors.New("logs_deduplication interval must be greater than 0")
+	}
 	return nil
 }
 
 // validateDimensions checks duplicates for reserved dimensions and additional dimensions.
-func validateDimensions(dimensions []Dimension) error {
+func validateDimensions(dimensions []Dimension, fingerprint bool) error {
 	labelNames := make(map[string]struct{})
 	for _, key := range []string{serviceNameKey, spanKindKey, statusCodeKey} {
 		labelNames[key] = struct{}{}
 	}
+	if fingerprint {
+		labelNames[exceptionFingerprintKey] = struct{}{}
+	}
 
 	for _, key := range dimensions {
 		if _, ok := labelNames[key.Name]; ok {
@@ -6,6 +6,7 @@ package exceptionsconnector
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -22,8 +23,9 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 
 	tests := []struct {
-		id       component.ID
-		expected component.Config
+		id          component.ID
+		expected    component.Config
+		expectedErr string
 	}{
 		{
 			id:       component.NewIDWithName(metadata.Type, "default"),
@@ -36,8 +38,17 @@ func TestLoadConfig(t *testing.T) {
 					{Name: exceptionTypeKey},
 					{Name: exceptionMessageKey},
 				},
+				Fingerprint: true,
+				LogsDeduplication: LogsDeduplication{
+					Enabled:  true,
+					Interval: 30 * time.Second,
+				},
 			},
 		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_deduplication_interval"),
+			expectedErr: "logs_deduplication interval must be greater than 0",
+		},
 	}
 
 	for _, tt := range tests {
@@ -49,6 +60,10 @@ func TestLoadConfig(t *testing.T) {
 			require.NoError(t, err)
 			err = component.UnmarshalConfig(sub, cfg)
 			assert.NoError(t, err)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
 			assert.NoError(t, component.ValidateConfig(cfg))
 			assert.Equal(t, tt.expected, cfg)
 		})
@@ -59,6 +74,7 @@ func TestValidateDimensions(t *testing.T) {
 	for _, tc := range []struct {
 		name        string
 		dimensions  []Dimension
+		fingerprint bool
 		expectedErr string
 	}{
 		{
@@ -87,9 +103,17 @@ func TestValidateDimensions(t *testing.T) {
 			},
 			expectedErr: "duplicate dimension name \"service_name\"",
 		},
+		{
+			name: "duplicate dimension with fingerprint",
+			dimensions: []Dimension{
+				{Name: "exception.fingerprint"},
+			},
+			fingerprint: true,
+			expectedErr: "duplicate dimension name \"exception.fingerprint\"",
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			err := validateDimensions(tc.dimensions)
+			err := validateDimensions(tc.dimensions, tc.fingerprint)
 			if tc.expectedErr != "" {
 				assert.EqualError(t, err, tc.expectedErr)
 			} else {
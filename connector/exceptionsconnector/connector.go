@@ -13,6 +13,9 @@ const (
 	exceptionTypeKey       = conventions.AttributeExceptionType
 	exceptionMessageKey    = conventions.AttributeExceptionMessage
 	exceptionStacktraceKey = conventions.AttributeExceptionStacktrace
+	// exceptionFingerprintKey and exceptionCountKey are not part of the semantic conventions.
+	exceptionFingerprintKey = "exception.fingerprint"
+	exceptionCountKey       = "exception.count"
 	// TODO(marctc): formalize these constants in the OpenTelemetry specification.
 	spanKindKey   = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey = "status.code" // OpenTelemetry non-standard constant.
@@ -6,6 +6,8 @@ package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-c
 import (
 	"context"
 	"strings"
+	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -25,19 +27,142 @@ type logsConnector struct {
 	dimensions []dimension
 
 	logsConsumer consumer.Logs
-	component.StartFunc
-	component.ShutdownFunc
 
 	logger *zap.Logger
+
+	// pending holds the logs of the first occurrence of each exception
+	// until the end of the deduplication interval.
+	lock        sync.Mutex
+	pending     plog.Logs
+	occurrences map[string]*occurrence
+
+	shutdownCh chan struct{}
+	done       sync.WaitGroup
+}
+
+// occurrence tracks the number of occurrences of a deduplicated exception.
+type occurrence struct {
+	logRecord plog.LogRecord
+	count     int64
 }
 
 func newLogsConnector(logger *zap.Logger, config component.Config) *logsConnector {
 	cfg := config.(*Config)
 
 	return &logsConnector{
-		logger:     logger,
-		config:     *cfg,
-		dimensions: newDimensions(cfg.Dimensions),
+		logger:      logger,
+		config:      *cfg,
+		dimensions:  newDimensions(cfg.Dimensions),
+		pending:     plog.NewLogs(),
+		occurrences: make(map[string]*occurrence),
+		shutdownCh:  make(chan struct{}),
+	}
+}
+
+// Start implements the component.Component interface.
+func (c *logsConnector) Start(context.Context, component.Host) error {
+	if !c.config.LogsDeduplication.Enabled {
+		return nil
+	}
+
+	c.done.Add(1)
+	go c.flushLoop(c.config.LogsDeduplication.Interval)
+	return nil
+}
+
+// Shutdown implements the component.Component interface.
+func (c *logsConnector) Shutdown(ctx context.Context) error {
+	if !c.config.LogsDeduplication.Enabled {
+		return nil
+	}
+
+	close(c.shutdownCh)
+	c.done.Wait()
+	return c.flush(ctx)
+}
+
+// flushLoop periodically emits the deduplicated logs.
+func (c *logsConnector) flushLoop(d time.Duration) {
+	defer c.done.Done()
+
+	t := time.NewTicker(d)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			// Errors are already logged by exportLogs.
+			_ = c.flush(context.Background())
+		case <-c.shutdownCh:
+			return
+		}
+	}
+}
+
+// flush emits the logs of the exceptions that occurred during the current interval.
+func (c *logsConnector) flush(ctx context.Context) error {
+	c.lock.Lock()
+	if len(c.occurrences) == 0 {
+		c.lock.Unlock()
+		return nil
+	}
+	for _, occ := range c.occurrences {
+		occ.logRecord.Attributes().PutInt(exceptionCountKey, occ.count)
+	}
+	ld := c.pending
+	c.pending = plog.NewLogs()
+	c.occurrences = make(map[string]*occurrence)
+	c.lock.Unlock()
+
+	return c.exportLogs(ctx, ld)
+}
+
+// deduplicateTraces adds the logs of the exceptions seen for the first time during the current
+// interval to the pending logs, and counts the occurrences of the exceptions already seen.
+func (c *logsConnector) deduplicateTraces(traces ptrace.Traces) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i := 0; i < traces.ResourceSpans().Len(); i++ {
+		rspans := traces.ResourceSpans().At(i)
+		resourceAttr := rspans.Resource().Attributes()
+		serviceAttr, ok := resourceAttr.Get(conventions.AttributeServiceName)
+		if !ok {
+			continue
+		}
+		serviceName := serviceAttr.Str()
+		ilsSlice := rspans.ScopeSpans()
+		for j := 0; j < ilsSlice.Len(); j++ {
+			ils := ilsSlice.At(j)
+			// The scope is only added to the pending logs if one of its exceptions wasn't seen yet.
+			var sl plog.ScopeLogs
+			hasScope := false
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					if event.Name() != eventNameExc {
+						continue
+					}
+
+					fp := fingerprint(event.Attributes())
+					key := serviceName + metricKeySeparator + fp
+					if occ, ok := c.occurrences[key]; ok {
+						occ.count++
+						continue
+					}
+
+					if !hasScope {
+						sl = c.newScopeLogs(c.pending)
+						ils.Scope().CopyTo(sl.Scope())
+						hasScope = true
+					}
+					logRecord := c.attrToLogRecord(sl, serviceName, span, event)
+					logRecord.Attributes().PutStr(exceptionFingerprintKey, fp)
+					c.occurrences[key] = &occurrence{logRecord: logRecord, count: 1}
+				}
+			}
+		}
 	}
 }
 
@@ -49,6 +174,11 @@ func (c *logsConnector) Capabilities() consumer.Capabilities {
 // ConsumeTraces implements the consumer.Traces interface.
 // It aggregates the trace data to generate logs.
 func (c *logsConnector) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
+	if c.config.LogsDeduplication.Enabled {
+		c.deduplicateTraces(traces)
+		return nil
+	}
+
 	ld := plog.NewLogs()
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
 		rspans := traces.ResourceSpans().At(i)
@@ -113,6 +243,10 @@ func (c *logsConnector) attrToLogRecord(sl plog.ScopeLogs, serviceName string, s
 		}
 	}
 
+	if c.config.Fingerprint {
+		logRecord.Attributes().PutStr(exceptionFingerprintKey, fingerprint(eventAttrs))
+	}
+
 	// Add stacktrace to the log record.
 	logRecord.Attributes().PutStr(exceptionStacktraceKey, getValue(eventAttrs, exceptionStacktraceKey))
 
@@ -6,6 +6,7 @@ package exceptionsconnector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -46,6 +47,43 @@ func TestConnectorLogConsumeTraces(t *testing.T) {
 	}
 }
 
+func TestConnectorLogConsumeTracesDeduplicated(t *testing.T) {
+	lsink := new(consumertest.LogsSink)
+
+	p := newTestLogsConnector(lsink, zaptest.NewLogger(t))
+	p.config.LogsDeduplication = LogsDeduplication{Enabled: true, Interval: time.Hour}
+
+	ctx := metadata.NewIncomingContext(context.Background(), nil)
+	require.NoError(t, p.Start(ctx, componenttest.NewNopHost()))
+
+	// Two traces with three occurrences each: two from service-a and one from service-b.
+	require.NoError(t, p.ConsumeTraces(ctx, buildSampleTrace()))
+	require.NoError(t, p.ConsumeTraces(ctx, buildSampleTrace()))
+	assert.Len(t, lsink.AllLogs(), 0)
+
+	// Logs are emitted at the end of the interval, which is forced by shutting down.
+	require.NoError(t, p.Shutdown(ctx))
+	logs := lsink.AllLogs()
+	require.Len(t, logs, 1)
+	require.Equal(t, 2, logs[0].LogRecordCount())
+
+	counts := map[string]int64{}
+	rls := logs[0].ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		lrs := rls.At(i).ScopeLogs().At(0).LogRecords()
+		for j := 0; j < lrs.Len(); j++ {
+			attrs := lrs.At(j).Attributes()
+			service, _ := attrs.Get(serviceNameKey)
+			count, ok := attrs.Get(exceptionCountKey)
+			require.True(t, ok)
+			_, ok = attrs.Get(exceptionFingerprintKey)
+			assert.True(t, ok)
+			counts[service.Str()] = count.Int()
+		}
+	}
+	assert.Equal(t, map[string]int64{"service-a": 4, "service-b": 2}, counts)
+}
+
 func newTestLogsConnector(lcon consumer.Logs, logger *zap.Logger) *logsConnector {
 	cfg := &Config{
 		Dimensions: []Dimension{
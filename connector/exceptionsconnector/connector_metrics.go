@@ -56,7 +56,7 @@ func newMetricsConnector(logger *zap.Logger, config component.Config) *metricsCo
 	return &metricsConnector{
 		logger:         logger,
 		config:         *cfg,
-		dimensions:     newDimensions(cfg.Dimensions),
+		dimensions:     newDimensions(metricsDimensions(cfg)),
 		keyBuf:         bytes.NewBuffer(make([]byte, 0, 1024)),
 		startTimestamp: pcommon.NewTimestampFromTime(time.Now()),
 		exceptions:     make(map[string]*excVal),
@@ -92,9 +92,15 @@ func (c *metricsConnector) ConsumeTraces(ctx context.Context, traces ptrace.Trac
 
 						c.keyBuf.Reset()
 						buildKey(c.keyBuf, serviceName, span, c.dimensions, eventAttrs)
-						key := c.keyBuf.String()
-
 						attrs := buildDimensionKVs(c.dimensions, serviceName, span, eventAttrs)
+
+						if c.config.Fingerprint {
+							fp := fingerprint(eventAttrs)
+							concatDimensionValue(c.keyBuf, fp, true)
+							attrs.PutStr(exceptionFingerprintKey, fp)
+						}
+
+						key := c.keyBuf.String()
 						c.addException(key, attrs)
 					}
 				}
@@ -156,6 +162,21 @@ func (c *metricsConnector) addException(excKey string, attrs pcommon.Map) {
 	exc.count++
 }
 
+// metricsDimensions returns the additional dimensions of the metrics. When fingerprint is enabled,
+// the exception.fingerprint dimension replaces exception.message, to bound the cardinality of the metrics.
+func metricsDimensions(cfg *Config) []Dimension {
+	if !cfg.Fingerprint {
+		return cfg.Dimensions
+	}
+	dims := make([]Dimension, 0, len(cfg.Dimensions))
+	for _, d := range cfg.Dimensions {
+		if d.Name != exceptionMessageKey {
+			dims = append(dims, d)
+		}
+	}
+	return dims
+}
+
 func buildDimensionKVs(dimensions []dimension, serviceName string, span ptrace.Span, eventAttrs pcommon.Map) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.EnsureCapacity(3 + len(dimensions))
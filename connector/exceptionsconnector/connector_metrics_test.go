@@ -89,6 +89,47 @@ func TestConnectorConsumeTraces(t *testing.T) {
 	}
 }
 
+func TestConnectorConsumeTracesFingerprint(t *testing.T) {
+	msink := &consumertest.MetricsSink{}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Fingerprint = true
+	p := newMetricsConnector(zaptest.NewLogger(t), cfg)
+	p.metricsConsumer = msink
+
+	traces := buildSampleTrace()
+	// The same exception with a different message is counted in the same series.
+	event := traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Events().AppendEmpty()
+	event.SetName("exception")
+	event.Attributes().PutStr(exceptionTypeKey, "Exception")
+	event.Attributes().PutStr(exceptionMessageKey, "Another exception message")
+	event.Attributes().PutStr(exceptionStacktraceKey, "Exception stacktrace")
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+
+	metrics := msink.AllMetrics()
+	require.Len(t, metrics, 1)
+	dps := metrics[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	require.Equal(t, 3, dps.Len())
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		fp, ok := dp.Attributes().Get(exceptionFingerprintKey)
+		require.True(t, ok)
+		assert.Equal(t, fingerprint(event.Attributes()), fp.Str())
+		// the fingerprint replaces the message of the default dimensions
+		_, ok = dp.Attributes().Get(exceptionMessageKey)
+		assert.False(t, ok)
+
+		kind, _ := dp.Attributes().Get(spanKindKey)
+		service, _ := dp.Attributes().Get(serviceNameKey)
+		if service.Str() == "service-a" && kind.Str() == "SPAN_KIND_SERVER" {
+			assert.Equal(t, int64(2), dp.IntValue())
+		} else {
+			assert.Equal(t, int64(1), dp.IntValue())
+		}
+	}
+}
+
 func BenchmarkConnectorConsumeTraces(b *testing.B) {
 	msink := &consumertest.MetricsSink{}
 
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/exceptionsconnector"
+
+import (
+	"encoding/hex"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// numberRegex matches the numbers of a stacktrace, such as line numbers, offsets or memory addresses.
+var numberRegex = regexp.MustCompile(`0[xX][0-9a-fA-F]+|[0-9]+`)
+
+// fingerprint identifies an exception by its type and the frames of its stacktrace.
+// The message of the exception and the numbers found in the stacktrace are left out
+// as they often differ between occurrences of the same exception.
+func fingerprint(eventAttrs pcommon.Map) string {
+	stacktrace := getValue(eventAttrs, exceptionStacktraceKey)
+	// Most runtimes repeat the message in the stacktrace.
+	if msg := getValue(eventAttrs, exceptionMessageKey); msg != "" {
+		stacktrace = strings.ReplaceAll(stacktrace, msg, "")
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(getValue(eventAttrs, exceptionTypeKey)))
+	for _, frame := range strings.Split(stacktrace, "\n") {
+		frame = strings.TrimSpace(numberRegex.ReplaceAllString(frame, ""))
+		if frame == "" {
+			continue
+		}
+		_, _ = h.Write([]byte{'\n'})
+		_, _ = h.Write([]byte(frame))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package exceptionsconnector
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestFingerprint(t *testing.T) {
+	newAttrs := func(excType, message, stacktrace string) pcommon.Map {
+		attrs := pcommon.NewMap()
+		attrs.PutStr(exceptionTypeKey, excType)
+		attrs.PutStr(exceptionMessageKey, message)
+		attrs.PutStr(exceptionStacktraceKey, stacktrace)
+		return attrs
+	}
+
+	javaStacktrace := func(message string, line int) string {
+		return "java.lang.IllegalStateException: " + message + "\n" +
+			"\tat com.example.Orders.find(Orders.java:" + strconv.Itoa(line) + ")\n" +
+			"\tat com.example.Handler.handle(Handler.java:17)\n"
+	}
+
+	base := fingerprint(newAttrs("java.lang.IllegalStateException", "order 1234 not found", javaStacktrace("order 1234 not found", 42)))
+
+	for _, tc := range []struct {
+		name  string
+		attrs pcommon.Map
+		equal bool
+	}{
+		{
+			name:  "different message",
+			attrs: newAttrs("java.lang.IllegalStateException", "order 5678 not found", javaStacktrace("order 5678 not found", 42)),
+			equal: true,
+		},
+		{
+			name:  "different line numbers",
+			attrs: newAttrs("java.lang.IllegalStateException", "order 1234 not found", javaStacktrace("order 1234 not found", 73)),
+			equal: true,
+		},
+		{
+			name:  "different type",
+			attrs: newAttrs("java.lang.IllegalArgumentException", "order 1234 not found", javaStacktrace("order 1234 not found", 42)),
+			equal: false,
+		},
+		{
+			name: "different frames",
+			attrs: newAttrs("java.lang.IllegalStateException", "order 1234 not found", "java.lang.IllegalStateException: order 1234 not found\n"+
+				"\tat com.example.Payments.find(Payments.java:42)\n"),
+			equal: false,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.equal {
+				assert.Equal(t, base, fingerprint(tc.attrs))
+			} else {
+				assert.NotEqual(t, base, fingerprint(tc.attrs))
+			}
+		})
+	}
+}
+
+func TestFingerprintAddresses(t *testing.T) {
+	attrs := func(stacktrace string) pcommon.Map {
+		m := pcommon.NewMap()
+		m.PutStr(exceptionTypeKey, "*errors.errorString")
+		m.PutStr(exceptionStacktraceKey, stacktrace)
+		return m
+	}
+
+	assert.Equal(t,
+		fingerprint(attrs("goroutine 1 [running]:\nmain.main()\n\t/app/main.go:12 +0x1d\n")),
+		fingerprint(attrs("goroutine 27 [running]:\nmain.main()\n\t/app/main.go:14 +0xfe\n")),
+	)
+}
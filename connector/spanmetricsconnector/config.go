@@ -46,6 +46,12 @@ type Config struct {
 	// Optional. See defaultDimensionsCacheSize in connector.go for the default value.
 	DimensionsCacheSize int `mapstructure:"dimensions_cache_size"`
 
+	// MaxSeries defines the maximum number of series per resource. Once the limit is reached,
+	// spans that would create a new series are aggregated into a single series with the
+	// otel.metric.overflow attribute set to true.
+	// Optional. Set to 0 (the default) to disable the limit.
+	MaxSeries int `mapstructure:"max_series"`
+
 	AggregationTemporality string `mapstructure:"aggregation_temporality"`
 
 	Histogram HistogramConfig `mapstructure:"histogram"`
@@ -96,6 +102,10 @@ func (c Config) Validate() error {
 		)
 	}
 
+	if c.MaxSeries < 0 {
+		return fmt.Errorf("invalid max_series: %v, the maximum number of series should not be negative", c.MaxSeries)
+	}
+
 	if c.Histogram.Explicit != nil && c.Histogram.Exponential != nil {
 		return errors.New("use either `explicit` or `exponential` buckets histogram")
 	}
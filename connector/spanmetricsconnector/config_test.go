@@ -48,6 +48,7 @@ func TestLoadConfig(t *testing.T) {
 					{Name: "http.status_code", Default: (*string)(nil)},
 				},
 				DimensionsCacheSize:  1500,
+				MaxSeries:            500,
 				MetricsFlushInterval: 30 * time.Second,
 				Exemplars: ExemplarsConfig{
 					Enabled: true,
@@ -85,6 +86,10 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "invalid_histogram_unit"),
 			errorMessage: "unknown Unit \"h\"",
 		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_max_series"),
+			errorMessage: "invalid max_series: -1, the maximum number of series should not be negative",
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "exemplars_enabled"),
 			expected: &Config{
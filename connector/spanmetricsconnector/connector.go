@@ -17,9 +17,12 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
@@ -30,8 +33,15 @@ const (
 	spanNameKey        = "span.name"   // OpenTelemetry non-standard constant.
 	spanKindKey        = "span.kind"   // OpenTelemetry non-standard constant.
 	statusCodeKey      = "status.code" // OpenTelemetry non-standard constant.
+	overflowKey        = "otel.metric.overflow"
 	metricKeySeparator = string(byte(0))
 
+	// overflowMetricKey is the metric key of the series spans are aggregated into once max_series is reached.
+	// It starts with the separator so that it can't be produced by buildKey for a non-empty service name.
+	overflowMetricKey = metrics.Key(metricKeySeparator + overflowKey)
+
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+
 	defaultDimensionsCacheSize = 1000
 
 	metricNameDuration = "duration"
@@ -61,6 +71,9 @@ type connectorImp struct {
 	// e.g. { "foo/barOK": { "serviceName": "foo", "span.name": "/bar", "status_code": "OK" }}
 	metricKeyToDimensions *cache.Cache[metrics.Key, pcommon.Map]
 
+	// Number of spans aggregated into the overflow series.
+	overflowSpans metric.Int64Counter
+
 	ticker  *clock.Ticker
 	done    chan struct{}
 	started bool
@@ -94,7 +107,8 @@ func newDimensions(cfgDims []Dimension) []dimension {
 	return dims
 }
 
-func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Ticker) (*connectorImp, error) {
+func newConnector(set component.TelemetrySettings, config component.Config, ticker *clock.Ticker) (*connectorImp, error) {
+	logger := set.Logger
 	logger.Info("Building spanmetrics connector")
 	cfg := config.(*Config)
 
@@ -103,8 +117,17 @@ func newConnector(logger *zap.Logger, config component.Config, ticker *clock.Tic
 		return nil, err
 	}
 
+	overflowSpans, err := set.MeterProvider.Meter(scopeName).Int64Counter(
+		metadata.Type+"_overflow_spans",
+		metric.WithDescription("Number of spans aggregated into the overflow series because max_series was reached."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	return &connectorImp{
 		logger:                logger,
+		overflowSpans:         overflowSpans,
 		config:                *cfg,
 		startTimestamp:        pcommon.NewTimestampFromTime(time.Now()),
 		resourceMetrics:       make(map[resourceKey]*resourceMetrics),
@@ -202,9 +225,9 @@ func (p *connectorImp) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces implements the consumer.Traces interface.
 // It aggregates the trace data to generate metrics.
-func (p *connectorImp) ConsumeTraces(_ context.Context, traces ptrace.Traces) error {
+func (p *connectorImp) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
 	p.lock.Lock()
-	p.aggregateMetrics(traces)
+	p.aggregateMetrics(ctx, traces)
 	p.lock.Unlock()
 	return nil
 }
@@ -276,7 +299,10 @@ func (p *connectorImp) resetState() {
 // Each metric is identified by a key that is built from the service name
 // and span metadata such as name, kind, status_code and any additional
 // dimensions the user has configured.
-func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
+//
+// Once a resource has max_series series, spans that would create a new
+// series are aggregated into a single overflow series instead.
+func (p *connectorImp) aggregateMetrics(ctx context.Context, traces ptrace.Traces) {
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
 		rspans := traces.ResourceSpans().At(i)
 		resourceAttr := rspans.Resource().Attributes()
@@ -291,6 +317,7 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 
 		unitDivider := unitDivider(p.config.Histogram.Unit)
 		serviceName := serviceAttr.Str()
+		overflowSpans := int64(0)
 		ilsSlice := rspans.ScopeSpans()
 		for j := 0; j < ilsSlice.Len(); j++ {
 			ils := ilsSlice.At(j)
@@ -306,10 +333,17 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				}
 				key := p.buildKey(serviceName, span, p.dimensions, resourceAttr)
 
-				attributes, ok := p.metricKeyToDimensions.Get(key)
-				if !ok {
-					attributes = p.buildAttributes(serviceName, span, resourceAttr)
-					p.metricKeyToDimensions.Add(key, attributes)
+				var attributes pcommon.Map
+				if p.isSeriesLimitReached(sums, key) {
+					key = overflowMetricKey
+					attributes = p.buildOverflowAttributes(serviceName)
+					overflowSpans++
+				} else {
+					attributes, ok = p.metricKeyToDimensions.Get(key)
+					if !ok {
+						attributes = p.buildAttributes(serviceName, span, resourceAttr)
+						p.metricKeyToDimensions.Add(key, attributes)
+					}
 				}
 				if !p.config.Histogram.Disable {
 					// aggregate histogram metrics
@@ -323,7 +357,35 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 				s.Add(1)
 			}
 		}
+		if overflowSpans > 0 {
+			p.overflowSpans.Add(ctx, overflowSpans, metric.WithAttributes(attribute.String(serviceNameKey, serviceName)))
+		}
+	}
+}
+
+// isSeriesLimitReached returns true if the span with the given key must be
+// aggregated into the overflow series, i.e. if it doesn't belong to an existing
+// series and the resource already has max_series series, not counting the overflow series.
+func (p *connectorImp) isSeriesLimitReached(sums metrics.SumMetrics, key metrics.Key) bool {
+	if p.config.MaxSeries <= 0 || sums.Contains(key) {
+		return false
+	}
+	series := sums.Len()
+	if sums.Contains(overflowMetricKey) {
+		series--
+	}
+	return series >= p.config.MaxSeries
+}
+
+// buildOverflowAttributes builds the attributes of the overflow series.
+// Only the service name is kept, as all other dimensions differ between the aggregated spans.
+func (p *connectorImp) buildOverflowAttributes(serviceName string) pcommon.Map {
+	attr := pcommon.NewMap()
+	if !contains(p.config.ExcludeDimensions, serviceNameKey) {
+		attr.PutStr(serviceNameKey, serviceName)
 	}
+	attr.PutBool(overflowKey, true)
+	return attr
 }
 
 func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics.Histogram) {
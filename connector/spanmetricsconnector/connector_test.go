@@ -15,6 +15,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/tilinna/clock"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/connector/connectortest"
 	"go.opentelemetry.io/collector/consumer"
@@ -438,7 +439,7 @@ func disabledHistogramsConfig() HistogramConfig {
 func TestBuildKeySameServiceNameCharSequence(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	span0 := ptrace.NewSpan()
@@ -458,7 +459,7 @@ func TestBuildKeyExcludeDimensionsAll(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
 	cfg.ExcludeDimensions = []string{"span.kind", "service.name", "span.name", "status.code"}
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	span0 := ptrace.NewSpan()
@@ -471,7 +472,7 @@ func TestBuildKeyExcludeWrongDimensions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
 	cfg.ExcludeDimensions = []string{"span.kind", "service.name.wrong.name", "span.name", "status.code"}
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	span0 := ptrace.NewSpan()
@@ -483,7 +484,7 @@ func TestBuildKeyExcludeWrongDimensions(t *testing.T) {
 func TestBuildKeyWithDimensions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig().(*Config)
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	require.NoError(t, err)
 
 	defaultFoo := pcommon.NewValueStr("bar")
@@ -625,7 +626,7 @@ func TestConnectorCapabilities(t *testing.T) {
 	cfg := factory.CreateDefaultConfig().(*Config)
 
 	// Test
-	c, err := newConnector(zaptest.NewLogger(t), cfg, nil)
+	c, err := newConnector(newTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
 	c.metricsConsumer = new(consumertest.MetricsSink)
 	assert.NoError(t, err)
 	caps := c.Capabilities()
@@ -942,6 +943,65 @@ func TestExcludeDimensionsConsumeTraces(t *testing.T) {
 
 }
 
+func TestMaxSeriesConsumeTraces(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxSeries = 2
+	p, err := newConnector(newTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
+	require.NoError(t, err)
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, "service-a")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for _, name := range []string{"op-1", "op-2", "op-3", "op-1", "op-4", "op-3"} {
+		span := spans.AppendEmpty()
+		span.SetName(name)
+		span.SetKind(ptrace.SpanKindServer)
+	}
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), traces))
+
+	md := p.buildMetrics()
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	calls := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, metricNameCalls, calls.Name())
+
+	counts := make(map[string]int64)
+	for i := 0; i < calls.Sum().DataPoints().Len(); i++ {
+		dp := calls.Sum().DataPoints().At(i)
+		if overflow, ok := dp.Attributes().Get(overflowKey); ok {
+			assert.True(t, overflow.Bool())
+			assert.Equal(t, map[string]any{serviceNameKey: "service-a", overflowKey: true}, dp.Attributes().AsRaw())
+			counts[overflowKey] = dp.IntValue()
+			continue
+		}
+		name, ok := dp.Attributes().Get(spanNameKey)
+		require.True(t, ok)
+		counts[name.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"op-1": 2, "op-2": 1, overflowKey: 3}, counts)
+
+	duration := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1)
+	assert.Equal(t, 3, duration.Histogram().DataPoints().Len())
+}
+
+func TestIsSeriesLimitReached(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxSeries = 2
+	p, err := newConnector(newTelemetrySettings(zaptest.NewLogger(t)), cfg, nil)
+	require.NoError(t, err)
+
+	sums := metrics.NewSumMetrics()
+	sums.GetOrCreate("op-1", pcommon.NewMap())
+	sums.GetOrCreate(overflowMetricKey, pcommon.NewMap())
+
+	// the overflow series doesn't count towards max_series
+	assert.False(t, p.isSeriesLimitReached(sums, "op-2"))
+	sums.GetOrCreate("op-2", pcommon.NewMap())
+	assert.False(t, p.isSeriesLimitReached(sums, "op-2"))
+	assert.True(t, p.isSeriesLimitReached(sums, "op-3"))
+}
+
 func newConnectorImp(t *testing.T, mcon consumer.Metrics, defaultNullValue *string, histogramConfig func() HistogramConfig, exemplarsConfig func() ExemplarsConfig, temporality string, logger *zap.Logger, ticker *clock.Ticker, excludedDimensions ...string) *connectorImp {
 
 	cfg := &Config{
@@ -967,7 +1027,7 @@ func newConnectorImp(t *testing.T, mcon consumer.Metrics, defaultNullValue *stri
 			{regionResourceAttrName, nil},
 		},
 	}
-	c, err := newConnector(logger, cfg, ticker)
+	c, err := newConnector(newTelemetrySettings(logger), cfg, ticker)
 	require.NoError(t, err)
 	c.metricsConsumer = mcon
 	return c
@@ -977,6 +1037,12 @@ func stringp(str string) *string {
 	return &str
 }
 
+func newTelemetrySettings(logger *zap.Logger) component.TelemetrySettings {
+	set := componenttest.NewNopTelemetrySettings()
+	set.Logger = logger
+	return set
+}
+
 func TestConnectorConsumeTracesEvictedCacheKey(t *testing.T) {
 	// Prepare
 	traces0 := ptrace.NewTraces()
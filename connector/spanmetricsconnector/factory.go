@@ -36,7 +36,7 @@ func createDefaultConfig() component.Config {
 }
 
 func createTracesToMetricsConnector(ctx context.Context, params connector.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
-	c, err := newConnector(params.Logger, cfg, metricsTicker(ctx, cfg))
+	c, err := newConnector(params.TelemetrySettings, cfg, metricsTicker(ctx, cfg))
 	if err != nil {
 		return nil, err
 	}
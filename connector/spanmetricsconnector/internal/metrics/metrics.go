@@ -257,6 +257,17 @@ func (m *SumMetrics) GetOrCreate(key Key, attributes pcommon.Map) *Sum {
 	return s
 }
 
+// Contains returns true if a sum with the given key exists.
+func (m *SumMetrics) Contains(key Key) bool {
+	_, ok := m.metrics[key]
+	return ok
+}
+
+// Len returns the number of sums.
+func (m *SumMetrics) Len() int {
+	return len(m.metrics)
+}
+
 func (m *SumMetrics) BuildMetrics(
 	metric pmetric.Metric,
 	start pcommon.Timestamp,
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/multierr"
+)
+
+// Forward protocol modes used to send the events.
+const (
+	modeMessage       = "message"
+	modeForward       = "forward"
+	modePackedForward = "packed_forward"
+)
+
+const compressionGzip = "gzip"
+
+var (
+	errEmptyEndpoint          = errors.New("endpoint must be specified")
+	errEmptyTag               = errors.New("tag must be specified")
+	errUnsupportedMode        = fmt.Errorf("mode must be one of %q, %q or %q", modeMessage, modeForward, modePackedForward)
+	errUnsupportedCompression = fmt.Errorf("compression must be empty or %q", compressionGzip)
+	errCompressionMode        = fmt.Errorf("compression is only supported with mode %q", modePackedForward)
+	errNegativeTimeout        = errors.New("connection_timeout and ack_timeout cannot be negative")
+)
+
+// Config defines configuration for the Fluent Forward exporter.
+type Config struct {
+	// The address of the Fluentd or Fluent Bit server, of the form `<host>:<port>` (TCP)
+	// or `unix://<socket_path>` (Unix domain socket).
+	Endpoint string `mapstructure:"endpoint"`
+
+	// ConnectionTimeout is the maximum duration of the connection to the server, including
+	// the handshake when a shared key is configured.
+	ConnectionTimeout time.Duration `mapstructure:"connection_timeout"`
+
+	// TLSSetting is the TLS configuration of the connection, which is disabled by default.
+	TLSSetting configtls.TLSClientSetting `mapstructure:"tls"`
+
+	// Mode is the Forward protocol mode used to send the log records:
+	// message, forward or packed_forward.
+	Mode string `mapstructure:"mode"`
+
+	// Compression of the entries sent in packed_forward mode, only gzip is supported.
+	Compression string `mapstructure:"compression"`
+
+	// Tag is the tag of the log records which do not have a `fluent.tag` attribute.
+	Tag string `mapstructure:"tag"`
+
+	// RequireAck requests the server to acknowledge each event with its chunk option.
+	RequireAck bool `mapstructure:"require_ack"`
+
+	// AckTimeout is the maximum duration to wait for the acknowledgment of an event.
+	AckTimeout time.Duration `mapstructure:"ack_timeout"`
+
+	// SharedKey enables the handshake phase of the Forward protocol with the given key.
+	SharedKey configopaque.String `mapstructure:"shared_key"`
+
+	// Hostname is the client hostname sent during the handshake, which defaults to the hostname of the host.
+	Hostname string `mapstructure:"hostname"`
+
+	// Username and Password are sent during the handshake when the server requires user authentication.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+}
+
+// Validate the configuration for errors. This is required by component.Config.
+func (cfg *Config) Validate() error {
+	var errs error
+	if cfg.Endpoint == "" {
+		errs = multierr.Append(errs, errEmptyEndpoint)
+	}
+	if cfg.Tag == "" {
+		errs = multierr.Append(errs, errEmptyTag)
+	}
+
+	switch cfg.Mode {
+	case modeMessage, modeForward, modePackedForward:
+	default:
+		errs = multierr.Append(errs, errUnsupportedMode)
+	}
+
+	switch cfg.Compression {
+	case "":
+	case compressionGzip:
+		if cfg.Mode != modePackedForward {
+			errs = multierr.Append(errs, errCompressionMode)
+		}
+	default:
+		errs = multierr.Append(errs, errUnsupportedCompression)
+	}
+
+	if cfg.ConnectionTimeout < 0 || cfg.AckTimeout < 0 {
+		errs = multierr.Append(errs, errNegativeTimeout)
+	}
+	return errs
+}
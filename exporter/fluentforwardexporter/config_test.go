@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	defaultCfg := createDefaultConfig().(*Config)
+	defaultCfg.Endpoint = "localhost:24224"
+
+	allSettingsCfg := createDefaultConfig().(*Config)
+	allSettingsCfg.Endpoint = "fluentd.example.com:24224"
+	allSettingsCfg.ConnectionTimeout = 5 * time.Second
+	allSettingsCfg.Mode = modePackedForward
+	allSettingsCfg.Compression = compressionGzip
+	allSettingsCfg.Tag = "app.logs"
+	allSettingsCfg.RequireAck = true
+	allSettingsCfg.AckTimeout = 10 * time.Second
+	allSettingsCfg.SharedKey = "secret"
+	allSettingsCfg.Hostname = "collector"
+	allSettingsCfg.Username = "user"
+	allSettingsCfg.Password = "password"
+	allSettingsCfg.TLSSetting = configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{
+			CAFile: "testdata/ca.crt",
+		},
+	}
+	allSettingsCfg.TimeoutSettings = exporterhelper.TimeoutSettings{Timeout: 20 * time.Second}
+	allSettingsCfg.QueueSettings = exporterhelper.QueueSettings{Enabled: true, NumConsumers: 2, QueueSize: 10}
+	allSettingsCfg.RetrySettings.Enabled = false
+
+	tests := []struct {
+		id       component.ID
+		expected component.Config
+	}{
+		{
+			id:       component.NewID(metadata.Type),
+			expected: defaultCfg,
+		},
+		{
+			id:       component.NewIDWithName(metadata.Type, "allsettings"),
+			expected: allSettingsCfg,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "missing endpoint and tag",
+			modify: func(cfg *Config) { cfg.Endpoint = ""; cfg.Tag = "" },
+			err:    "endpoint must be specified; tag must be specified",
+		},
+		{
+			name:   "unsupported mode",
+			modify: func(cfg *Config) { cfg.Mode = "packed" },
+			err:    `mode must be one of "message", "forward" or "packed_forward"`,
+		},
+		{
+			name:   "unsupported compression",
+			modify: func(cfg *Config) { cfg.Mode = modePackedForward; cfg.Compression = "zstd" },
+			err:    `compression must be empty or "gzip"`,
+		},
+		{
+			name:   "compression without packed_forward",
+			modify: func(cfg *Config) { cfg.Compression = compressionGzip },
+			err:    `compression is only supported with mode "packed_forward"`,
+		},
+		{
+			name:   "negative timeout",
+			modify: func(cfg *Config) { cfg.AckTimeout = -time.Second },
+			err:    "connection_timeout and ack_timeout cannot be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:24224"
+			tt.modify(cfg)
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
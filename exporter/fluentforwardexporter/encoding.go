@@ -0,0 +1,352 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// The log records are converted with the inverse of the conversion of the
+// fluentforward receiver, following
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1.
+const (
+	tagAttributeKey = "fluent.tag"
+	messageKey      = "message"
+)
+
+// eventTime is the EventTime extension of the Forward protocol, which carries
+// timestamps with a nanosecond precision.
+type eventTime time.Time
+
+func (*eventTime) ExtensionType() int8 {
+	return 0x00
+}
+
+func (*eventTime) Len() int {
+	return 8
+}
+
+func (e *eventTime) MarshalBinaryTo(b []byte) error {
+	binary.BigEndian.PutUint32(b[0:], uint32(time.Time(*e).Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(time.Time(*e).Nanosecond()))
+	return nil
+}
+
+func (e *eventTime) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return errors.New("data should be exactly 8 bytes")
+	}
+	secs := int64(binary.BigEndian.Uint32(b[0:]))
+	nanos := int64(binary.BigEndian.Uint32(b[4:]))
+	*e = eventTime(time.Unix(secs, nanos))
+	return nil
+}
+
+// entry is a log record converted to the time and the record of an event.
+type entry struct {
+	time   eventTime
+	record map[string]interface{}
+	// index is the position of the log record in the logs.
+	index int
+}
+
+// taggedEntries are the entries sharing the same tag.
+type taggedEntries struct {
+	tag     string
+	entries []entry
+}
+
+// event is an encoded event, with the chunk option to be acknowledged if any.
+type event struct {
+	data  []byte
+	chunk string
+	// records are the indexes of the log records in the event.
+	records []int
+}
+
+// groupByTag converts the log records into entries, grouped by tag in the order
+// in which the tags first appear.
+func groupByTag(ld plog.Logs, defaultTag string) []*taggedEntries {
+	var groups []*taggedEntries
+	byTag := map[string]*taggedEntries{}
+	index := 0
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				tag := tagOf(lr, defaultTag)
+				group, ok := byTag[tag]
+				if !ok {
+					group = &taggedEntries{tag: tag}
+					byTag[tag] = group
+					groups = append(groups, group)
+				}
+				group.entries = append(group.entries, entry{
+					time:   eventTime(timeOf(lr)),
+					record: recordOf(lr),
+					index:  index,
+				})
+				index++
+			}
+		}
+	}
+	return groups
+}
+
+// tagOf returns the tag from the `fluent.tag` attribute set by the receiver,
+// or the default tag.
+func tagOf(lr plog.LogRecord, defaultTag string) string {
+	if tag, ok := lr.Attributes().Get(tagAttributeKey); ok && tag.Type() == pcommon.ValueTypeStr && tag.Str() != "" {
+		return tag.Str()
+	}
+	return defaultTag
+}
+
+func timeOf(lr plog.LogRecord) time.Time {
+	if lr.Timestamp() != 0 {
+		return lr.Timestamp().AsTime()
+	}
+	if lr.ObservedTimestamp() != 0 {
+		return lr.ObservedTimestamp().AsTime()
+	}
+	return time.Now()
+}
+
+// recordOf returns the attributes of the log record, except for its tag, with
+// its body as the message.
+func recordOf(lr plog.LogRecord) map[string]interface{} {
+	record := make(map[string]interface{}, lr.Attributes().Len()+1)
+	lr.Attributes().Range(func(k string, v pcommon.Value) bool {
+		if k != tagAttributeKey {
+			record[k] = v.AsRaw()
+		}
+		return true
+	})
+	if lr.Body().Type() != pcommon.ValueTypeEmpty {
+		record[messageKey] = lr.Body().AsRaw()
+	}
+	return record
+}
+
+// appendEntry appends the [time, record] array of an entry.
+func appendEntry(b []byte, e entry) ([]byte, error) {
+	b = msgp.AppendArrayHeader(b, 2)
+	return appendTimeAndRecord(b, e)
+}
+
+func appendTimeAndRecord(b []byte, e entry) ([]byte, error) {
+	b, err := msgp.AppendExtension(b, &e.time)
+	if err != nil {
+		return nil, msgp.WrapError(err, "Time")
+	}
+	b, err = msgp.AppendMapStrIntf(b, e.record)
+	if err != nil {
+		return nil, msgp.WrapError(err, "Record")
+	}
+	return b, nil
+}
+
+// appendOptions appends the option map of an event, given as key value pairs.
+func appendOptions(b []byte, options ...interface{}) ([]byte, error) {
+	b = msgp.AppendMapHeader(b, uint32(len(options)/2))
+	for i := 0; i+1 < len(options); i += 2 {
+		b = msgp.AppendString(b, options[i].(string))
+		var err error
+		b, err = msgp.AppendIntf(b, options[i+1])
+		if err != nil {
+			return nil, msgp.WrapError(err, "Option")
+		}
+	}
+	return b, nil
+}
+
+// encoder encodes entries into events of the configured mode.
+type encoder struct {
+	mode       string
+	compress   bool
+	requireAck bool
+}
+
+func (enc *encoder) encode(groups []*taggedEntries) ([]event, error) {
+	var events []event
+	for _, group := range groups {
+		switch enc.mode {
+		case modeMessage:
+			for _, e := range group.entries {
+				ev, err := enc.messageEvent(group.tag, e)
+				if err != nil {
+					return nil, err
+				}
+				ev.records = []int{e.index}
+				events = append(events, ev)
+			}
+		case modeForward:
+			ev, err := enc.forwardEvent(group.tag, group.entries)
+			if err != nil {
+				return nil, err
+			}
+			ev.records = indexes(group.entries)
+			events = append(events, ev)
+		case modePackedForward:
+			ev, err := enc.packedForwardEvent(group.tag, group.entries)
+			if err != nil {
+				return nil, err
+			}
+			ev.records = indexes(group.entries)
+			events = append(events, ev)
+		}
+	}
+	return events, nil
+}
+
+func indexes(entries []entry) []int {
+	records := make([]int, len(entries))
+	for i, e := range entries {
+		records[i] = e.index
+	}
+	return records
+}
+
+// withRecords returns a copy of the logs holding only the log records at the
+// given indexes, in the order of groupByTag.
+func withRecords(ld plog.Logs, records map[int]struct{}) plog.Logs {
+	out := plog.NewLogs()
+	ld.CopyTo(out)
+	index := 0
+	out.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(plog.LogRecord) bool {
+				_, keep := records[index]
+				index++
+				return !keep
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return out
+}
+
+// messageEvent encodes [tag, time, record, option?].
+func (enc *encoder) messageEvent(tag string, e entry) (event, error) {
+	ev, err := enc.newEvent()
+	if err != nil {
+		return ev, err
+	}
+	b := msgp.AppendArrayHeader(nil, enc.arrayLen(3))
+	b = msgp.AppendString(b, tag)
+	if b, err = appendTimeAndRecord(b, e); err != nil {
+		return ev, err
+	}
+	if ev.chunk != "" {
+		if b, err = appendOptions(b, "chunk", ev.chunk); err != nil {
+			return ev, err
+		}
+	}
+	ev.data = b
+	return ev, nil
+}
+
+// forwardEvent encodes [tag, [[time, record], ...], option?].
+func (enc *encoder) forwardEvent(tag string, entries []entry) (event, error) {
+	ev, err := enc.newEvent()
+	if err != nil {
+		return ev, err
+	}
+	b := msgp.AppendArrayHeader(nil, enc.arrayLen(2))
+	b = msgp.AppendString(b, tag)
+	b = msgp.AppendArrayHeader(b, uint32(len(entries)))
+	for _, e := range entries {
+		if b, err = appendEntry(b, e); err != nil {
+			return ev, err
+		}
+	}
+	if ev.chunk != "" {
+		if b, err = appendOptions(b, "size", len(entries), "chunk", ev.chunk); err != nil {
+			return ev, err
+		}
+	}
+	ev.data = b
+	return ev, nil
+}
+
+// packedForwardEvent encodes [tag, bin([time, record]...), option], where the
+// entries may be compressed with gzip.
+func (enc *encoder) packedForwardEvent(tag string, entries []entry) (event, error) {
+	ev, err := enc.newEvent()
+	if err != nil {
+		return ev, err
+	}
+	var packed []byte
+	for _, e := range entries {
+		if packed, err = appendEntry(packed, e); err != nil {
+			return ev, err
+		}
+	}
+
+	options := []interface{}{"size", len(entries)}
+	if enc.compress {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		if _, err = gw.Write(packed); err != nil {
+			return ev, err
+		}
+		if err = gw.Close(); err != nil {
+			return ev, err
+		}
+		packed = buf.Bytes()
+		options = append(options, "compressed", compressionGzip)
+	}
+	if ev.chunk != "" {
+		options = append(options, "chunk", ev.chunk)
+	}
+
+	b := msgp.AppendArrayHeader(nil, 3)
+	b = msgp.AppendString(b, tag)
+	b = msgp.AppendBytes(b, packed)
+	if b, err = appendOptions(b, options...); err != nil {
+		return ev, err
+	}
+	ev.data = b
+	return ev, nil
+}
+
+// arrayLen returns the length of the event array, which includes the option
+// map only when a chunk is sent.
+func (enc *encoder) arrayLen(withoutOptions uint32) uint32 {
+	if enc.requireAck {
+		return withoutOptions + 1
+	}
+	return withoutOptions
+}
+
+func (enc *encoder) newEvent() (event, error) {
+	if !enc.requireAck {
+		return event{}, nil
+	}
+	chunk, err := newChunkID()
+	return event{chunk: chunk}, err
+}
+
+// newChunkID returns a unique id to be acknowledged by the server.
+func newChunkID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(id), nil
+}
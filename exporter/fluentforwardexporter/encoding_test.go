@@ -0,0 +1,168 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/fluentforwardreceiver"
+)
+
+var testTime = time.Date(2023, 7, 1, 12, 30, 45, 123456789, time.UTC)
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+
+	lr := lrs.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.Body().SetStr("first")
+	lr.Attributes().PutStr(tagAttributeKey, "app.web")
+	lr.Attributes().PutInt("status", 200)
+	lr.Attributes().PutDouble("duration", 1.5)
+	lr.Attributes().PutBool("cached", true)
+	lr.Attributes().PutEmptyMap("user").PutStr("name", "alice")
+	lr.Attributes().PutEmptySlice("tags").AppendEmpty().SetStr("a")
+
+	lr = lrs.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	lr.Body().SetStr("second")
+
+	lr = lrs.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime.Add(2 * time.Second)))
+	lr.Body().SetStr("third")
+	lr.Attributes().PutStr(tagAttributeKey, "app.web")
+	return ld
+}
+
+// decodeEvents decodes the events with the conversion of the fluentforward receiver.
+func decodeEvents(t *testing.T, events []event) []fluentforwardreceiver.Event {
+	var decoded []fluentforwardreceiver.Event
+	for _, ev := range events {
+		reader := msgp.NewReader(bytes.NewReader(ev.data))
+		mode, err := fluentforwardreceiver.DetermineNextEventMode(reader.R)
+		require.NoError(t, err)
+
+		var e fluentforwardreceiver.Event
+		switch mode {
+		case fluentforwardreceiver.MessageMode:
+			e = &fluentforwardreceiver.MessageEventLogRecord{}
+		case fluentforwardreceiver.ForwardMode:
+			e = &fluentforwardreceiver.ForwardEventLogRecords{}
+		case fluentforwardreceiver.PackedForwardMode:
+			e = &fluentforwardreceiver.PackedForwardEventLogRecords{}
+		default:
+			require.Fail(t, "unexpected mode", mode.String())
+		}
+		require.NoError(t, e.DecodeMsg(reader))
+		assert.Equal(t, ev.chunk, e.Chunk())
+		decoded = append(decoded, e)
+	}
+	return decoded
+}
+
+func TestEncodeInverseOfReceiverConversion(t *testing.T) {
+	tests := []struct {
+		name           string
+		encoder        encoder
+		expectedEvents int
+	}{
+		{
+			name:           "message",
+			encoder:        encoder{mode: modeMessage},
+			expectedEvents: 3,
+		},
+		{
+			name:           "forward",
+			encoder:        encoder{mode: modeForward},
+			expectedEvents: 2,
+		},
+		{
+			name:           "packed_forward",
+			encoder:        encoder{mode: modePackedForward},
+			expectedEvents: 2,
+		},
+		{
+			name:           "compressed packed_forward with ack",
+			encoder:        encoder{mode: modePackedForward, compress: true, requireAck: true},
+			expectedEvents: 2,
+		},
+		{
+			name:           "forward with ack",
+			encoder:        encoder{mode: modeForward, requireAck: true},
+			expectedEvents: 2,
+		},
+		{
+			name:           "message with ack",
+			encoder:        encoder{mode: modeMessage, requireAck: true},
+			expectedEvents: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := tt.encoder.encode(groupByTag(testLogs(), "default"))
+			require.NoError(t, err)
+			require.Len(t, events, tt.expectedEvents)
+			for _, ev := range events {
+				assert.Equal(t, tt.encoder.requireAck, ev.chunk != "")
+			}
+
+			byBody := map[string]plog.LogRecord{}
+			for _, e := range decodeEvents(t, events) {
+				if tt.encoder.compress {
+					assert.Equal(t, compressionGzip, e.Compressed())
+				}
+				for i := 0; i < e.LogRecords().Len(); i++ {
+					lr := e.LogRecords().At(i)
+					byBody[lr.Body().Str()] = lr
+				}
+			}
+			require.Len(t, byBody, 3)
+
+			first := byBody["first"]
+			assert.Equal(t, testTime, first.Timestamp().AsTime().UTC())
+			assert.Equal(t, map[string]interface{}{
+				tagAttributeKey: "app.web",
+				"status":        int64(200),
+				"duration":      1.5,
+				"cached":        true,
+				"user":          map[string]interface{}{"name": "alice"},
+				"tags":          []interface{}{"a"},
+			}, first.Attributes().AsRaw())
+
+			second := byBody["second"]
+			assert.Equal(t, testTime.Add(time.Second), second.Timestamp().AsTime().UTC())
+			assert.Equal(t, map[string]interface{}{tagAttributeKey: "default"}, second.Attributes().AsRaw())
+
+			third := byBody["third"]
+			assert.Equal(t, testTime.Add(2*time.Second), third.Timestamp().AsTime().UTC())
+			assert.Equal(t, map[string]interface{}{tagAttributeKey: "app.web"}, third.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestGroupByTag(t *testing.T) {
+	groups := groupByTag(testLogs(), "default")
+	require.Len(t, groups, 2)
+	assert.Equal(t, "app.web", groups[0].tag)
+	assert.Len(t, groups[0].entries, 2)
+	assert.Equal(t, "default", groups[1].tag)
+	assert.Len(t, groups[1].entries, 1)
+	assert.NotContains(t, groups[0].entries[0].record, tagAttributeKey)
+	assert.Equal(t, "first", groups[0].entries[0].record[messageKey])
+}
+
+func TestRecordWithoutBody(t *testing.T) {
+	lr := plog.NewLogRecord()
+	lr.Attributes().PutStr("key", "value")
+	assert.Equal(t, map[string]interface{}{"key": "value"}, recordOf(lr))
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+const unixPrefix = "unix://"
+
+type fluentForwardExporter struct {
+	config    *Config
+	logger    *zap.Logger
+	tlsConfig *tls.Config
+	encoder   *encoder
+	creds     handshakeCredentials
+
+	// The connection is kept open across pushes, and reset on errors.
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *msgp.Reader
+}
+
+func newFluentForwardExporter(cfg *Config, set component.TelemetrySettings) (*fluentForwardExporter, error) {
+	tlsConfig, err := cfg.TLSSetting.LoadTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	hostname := cfg.Hostname
+	if hostname == "" {
+		if hostname, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("failed to get the hostname for the handshake: %w", err)
+		}
+	}
+
+	return &fluentForwardExporter{
+		config:    cfg,
+		logger:    set.Logger,
+		tlsConfig: tlsConfig,
+		encoder: &encoder{
+			mode:       cfg.Mode,
+			compress:   cfg.Compression == compressionGzip,
+			requireAck: cfg.RequireAck,
+		},
+		creds: handshakeCredentials{
+			hostname:  hostname,
+			sharedKey: string(cfg.SharedKey),
+			username:  cfg.Username,
+			password:  string(cfg.Password),
+		},
+	}, nil
+}
+
+func (e *fluentForwardExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	events, err := e.encoder.encode(groupByTag(ld, e.config.Tag))
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.conn == nil {
+		if err = e.connect(ctx); err != nil {
+			return err
+		}
+	}
+
+	for i, ev := range events {
+		if err = e.send(ctx, ev); err != nil {
+			e.closeConn()
+			// Only the log records of the events not sent yet are retried,
+			// the previous events have already been written or acknowledged.
+			if i == 0 {
+				return err
+			}
+			unsent := map[int]struct{}{}
+			for _, ev := range events[i:] {
+				for _, index := range ev.records {
+					unsent[index] = struct{}{}
+				}
+			}
+			return consumererror.NewLogs(err, withRecords(ld, unsent))
+		}
+	}
+	return nil
+}
+
+// connect establishes the connection to the server, and performs the
+// handshake when a shared key is configured.
+func (e *fluentForwardExporter) connect(ctx context.Context) error {
+	network, address := "tcp", e.config.Endpoint
+	if strings.HasPrefix(address, unixPrefix) {
+		network, address = "unix", strings.TrimPrefix(address, unixPrefix)
+	}
+
+	dialer := &net.Dialer{Timeout: e.config.ConnectionTimeout}
+	var conn net.Conn
+	var err error
+	if e.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: e.tlsConfig}).DialContext(ctx, network, address)
+	} else {
+		conn, err = dialer.DialContext(ctx, network, address)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", e.config.Endpoint, err)
+	}
+	reader := msgp.NewReader(conn)
+
+	if e.config.SharedKey != "" {
+		if err = conn.SetDeadline(e.deadline(ctx, e.config.ConnectionTimeout)); err == nil {
+			err = handshake(conn, reader, e.creds)
+		}
+		if err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	e.logger.Debug("Connected to the Fluent Forward server", zap.String("endpoint", e.config.Endpoint))
+	e.conn = conn
+	e.reader = reader
+	return nil
+}
+
+// send writes the event and waits for its acknowledgment when required.
+func (e *fluentForwardExporter) send(ctx context.Context, ev event) error {
+	if err := e.conn.SetWriteDeadline(e.deadline(ctx, 0)); err != nil {
+		return err
+	}
+	if _, err := e.conn.Write(ev.data); err != nil {
+		return fmt.Errorf("failed to send event: %w", err)
+	}
+	if ev.chunk == "" {
+		return nil
+	}
+
+	if err := e.conn.SetReadDeadline(e.deadline(ctx, e.config.AckTimeout)); err != nil {
+		return err
+	}
+	ack, err := readAck(e.reader)
+	if err != nil {
+		return fmt.Errorf("failed to read acknowledgment of chunk %s: %w", ev.chunk, err)
+	}
+	if ack != ev.chunk {
+		return fmt.Errorf("unexpected acknowledgment %s of chunk %s", ack, ev.chunk)
+	}
+	return nil
+}
+
+// deadline returns the earliest of the context deadline and the given timeout,
+// or no deadline if neither is set.
+func (e *fluentForwardExporter) deadline(ctx context.Context, timeout time.Duration) time.Time {
+	deadline, _ := ctx.Deadline()
+	if timeout > 0 {
+		if timeoutDeadline := time.Now().Add(timeout); deadline.IsZero() || timeoutDeadline.Before(deadline) {
+			deadline = timeoutDeadline
+		}
+	}
+	return deadline
+}
+
+// readAck reads the {"ack": chunk} response of the server.
+func readAck(r *msgp.Reader) (string, error) {
+	mapLen, err := r.ReadMapHeader()
+	if err != nil {
+		return "", err
+	}
+	var ack string
+	for ; mapLen > 0; mapLen-- {
+		key, err := r.ReadString()
+		if err != nil {
+			return "", err
+		}
+		if key == "ack" {
+			if ack, err = r.ReadString(); err != nil {
+				return "", msgp.WrapError(err, "Ack")
+			}
+			continue
+		}
+		if err = r.Skip(); err != nil {
+			return "", err
+		}
+	}
+	return ack, nil
+}
+
+func (e *fluentForwardExporter) closeConn() {
+	if e.conn == nil {
+		return
+	}
+	if err := e.conn.Close(); err != nil {
+		e.logger.Debug("Failed to close the connection", zap.Error(err))
+	}
+	e.conn = nil
+	e.reader = nil
+}
+
+func (e *fluentForwardExporter) shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.closeConn()
+	return nil
+}
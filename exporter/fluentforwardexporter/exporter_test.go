@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/fluentforwardreceiver"
+)
+
+func TestExportToReceiver(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "message",
+			modify: func(cfg *Config) { cfg.Mode = modeMessage },
+		},
+		{
+			name:   "forward",
+			modify: func(cfg *Config) { cfg.Mode = modeForward },
+		},
+		{
+			name:   "packed_forward",
+			modify: func(cfg *Config) { cfg.Mode = modePackedForward },
+		},
+		{
+			name:   "compressed packed_forward",
+			modify: func(cfg *Config) { cfg.Mode = modePackedForward; cfg.Compression = compressionGzip },
+		},
+		{
+			name:   "forward with ack",
+			modify: func(cfg *Config) { cfg.Mode = modeForward; cfg.RequireAck = true },
+		},
+		{
+			name:   "message with ack",
+			modify: func(cfg *Config) { cfg.Mode = modeMessage; cfg.RequireAck = true },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := testutil.GetAvailableLocalAddress(t)
+
+			sink := new(consumertest.LogsSink)
+			rcvFactory := fluentforwardreceiver.NewFactory()
+			rcvCfg := rcvFactory.CreateDefaultConfig().(*fluentforwardreceiver.Config)
+			rcvCfg.ListenAddress = endpoint
+			rcv, err := rcvFactory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), rcvCfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, rcv.Shutdown(context.Background())) }()
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Endpoint = endpoint
+			cfg.RetrySettings.Enabled = false
+			tt.modify(cfg)
+			exp, err := factory.CreateLogsExporter(context.Background(), exportertest.NewNopCreateSettings(), cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+			// Send twice to reuse the connection.
+			require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs()))
+			require.NoError(t, exp.ConsumeLogs(context.Background(), testLogs()))
+
+			require.Eventually(t, func() bool {
+				return sink.LogRecordCount() == 6
+			}, 5*time.Second, 10*time.Millisecond)
+
+			var bodies []string
+			for _, ld := range sink.AllLogs() {
+				lrs := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				for i := 0; i < lrs.Len(); i++ {
+					bodies = append(bodies, lrs.At(i).Body().Str())
+				}
+			}
+			sort.Strings(bodies)
+			assert.Equal(t, []string{"first", "first", "second", "second", "third", "third"}, bodies)
+		})
+	}
+}
+
+func TestExportConnectionError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	exp, err := newFluentForwardExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	err = exp.pushLogs(context.Background(), testLogs())
+	assert.ErrorContains(t, err, "failed to connect to "+cfg.Endpoint)
+}
+
+// fakeServer is a Fluent Forward server over TLS which performs the handshake,
+// and acknowledges events with the given function.
+type fakeServer struct {
+	listener  net.Listener
+	sharedKey string
+	username  string
+	password  string
+	ack       func(chunk string) string
+	events    chan fluentforwardreceiver.Event
+}
+
+func newFakeServer(t *testing.T, sharedKey string) *fakeServer {
+	cert, err := tls.LoadX509KeyPair("testdata/server.crt", "testdata/server.key")
+	require.NoError(t, err)
+	listener, err := tls.Listen("tcp", "localhost:0", &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+
+	s := &fakeServer{
+		listener:  listener,
+		sharedKey: sharedKey,
+		ack:       func(chunk string) string { return chunk },
+		events:    make(chan fluentforwardreceiver.Event, 10),
+	}
+	t.Cleanup(func() { listener.Close() })
+	return s
+}
+
+func (s *fakeServer) serve(t *testing.T) {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := msgp.NewReader(conn)
+
+	nonce, authSalt := []byte("nonce"), []byte("")
+	if s.username != "" {
+		authSalt = []byte("auth-salt")
+	}
+	b := msgp.AppendArrayHeader(nil, 2)
+	b = msgp.AppendString(b, "HELO")
+	b = msgp.AppendMapHeader(b, 3)
+	b = msgp.AppendString(b, "nonce")
+	b = msgp.AppendBytes(b, nonce)
+	b = msgp.AppendString(b, "auth")
+	b = msgp.AppendBytes(b, authSalt)
+	b = msgp.AppendString(b, "keepalive")
+	b = msgp.AppendBool(b, true)
+	_, err = conn.Write(b)
+	require.NoError(t, err)
+
+	ping, err := reader.ReadIntf()
+	require.NoError(t, err)
+	fields := ping.([]interface{})
+	require.Len(t, fields, 6)
+	assert.Equal(t, "PING", fields[0])
+	hostname, salt := fields[1].(string), fields[2].([]byte)
+
+	authResult, reason := true, ""
+	if fields[3] != hexDigest(salt, []byte(hostname), nonce, []byte(s.sharedKey)) {
+		authResult, reason = false, "shared key mismatch"
+	} else if s.username != "" && (fields[4] != s.username || fields[5] != hexDigest(authSalt, []byte(s.username), []byte(s.password))) {
+		authResult, reason = false, "username/password mismatch"
+	}
+
+	b = msgp.AppendArrayHeader(nil, 5)
+	b = msgp.AppendString(b, "PONG")
+	b = msgp.AppendBool(b, authResult)
+	b = msgp.AppendString(b, reason)
+	b = msgp.AppendString(b, "server")
+	b = msgp.AppendString(b, hexDigest(salt, []byte("server"), nonce, []byte(s.sharedKey)))
+	_, err = conn.Write(b)
+	require.NoError(t, err)
+	if !authResult {
+		return
+	}
+
+	for {
+		mode, err := fluentforwardreceiver.DetermineNextEventMode(reader.R)
+		if err != nil {
+			return
+		}
+		require.Equal(t, fluentforwardreceiver.ForwardMode, mode)
+		event := &fluentforwardreceiver.ForwardEventLogRecords{}
+		require.NoError(t, event.DecodeMsg(reader))
+		s.events <- event
+
+		if event.Chunk() != "" {
+			b = msgp.AppendMapHeader(nil, 1)
+			b = msgp.AppendString(b, "ack")
+			b = msgp.AppendString(b, s.ack(event.Chunk()))
+			_, err = conn.Write(b)
+			require.NoError(t, err)
+		}
+	}
+}
+
+func newTLSTestExporter(t *testing.T, server *fakeServer, modify func(cfg *Config)) *fluentForwardExporter {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.listener.Addr().String()
+	cfg.Hostname = "client"
+	cfg.RequireAck = true
+	cfg.TLSSetting = configtls.TLSClientSetting{
+		TLSSetting: configtls.TLSSetting{
+			CAFile: "testdata/ca.crt",
+		},
+		ServerName: "localhost",
+	}
+	modify(cfg)
+	exp, err := newFluentForwardExporter(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, exp.shutdown(context.Background())) })
+	return exp
+}
+
+func TestHandshakeOverTLS(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	server.username, server.password = "user", "password"
+	go server.serve(t)
+
+	exp := newTLSTestExporter(t, server, func(cfg *Config) {
+		cfg.SharedKey = "secret"
+		cfg.Username = "user"
+		cfg.Password = "password"
+	})
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs()))
+
+	received := 0
+	for received < 3 {
+		select {
+		case event := <-server.events:
+			received += event.LogRecords().Len()
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for events")
+		}
+	}
+}
+
+func TestHandshakeFailures(t *testing.T) {
+	tests := []struct {
+		name      string
+		serverKey string
+		modify    func(cfg *Config)
+		err       string
+	}{
+		{
+			name:      "wrong shared key",
+			serverKey: "secret",
+			modify:    func(cfg *Config) { cfg.SharedKey = "wrong" },
+			err:       "handshake failed: shared key mismatch",
+		},
+		{
+			name:      "wrong password",
+			serverKey: "secret",
+			modify:    func(cfg *Config) { cfg.SharedKey = "secret"; cfg.Username = "user"; cfg.Password = "wrong" },
+			err:       "handshake failed: username/password mismatch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeServer(t, tt.serverKey)
+			server.username, server.password = "user", "password"
+			go server.serve(t)
+
+			exp := newTLSTestExporter(t, server, tt.modify)
+			assert.EqualError(t, exp.pushLogs(context.Background(), testLogs()), tt.err)
+		})
+	}
+}
+
+func TestUnexpectedAck(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	server.ack = func(string) string { return "other" }
+	go server.serve(t)
+
+	exp := newTLSTestExporter(t, server, func(cfg *Config) { cfg.SharedKey = "secret" })
+	assert.ErrorContains(t, exp.pushLogs(context.Background(), testLogs()), "unexpected acknowledgment other of chunk")
+	// The connection is reset after an error.
+	assert.Nil(t, exp.conn)
+}
+
+func TestOnlyUnsentRecordsAreRetried(t *testing.T) {
+	server := newFakeServer(t, "secret")
+	acks := 0
+	server.ack = func(chunk string) string {
+		acks++
+		if acks > 1 {
+			return "other"
+		}
+		return chunk
+	}
+	go server.serve(t)
+
+	exp := newTLSTestExporter(t, server, func(cfg *Config) { cfg.SharedKey = "secret" })
+	err := exp.pushLogs(context.Background(), testLogs())
+	require.ErrorContains(t, err, "unexpected acknowledgment other of chunk")
+
+	// The records tagged app.web were acknowledged, only the record with the
+	// default tag remains to be sent.
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	remaining := logsErr.Data()
+	require.Equal(t, 1, remaining.LogRecordCount())
+	assert.Equal(t, "second", remaining.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
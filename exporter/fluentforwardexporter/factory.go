@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter/internal/metadata"
+)
+
+const (
+	defaultTag               = "otel"
+	defaultConnectionTimeout = 10 * time.Second
+	defaultAckTimeout        = 30 * time.Second
+)
+
+// NewFactory returns a new factory for the Fluent Forward exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		metadata.Type,
+		createDefaultConfig,
+		exporter.WithLogs(createLogsExporter, metadata.LogsStability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	qs := exporterhelper.NewDefaultQueueSettings()
+	qs.Enabled = false
+
+	return &Config{
+		ConnectionTimeout: defaultConnectionTimeout,
+		TLSSetting: configtls.TLSClientSetting{
+			Insecure: true,
+		},
+		Mode:            modeForward,
+		Tag:             defaultTag,
+		AckTimeout:      defaultAckTimeout,
+		RetrySettings:   exporterhelper.NewDefaultRetrySettings(),
+		QueueSettings:   qs,
+		TimeoutSettings: exporterhelper.NewDefaultTimeoutSettings(),
+	}
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	params exporter.CreateSettings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	oCfg := cfg.(*Config)
+	exp, err := newFluentForwardExporter(oCfg, params.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporterhelper.NewLogsExporter(
+		ctx,
+		params,
+		cfg,
+		exp.pushLogs,
+		exporterhelper.WithShutdown(exp.shutdown),
+		exporterhelper.WithTimeout(oCfg.TimeoutSettings),
+		exporterhelper.WithRetry(oCfg.RetrySettings),
+		exporterhelper.WithQueue(oCfg.QueueSettings),
+	)
+}
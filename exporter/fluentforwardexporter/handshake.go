@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// The handshake phase of the Forward protocol is described in
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1#handshake-messages.
+
+var errSharedKeyMismatch = errors.New("handshake failed: the server does not share the same key")
+
+// helo is the HELO message sent by the server when the connection is established.
+type helo struct {
+	nonce []byte
+	auth  []byte
+}
+
+// pong is the PONG message sent by the server in response to the PING message.
+type pong struct {
+	authResult bool
+	reason     string
+	hostname   string
+	digest     string
+}
+
+// handshakeCredentials are the credentials of the client used during the handshake.
+type handshakeCredentials struct {
+	hostname  string
+	sharedKey string
+	username  string
+	password  string
+}
+
+// handshake authenticates the client and the server with the shared key, and
+// the client with its username and password when the server requires it.
+func handshake(w io.Writer, r *msgp.Reader, creds handshakeCredentials) error {
+	h, err := readHelo(r)
+	if err != nil {
+		return fmt.Errorf("failed to read HELO: %w", err)
+	}
+
+	sharedKeySalt := make([]byte, 16)
+	if _, err = rand.Read(sharedKeySalt); err != nil {
+		return err
+	}
+
+	username, passwordDigest := "", ""
+	if len(h.auth) > 0 {
+		username = creds.username
+		passwordDigest = hexDigest(h.auth, []byte(creds.username), []byte(creds.password))
+	}
+
+	b := msgp.AppendArrayHeader(nil, 6)
+	b = msgp.AppendString(b, "PING")
+	b = msgp.AppendString(b, creds.hostname)
+	b = msgp.AppendBytes(b, sharedKeySalt)
+	b = msgp.AppendString(b, hexDigest(sharedKeySalt, []byte(creds.hostname), h.nonce, []byte(creds.sharedKey)))
+	b = msgp.AppendString(b, username)
+	b = msgp.AppendString(b, passwordDigest)
+	if _, err = w.Write(b); err != nil {
+		return fmt.Errorf("failed to send PING: %w", err)
+	}
+
+	p, err := readPong(r)
+	if err != nil {
+		return fmt.Errorf("failed to read PONG: %w", err)
+	}
+	if !p.authResult {
+		return fmt.Errorf("handshake failed: %s", p.reason)
+	}
+	if p.digest != hexDigest(sharedKeySalt, []byte(p.hostname), h.nonce, []byte(creds.sharedKey)) {
+		return errSharedKeyMismatch
+	}
+	return nil
+}
+
+// hexDigest returns the hex encoded SHA-512 digest of the concatenated values.
+func hexDigest(values ...[]byte) string {
+	h := sha512.New()
+	for _, v := range values {
+		h.Write(v)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func readHelo(r *msgp.Reader) (helo, error) {
+	var h helo
+	if err := readMessageType(r, "HELO", 2); err != nil {
+		return h, err
+	}
+	optionsLen, err := r.ReadMapHeader()
+	if err != nil {
+		return h, msgp.WrapError(err, "Options")
+	}
+	for ; optionsLen > 0; optionsLen-- {
+		key, err := r.ReadString()
+		if err != nil {
+			return h, msgp.WrapError(err, "Options")
+		}
+		switch key {
+		case "nonce":
+			h.nonce, err = readBinOrString(r)
+		case "auth":
+			h.auth, err = readBinOrString(r)
+		default:
+			err = r.Skip()
+		}
+		if err != nil {
+			return h, msgp.WrapError(err, "Options", key)
+		}
+	}
+	return h, nil
+}
+
+func readPong(r *msgp.Reader) (pong, error) {
+	var p pong
+	if err := readMessageType(r, "PONG", 5); err != nil {
+		return p, err
+	}
+	var err error
+	if p.authResult, err = r.ReadBool(); err != nil {
+		return p, msgp.WrapError(err, "AuthResult")
+	}
+	if p.reason, err = r.ReadString(); err != nil {
+		return p, msgp.WrapError(err, "Reason")
+	}
+	if p.hostname, err = r.ReadString(); err != nil {
+		return p, msgp.WrapError(err, "Hostname")
+	}
+	if p.digest, err = r.ReadString(); err != nil {
+		return p, msgp.WrapError(err, "SharedKeyDigest")
+	}
+	return p, nil
+}
+
+// readMessageType reads the header of a handshake message, which is an array
+// starting with its type.
+func readMessageType(r *msgp.Reader, expected string, expectedLen uint32) error {
+	arrLen, err := r.ReadArrayHeader()
+	if err != nil {
+		return err
+	}
+	if arrLen != expectedLen {
+		return msgp.ArrayError{Wanted: expectedLen, Got: arrLen}
+	}
+	messageType, err := r.ReadString()
+	if err != nil {
+		return msgp.WrapError(err, "Type")
+	}
+	if messageType != expected {
+		return fmt.Errorf("unexpected message type %q", messageType)
+	}
+	return nil
+}
+
+// readBinOrString reads a value sent as either binary or string.
+func readBinOrString(r *msgp.Reader) ([]byte, error) {
+	t, err := r.NextType()
+	if err != nil {
+		return nil, err
+	}
+	if t == msgp.StrType {
+		s, err := r.ReadString()
+		return []byte(s), err
+	}
+	return r.ReadBytes(nil)
+}
@@ -131,10 +131,8 @@ func (s *sender) addStructuredData(msg map[string]any) {
 		return
 	}
 
-	sd, ok := msg[structuredData].(map[string]map[string]string)
-	if !ok {
-		msg[structuredData] = emptyValue
-	} else {
+	switch sd := msg[structuredData].(type) {
+	case map[string]map[string]string:
 		sdElements := []string{}
 		for key, val := range sd {
 			sdElements = append(sdElements, key)
@@ -143,6 +141,19 @@ func (s *sender) addStructuredData(msg map[string]any) {
 			}
 		}
 		msg[structuredData] = sdElements
+	case map[string]any:
+		// Structured data parsed by the syslog receiver, as nested attributes.
+		sdElements := []string{}
+		for key, val := range sd {
+			sdElements = append(sdElements, key)
+			params, _ := val.(map[string]any)
+			for k, v := range params {
+				sdElements = append(sdElements, fmt.Sprintf("%s=\"%v\"", k, v))
+			}
+		}
+		msg[structuredData] = sdElements
+	default:
+		msg[structuredData] = emptyValue
 	}
 }
 
@@ -84,4 +84,20 @@ func TestFormatRFC5424(t *testing.T) {
 	assert.Equal(t, true, strings.Contains(formattedMsg, "UserHostAddress=\"192.168.2.132\""))
 	assert.Equal(t, true, strings.Contains(formattedMsg, "UserID=\"Tester2\""))
 	assert.Equal(t, true, strings.Contains(formattedMsg, "PEN=\"27389\""))
+
+	// Structured data received as nested attributes
+	msg3["structured_data"] = map[string]any{
+		"SecureAuth@27389": map[string]any{
+			"PEN":             "27389",
+			"Realm":           "SecureAuth0",
+			"UserHostAddress": "192.168.2.132",
+			"UserID":          "Tester2",
+		},
+	}
+	formattedMsg = s.formatRFC5424(msg3, timeObj3)
+	matched, err = regexp.MatchString(expectedForm, formattedMsg)
+	assert.Nil(t, err)
+	assert.Equal(t, true, matched, fmt.Sprintf("unexpected form of formatted message, formatted message: %s, regexp: %s", formattedMsg, expectedForm))
+	assert.Equal(t, true, strings.Contains(formattedMsg, "Realm=\"SecureAuth0\""))
+	assert.Equal(t, true, strings.Contains(formattedMsg, "PEN=\"27389\""))
 }
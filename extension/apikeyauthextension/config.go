@@ -0,0 +1,38 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"errors"
+)
+
+var (
+	errNoKeysFile = errors.New("no keys_file provided")
+	errNoHeader   = errors.New("no header provided")
+)
+
+// Config has the configuration for the API key authenticator extension.
+type Config struct {
+	// Header is the name of the HTTP header or gRPC metadata containing the API key. Default value: "x-api-key".
+	Header string `mapstructure:"header"`
+
+	// Scheme is the prefix of the API key in the header, such as "Bearer" when the key is sent in the
+	// "authorization" header. Optional.
+	Scheme string `mapstructure:"scheme"`
+
+	// KeysFile is the path of the YAML file with the hashed API keys and their attributes.
+	// The file is reloaded when modified.
+	KeysFile string `mapstructure:"keys_file"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.KeysFile == "" {
+		return errNoKeysFile
+	}
+	if cfg.Header == "" {
+		return errNoHeader
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+	"go.uber.org/zap"
+)
+
+var (
+	errNoAPIKey            = errors.New("no API key provided")
+	errInvalidSchemePrefix = errors.New("invalid API key scheme prefix")
+	errInvalidAPIKey       = errors.New("invalid API key")
+)
+
+type apiKeyAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	muKeys sync.RWMutex
+	keys   *keySet
+
+	shutdownCH chan struct{}
+	doneCH     chan struct{}
+}
+
+func newServerAuthExtension(cfg *Config, logger *zap.Logger) (auth.Server, error) {
+	if cfg.KeysFile == "" {
+		return nil, errNoKeysFile
+	}
+
+	a := &apiKeyAuth{
+		cfg:    cfg,
+		logger: logger,
+	}
+	return auth.NewServer(
+		auth.WithServerStart(a.start),
+		auth.WithServerAuthenticate(a.authenticate),
+		auth.WithServerShutdown(a.shutdown),
+	), nil
+}
+
+// start loads the keys file, and starts a routine reloading it when it's modified.
+func (a *apiKeyAuth) start(_ context.Context, _ component.Host) error {
+	keys, err := loadKeySet(a.cfg.KeysFile)
+	if err != nil {
+		return err
+	}
+	a.setKeys(keys)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(a.cfg.KeysFile); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	a.shutdownCH = make(chan struct{})
+	a.doneCH = make(chan struct{})
+	go a.watch(watcher)
+	return nil
+}
+
+func (a *apiKeyAuth) watch(watcher *fsnotify.Watcher) {
+	defer close(a.doneCH)
+	defer watcher.Close()
+	for {
+		select {
+		case <-a.shutdownCH:
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			// NOTE: k8s configmaps uses symlinks, the original file is removed when updated,
+			// so the watcher has to be added again.
+			if event.Op&(fsnotify.Remove|fsnotify.Chmod) != 0 {
+				if err := watcher.Remove(event.Name); err != nil {
+					a.logger.Debug("Failed to remove the keys file watcher", zap.Error(err))
+				}
+				if err := watcher.Add(a.cfg.KeysFile); err != nil {
+					a.logger.Error("Failed to watch the keys file", zap.Error(err))
+				}
+				a.reload()
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) != 0 {
+				a.reload()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			a.logger.Error("Failed to watch the keys file", zap.Error(err))
+		}
+	}
+}
+
+// reload loads the keys file again, the previous keys are kept if it's invalid.
+func (a *apiKeyAuth) reload() {
+	keys, err := loadKeySet(a.cfg.KeysFile)
+	if err != nil {
+		a.logger.Error("Failed to reload the keys file, the previous keys are kept", zap.String("filename", a.cfg.KeysFile), zap.Error(err))
+		return
+	}
+	a.setKeys(keys)
+	a.logger.Info("Reloaded the keys file", zap.String("filename", a.cfg.KeysFile))
+}
+
+func (a *apiKeyAuth) setKeys(keys *keySet) {
+	a.muKeys.Lock()
+	a.keys = keys
+	a.muKeys.Unlock()
+}
+
+func (a *apiKeyAuth) shutdown(_ context.Context) error {
+	if a.shutdownCH == nil {
+		return nil
+	}
+	close(a.shutdownCH)
+	<-a.doneCH
+	a.shutdownCH = nil
+	return nil
+}
+
+func (a *apiKeyAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	values := client.NewMetadata(headers).Get(a.cfg.Header)
+	if len(values) == 0 || values[0] == "" {
+		return ctx, errNoAPIKey
+	}
+
+	key := values[0]
+	if a.cfg.Scheme != "" {
+		prefix := a.cfg.Scheme + " "
+		if len(key) < len(prefix) || !strings.EqualFold(key[:len(prefix)], prefix) {
+			return ctx, errInvalidSchemePrefix
+		}
+		key = key[len(prefix):]
+	}
+
+	a.muKeys.RLock()
+	keys := a.keys
+	a.muKeys.RUnlock()
+	if keys == nil {
+		return ctx, errInvalidAPIKey
+	}
+
+	entry, ok := keys.lookup(key)
+	if !ok {
+		return ctx, errInvalidAPIKey
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = newAuthData(entry)
+	return client.NewContext(ctx, cl), nil
+}
+
+const keyIDAttribute = "key_id"
+
+var _ client.AuthData = (*authData)(nil)
+
+type authData struct {
+	keyID      string
+	attributes map[string]string
+	names      []string
+}
+
+func newAuthData(entry *keyEntry) *authData {
+	names := make([]string, 0, len(entry.Attributes)+1)
+	names = append(names, keyIDAttribute)
+	for name := range entry.Attributes {
+		if name != keyIDAttribute {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+
+	return &authData{
+		keyID:      entry.ID,
+		attributes: entry.Attributes,
+		names:      names,
+	}
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	if name == keyIDAttribute {
+		return a.keyID
+	}
+	if value, ok := a.attributes[name]; ok {
+		return value
+	}
+	return nil
+}
+
+func (a *authData) GetAttributeNames() []string {
+	return a.names
+}
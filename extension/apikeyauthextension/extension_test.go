@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func TestAuthenticate(t *testing.T) {
+	ext, err := newServerAuthExtension(&Config{
+		Header:   defaultHeader,
+		KeysFile: filepath.Join("testdata", "keys.yaml"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	for _, tt := range []struct {
+		casename      string
+		headers       map[string][]string
+		expectedAttrs map[string]interface{}
+		expectedErr   error
+	}{
+		{
+			casename: "sha256",
+			headers:  map[string][]string{"x-api-key": {"acme-secret-key"}},
+			expectedAttrs: map[string]interface{}{
+				"key_id":     "acme-1",
+				"tenant":     "acme",
+				"rate_class": "gold",
+			},
+		},
+		{
+			casename: "bcrypt",
+			headers:  map[string][]string{"X-Api-Key": {"globex-1.globex-secret-key"}},
+			expectedAttrs: map[string]interface{}{
+				"key_id":     "globex-1",
+				"tenant":     "globex",
+				"rate_class": "silver",
+			},
+		},
+		{
+			casename:    "unknownKey",
+			headers:     map[string][]string{"x-api-key": {"unknown-key"}},
+			expectedErr: errInvalidAPIKey,
+		},
+		{
+			casename:    "noKey",
+			headers:     map[string][]string{"authorization": {"acme-secret-key"}},
+			expectedErr: errNoAPIKey,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			ctx, err := ext.Authenticate(context.Background(), tt.headers)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+
+			data := client.FromContext(ctx).Auth
+			require.NotNil(t, data)
+			assert.Equal(t, []string{"key_id", "rate_class", "tenant"}, data.GetAttributeNames())
+			for name, value := range tt.expectedAttrs {
+				assert.Equal(t, value, data.GetAttribute(name), name)
+			}
+			assert.Nil(t, data.GetAttribute("unknown"))
+		})
+	}
+}
+
+func TestAuthenticateScheme(t *testing.T) {
+	ext, err := newServerAuthExtension(&Config{
+		Header:   "authorization",
+		Scheme:   "Bearer",
+		KeysFile: filepath.Join("testdata", "keys.yaml"),
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	ctx, err := ext.Authenticate(context.Background(), map[string][]string{"Authorization": {"bearer acme-secret-key"}})
+	require.NoError(t, err)
+	assert.Equal(t, "acme", client.FromContext(ctx).Auth.GetAttribute("tenant"))
+
+	_, err = ext.Authenticate(context.Background(), map[string][]string{"Authorization": {"acme-secret-key"}})
+	assert.Equal(t, errInvalidSchemePrefix, err)
+}
+
+func TestKeysFileReload(t *testing.T) {
+	keys, err := os.ReadFile(filepath.Join("testdata", "keys.yaml"))
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	require.NoError(t, os.WriteFile(path, keys, 0600))
+
+	ext, err := newServerAuthExtension(&Config{Header: defaultHeader, KeysFile: path}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	headers := map[string][]string{"x-api-key": {"acme-secret-key"}}
+	_, err = ext.Authenticate(context.Background(), headers)
+	require.NoError(t, err)
+
+	// the previous keys are kept when the file is invalid
+	require.NoError(t, os.WriteFile(path, []byte("keys: ["), 0600))
+	time.Sleep(100 * time.Millisecond)
+	_, err = ext.Authenticate(context.Background(), headers)
+	require.NoError(t, err)
+
+	// the key is revoked
+	require.NoError(t, os.WriteFile(path, []byte("keys:\n  - id: other\n    hash: \"sha256:"+
+		"0000000000000000000000000000000000000000000000000000000000000000\"\n"), 0600))
+	assert.Eventually(t, func() bool {
+		_, err = ext.Authenticate(context.Background(), headers)
+		return err == errInvalidAPIKey
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestStartInvalidKeysFile(t *testing.T) {
+	ext, err := newServerAuthExtension(&Config{Header: defaultHeader, KeysFile: filepath.Join(t.TempDir(), "missing.yaml")}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, ext.Shutdown(context.Background()))
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sha256Prefix = "sha256:"
+	// keyIDSeparator separates the ID from the secret in the keys with a bcrypt hash.
+	keyIDSeparator = "."
+)
+
+var errNoKeys = errors.New("no key found in the keys file")
+
+// keysFile is the content of the keys file.
+type keysFile struct {
+	Keys []keyEntry `yaml:"keys"`
+}
+
+// keyEntry is an API key, identified by its hash, and its attributes.
+type keyEntry struct {
+	// ID identifies the key, without revealing it. The keys with a bcrypt hash must start with their
+	// ID followed by ".", so that they are compared with a single bcrypt hash.
+	ID string `yaml:"id"`
+	// Hash is either "sha256:" followed by the hex-encoded SHA-256 hash of the key, or a bcrypt hash
+	// of the whole key, ID included.
+	Hash string `yaml:"hash"`
+	// Attributes are exposed in the auth data of the authenticated requests.
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// keySet holds the keys of a keys file, by type of hash.
+type keySet struct {
+	sha256 map[[sha256.Size]byte]*keyEntry
+	// bcrypt holds the keys with a bcrypt hash by ID, as comparing bcrypt hashes is slow on purpose
+	bcrypt map[string]*keyEntry
+
+	// bcryptMatches caches the bcrypt keys which were matched, by SHA-256 hash of the key,
+	// as comparing bcrypt hashes is slow on purpose
+	muBcryptMatches sync.RWMutex
+	bcryptMatches   map[[sha256.Size]byte]*keyEntry
+}
+
+func loadKeySet(path string) (*keySet, error) {
+	raw, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("read keys file: %w", err)
+	}
+
+	var file keysFile
+	if err = yaml.Unmarshal(raw, &file); err != nil {
+		return nil, fmt.Errorf("parse keys file: %w", err)
+	}
+	if len(file.Keys) == 0 {
+		return nil, errNoKeys
+	}
+
+	ks := &keySet{
+		sha256:        map[[sha256.Size]byte]*keyEntry{},
+		bcrypt:        map[string]*keyEntry{},
+		bcryptMatches: map[[sha256.Size]byte]*keyEntry{},
+	}
+	for i := range file.Keys {
+		entry := &file.Keys[i]
+		switch {
+		case strings.HasPrefix(entry.Hash, sha256Prefix):
+			decoded, err := hex.DecodeString(strings.TrimPrefix(entry.Hash, sha256Prefix))
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("key %d: invalid SHA-256 hash", i)
+			}
+			var sum [sha256.Size]byte
+			copy(sum[:], decoded)
+			if _, ok := ks.sha256[sum]; ok {
+				return nil, fmt.Errorf("key %d: duplicate SHA-256 hash", i)
+			}
+			ks.sha256[sum] = entry
+		case strings.HasPrefix(entry.Hash, "$2"):
+			if _, err := bcrypt.Cost([]byte(entry.Hash)); err != nil {
+				return nil, fmt.Errorf("key %d: invalid bcrypt hash: %w", i, err)
+			}
+			if entry.ID == "" || strings.Contains(entry.ID, keyIDSeparator) {
+				return nil, fmt.Errorf("key %d: the keys with a bcrypt hash must have an ID without %q", i, keyIDSeparator)
+			}
+			if _, ok := ks.bcrypt[entry.ID]; ok {
+				return nil, fmt.Errorf("key %d: duplicate ID %q", i, entry.ID)
+			}
+			ks.bcrypt[entry.ID] = entry
+		default:
+			return nil, fmt.Errorf("key %d: unsupported hash, must be %q followed by the hex-encoded hash, or a bcrypt hash", i, sha256Prefix)
+		}
+	}
+	return ks, nil
+}
+
+// lookup returns the entry of the key, if any. At most one bcrypt hash is compared per lookup:
+// the one of the ID the key starts with.
+func (ks *keySet) lookup(key string) (*keyEntry, bool) {
+	sum := sha256.Sum256([]byte(key))
+	if entry, ok := ks.sha256[sum]; ok {
+		return entry, true
+	}
+
+	id, _, found := strings.Cut(key, keyIDSeparator)
+	if !found {
+		return nil, false
+	}
+	entry, ok := ks.bcrypt[id]
+	if !ok {
+		return nil, false
+	}
+
+	ks.muBcryptMatches.RLock()
+	_, ok = ks.bcryptMatches[sum]
+	ks.muBcryptMatches.RUnlock()
+	if ok {
+		return entry, true
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(entry.Hash), []byte(key)) != nil {
+		return nil, false
+	}
+	ks.muBcryptMatches.Lock()
+	ks.bcryptMatches[sum] = entry
+	ks.muBcryptMatches.Unlock()
+	return entry, true
+}
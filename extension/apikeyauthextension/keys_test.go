@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package apikeyauthextension
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadKeySet(t *testing.T) {
+	ks, err := loadKeySet(filepath.Join("testdata", "keys.yaml"))
+	require.NoError(t, err)
+
+	entry, ok := ks.lookup("acme-secret-key")
+	require.True(t, ok)
+	assert.Equal(t, "acme-1", entry.ID)
+	assert.Equal(t, map[string]string{"tenant": "acme", "rate_class": "gold"}, entry.Attributes)
+
+	entry, ok = ks.lookup("globex-1.globex-secret-key")
+	require.True(t, ok)
+	assert.Equal(t, "globex-1", entry.ID)
+	assert.Len(t, ks.bcryptMatches, 1)
+
+	// the bcrypt matches are cached
+	cached, ok := ks.lookup("globex-1.globex-secret-key")
+	require.True(t, ok)
+	assert.Same(t, entry, cached)
+
+	_, ok = ks.lookup("unknown-key")
+	assert.False(t, ok)
+	// the bcrypt keys are only matched with the hash of their ID
+	_, ok = ks.lookup("globex-secret-key")
+	assert.False(t, ok)
+	_, ok = ks.lookup("globex-1.wrong-secret")
+	assert.False(t, ok)
+	_, ok = ks.lookup("acme-1.globex-secret-key")
+	assert.False(t, ok)
+	_, ok = ks.lookup("")
+	assert.False(t, ok)
+	assert.Len(t, ks.bcryptMatches, 1)
+}
+
+func TestLoadKeySetInvalid(t *testing.T) {
+	for _, tt := range []struct {
+		casename string
+		content  string
+	}{
+		{"noKeys", "keys: []"},
+		{"invalidYAML", "keys: ["},
+		{"unsupportedHash", "keys:\n  - hash: md5:foo"},
+		{"invalidSHA256", "keys:\n  - hash: sha256:1234"},
+		{"invalidBcrypt", "keys:\n  - id: foo\n    hash: $2a$foo"},
+		{"bcryptWithoutID", "keys:\n  - hash: $2a$04$.GUaZQxhCGcqoWpSypHe1.qNSdwcwLs.sLeGnZavjHgjuEPFoxxVO"},
+		{"bcryptIDWithSeparator", "keys:\n  - id: globex.1\n    hash: $2a$04$.GUaZQxhCGcqoWpSypHe1.qNSdwcwLs.sLeGnZavjHgjuEPFoxxVO"},
+		{"duplicateBcryptID", "keys:\n" +
+			"  - id: globex-1\n    hash: $2a$04$.GUaZQxhCGcqoWpSypHe1.qNSdwcwLs.sLeGnZavjHgjuEPFoxxVO\n" +
+			"  - id: globex-1\n    hash: $2a$04$.GUaZQxhCGcqoWpSypHe1.qNSdwcwLs.sLeGnZavjHgjuEPFoxxVO\n"},
+		{"duplicateSHA256", "keys:\n" +
+			"  - hash: sha256:b81ff514581f28cf9536d9227563cf3a93e9ced7728e175b4e1d044be8a3ef29\n" +
+			"  - hash: sha256:b81ff514581f28cf9536d9227563cf3a93e9ced7728e175b4e1d044be8a3ef29\n"},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keys.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			_, err := loadKeySet(path)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := loadKeySet(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
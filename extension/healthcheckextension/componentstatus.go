@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	receiverKind  = "receiver"
+	processorKind = "processor"
+	exporterKind  = "exporter"
+
+	statusHealthy   = "healthy"
+	statusUnhealthy = "unhealthy"
+)
+
+// failureView is a view of the items a kind of component failed to handle, tagged with the component
+type failureView struct {
+	kind    string
+	message string
+}
+
+// failureViews are the views of the items the components failed to handle, by view name
+var failureViews = map[string]failureView{
+	"receiver/refused_spans":             {kind: receiverKind, message: "refused %.0f spans"},
+	"receiver/refused_metric_points":     {kind: receiverKind, message: "refused %.0f metric points"},
+	"receiver/refused_log_records":       {kind: receiverKind, message: "refused %.0f log records"},
+	"processor/refused_spans":            {kind: processorKind, message: "refused %.0f spans"},
+	"processor/refused_metric_points":    {kind: processorKind, message: "refused %.0f metric points"},
+	"processor/refused_log_records":      {kind: processorKind, message: "refused %.0f log records"},
+	"processor/dropped_spans":            {kind: processorKind, message: "dropped %.0f spans"},
+	"processor/dropped_metric_points":    {kind: processorKind, message: "dropped %.0f metric points"},
+	"processor/dropped_log_records":      {kind: processorKind, message: "dropped %.0f log records"},
+	"exporter/send_failed_spans":         {kind: exporterKind, message: "failed to send %.0f spans"},
+	"exporter/send_failed_metric_points": {kind: exporterKind, message: "failed to send %.0f metric points"},
+	"exporter/send_failed_log_records":   {kind: exporterKind, message: "failed to send %.0f log records"},
+}
+
+// componentHealth is the health of a component, as returned by the status endpoint
+type componentHealth struct {
+	Status        string     `json:"status"`
+	LastError     string     `json:"last_error,omitempty"`
+	LastErrorTime *time.Time `json:"last_error_time,omitempty"`
+}
+
+// pipelineHealth is the health of a pipeline, as returned by the status endpoint
+type pipelineHealth struct {
+	Status     string                      `json:"status"`
+	Receivers  map[string]*componentHealth `json:"receivers,omitempty"`
+	Processors map[string]*componentHealth `json:"processors,omitempty"`
+	Exporters  map[string]*componentHealth `json:"exporters,omitempty"`
+}
+
+// collectorHealth is the body of the response of the status endpoint
+type collectorHealth struct {
+	Status    string                     `json:"status"`
+	Ready     bool                       `json:"ready"`
+	Timestamp time.Time                  `json:"timestamp"`
+	Pipelines map[string]*pipelineHealth `json:"pipelines"`
+}
+
+// componentKey identifies a component, as the same id may be used by components of different kinds
+type componentKey struct {
+	kind string
+	id   string
+}
+
+// componentFailure is the last failure of a component
+type componentFailure struct {
+	err  string
+	time time.Time
+}
+
+// componentStatusTracker is an OpenCensus exporter tracking the failures of the components, from the
+// views of the items they refused, dropped or failed to send
+type componentStatusTracker struct {
+	failureWindow time.Duration
+	now           func() time.Time
+
+	mu sync.Mutex
+	// failed holds the last cumulative values of the failure views, by view and component
+	failed map[string]map[string]float64
+	// lastFailures holds the last failure of each component
+	lastFailures map[componentKey]componentFailure
+}
+
+func newComponentStatusTracker(failureWindow time.Duration) *componentStatusTracker {
+	return &componentStatusTracker{
+		failureWindow: failureWindow,
+		now:           time.Now,
+		failed:        map[string]map[string]float64{},
+		lastFailures:  map[componentKey]componentFailure{},
+	}
+}
+
+// ExportView records the failures of the components since the previous export of the view
+func (t *componentStatusTracker) ExportView(vd *view.Data) {
+	fv, ok := failureViews[vd.View.Name]
+	if !ok {
+		return
+	}
+
+	// the rows of a component may be split by other tags, such as the transport of the receivers
+	totals := map[string]float64{}
+	for _, row := range vd.Rows {
+		id := ""
+		for _, tag := range row.Tags {
+			if tag.Key.Name() == fv.kind {
+				id = tag.Value
+			}
+		}
+		sum, ok := row.Data.(*view.SumData)
+		if id == "" || !ok {
+			continue
+		}
+		totals[id] += sum.Value
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, ok := t.failed[vd.View.Name]
+	if !ok {
+		previous = map[string]float64{}
+		t.failed[vd.View.Name] = previous
+	}
+	for id, total := range totals {
+		delta := total - previous[id]
+		previous[id] = total
+		if delta > 0 {
+			t.lastFailures[componentKey{kind: fv.kind, id: id}] = componentFailure{
+				err:  fmt.Sprintf(fv.message, delta),
+				time: t.now(),
+			}
+		}
+	}
+}
+
+// health returns the health of the pipelines and their components
+func (t *componentStatusTracker) health(pipelines map[string]pipelineSettings) map[string]*pipelineHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health := make(map[string]*pipelineHealth, len(pipelines))
+	for name, settings := range pipelines {
+		pipeline := &pipelineHealth{Status: statusHealthy}
+		pipeline.Receivers = t.componentsHealth(pipeline, receiverKind, settings.Receivers)
+		pipeline.Processors = t.componentsHealth(pipeline, processorKind, settings.Processors)
+		pipeline.Exporters = t.componentsHealth(pipeline, exporterKind, settings.Exporters)
+		health[name] = pipeline
+	}
+	return health
+}
+
+// componentsHealth returns the health of the components of a kind, and marks their pipeline unhealthy
+// if any of them is
+func (t *componentStatusTracker) componentsHealth(pipeline *pipelineHealth, kind string, ids []component.ID) map[string]*componentHealth {
+	if len(ids) == 0 {
+		return nil
+	}
+	now := t.now()
+	components := make(map[string]*componentHealth, len(ids))
+	for _, id := range ids {
+		ch := &componentHealth{Status: statusHealthy}
+		if failure, ok := t.lastFailures[componentKey{kind: kind, id: id.String()}]; ok {
+			failureTime := failure.time
+			ch.LastError = failure.err
+			ch.LastErrorTime = &failureTime
+			if now.Sub(failure.time) < t.failureWindow {
+				ch.Status = statusUnhealthy
+				pipeline.Status = statusUnhealthy
+			}
+		}
+		components[id.String()] = ch
+	}
+	return components
+}
+
+// ready tells whether the pipelines are healthy enough, all of them or any of them depending on the readiness
+func ready(pipelines map[string]*pipelineHealth, readiness string) bool {
+	if len(pipelines) == 0 {
+		return true
+	}
+	healthy := 0
+	for _, pipeline := range pipelines {
+		if pipeline.Status == statusHealthy {
+			healthy++
+		}
+	}
+	if readiness == readinessAny {
+		return healthy > 0
+	}
+	return healthy == len(pipelines)
+}
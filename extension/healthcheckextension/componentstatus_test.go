@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheckextension
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+)
+
+func failureData(t *testing.T, viewName, kind string, values map[string]float64) *view.Data {
+	key, err := tag.NewKey(kind)
+	require.NoError(t, err)
+
+	vd := &view.Data{View: &view.View{Name: viewName}}
+	for id, value := range values {
+		vd.Rows = append(vd.Rows, &view.Row{
+			Tags: []tag.Tag{{Key: key, Value: id}},
+			Data: &view.SumData{Value: value},
+		})
+	}
+	return vd
+}
+
+func failedSpansData(t *testing.T, values map[string]float64) *view.Data {
+	return failureData(t, "exporter/send_failed_spans", exporterKind, values)
+}
+
+func TestComponentStatusTracker(t *testing.T) {
+	tracker := newComponentStatusTracker(time.Minute)
+	now := time.Date(2023, 7, 12, 3, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	otlp := component.NewID("otlp")
+	backup := component.NewIDWithName("otlphttp", "backup")
+	pipelines := map[string]pipelineSettings{
+		"traces":  {Receivers: []component.ID{otlp}, Exporters: []component.ID{otlp, backup}},
+		"metrics": {Exporters: []component.ID{backup}},
+	}
+
+	tracker.ExportView(failedSpansData(t, map[string]float64{"otlp": 0, "otlphttp/backup": 0}))
+	health := tracker.health(pipelines)
+	assert.Equal(t, statusHealthy, health["traces"].Status)
+	assert.Equal(t, statusHealthy, health["metrics"].Status)
+	assert.Equal(t, &componentHealth{Status: statusHealthy}, health["traces"].Exporters["otlp"])
+	assert.Nil(t, health["metrics"].Receivers)
+
+	// only the failures since the previous export are accounted
+	now = now.Add(10 * time.Second)
+	tracker.ExportView(failedSpansData(t, map[string]float64{"otlp": 5, "otlphttp/backup": 0}))
+	now = now.Add(10 * time.Second)
+	tracker.ExportView(failedSpansData(t, map[string]float64{"otlp": 5, "otlphttp/backup": 0}))
+	failureTime := now.Add(-10 * time.Second)
+
+	health = tracker.health(pipelines)
+	assert.Equal(t, statusUnhealthy, health["traces"].Status)
+	assert.Equal(t, statusHealthy, health["metrics"].Status)
+	assert.Equal(t, &componentHealth{
+		Status:        statusUnhealthy,
+		LastError:     "failed to send 5 spans",
+		LastErrorTime: &failureTime,
+	}, health["traces"].Exporters["otlp"])
+	assert.Equal(t, statusHealthy, health["traces"].Exporters["otlphttp/backup"].Status)
+	// the receiver sharing the id of the exporter is not affected
+	assert.Equal(t, statusHealthy, health["traces"].Receivers["otlp"].Status)
+
+	// the component is healthy again once the failure window elapsed, the last error is kept
+	now = failureTime.Add(time.Minute)
+	health = tracker.health(pipelines)
+	assert.Equal(t, statusHealthy, health["traces"].Status)
+	assert.Equal(t, statusHealthy, health["traces"].Exporters["otlp"].Status)
+	assert.Equal(t, "failed to send 5 spans", health["traces"].Exporters["otlp"].LastError)
+}
+
+func TestComponentStatusTrackerReceiversAndProcessors(t *testing.T) {
+	tracker := newComponentStatusTracker(time.Minute)
+	pipelines := map[string]pipelineSettings{
+		"logs": {Receivers: []component.ID{component.NewID("otlp")}, Processors: []component.ID{component.NewID("memory_limiter")}},
+	}
+
+	// the rows of a receiver are split by transport
+	transport, err := tag.NewKey("transport")
+	require.NoError(t, err)
+	refused := failureData(t, "receiver/refused_log_records", receiverKind, map[string]float64{"otlp": 2})
+	refused.Rows = append(refused.Rows, &view.Row{
+		Tags: []tag.Tag{{Key: refused.Rows[0].Tags[0].Key, Value: "otlp"}, {Key: transport, Value: "http"}},
+		Data: &view.SumData{Value: 3},
+	})
+	tracker.ExportView(refused)
+	tracker.ExportView(failureData(t, "processor/dropped_log_records", processorKind, map[string]float64{"memory_limiter": 4}))
+
+	health := tracker.health(pipelines)
+	assert.Equal(t, statusUnhealthy, health["logs"].Status)
+	assert.Equal(t, "refused 5 log records", health["logs"].Receivers["otlp"].LastError)
+	assert.Equal(t, "dropped 4 log records", health["logs"].Processors["memory_limiter"].LastError)
+}
+
+func TestComponentStatusTrackerIgnoresOtherViews(t *testing.T) {
+	tracker := newComponentStatusTracker(time.Minute)
+	vd := failedSpansData(t, map[string]float64{"otlp": 5})
+	vd.View = &view.View{Name: "exporter/sent_spans"}
+	tracker.ExportView(vd)
+	assert.Empty(t, tracker.lastFailures)
+}
+
+func TestReady(t *testing.T) {
+	healthy := &pipelineHealth{Status: statusHealthy}
+	unhealthy := &pipelineHealth{Status: statusUnhealthy}
+
+	tests := []struct {
+		name      string
+		pipelines map[string]*pipelineHealth
+		all       bool
+		any       bool
+	}{
+		{
+			name: "no pipeline",
+			all:  true,
+			any:  true,
+		},
+		{
+			name:      "all healthy",
+			pipelines: map[string]*pipelineHealth{"traces": healthy, "metrics": healthy},
+			all:       true,
+			any:       true,
+		},
+		{
+			name:      "some healthy",
+			pipelines: map[string]*pipelineHealth{"traces": healthy, "metrics": unhealthy},
+			all:       false,
+			any:       true,
+		},
+		{
+			name:      "none healthy",
+			pipelines: map[string]*pipelineHealth{"traces": unhealthy, "metrics": unhealthy},
+			all:       false,
+			any:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.all, ready(tt.pipelines, readinessAll))
+			assert.Equal(t, tt.any, ready(tt.pipelines, readinessAny))
+		})
+	}
+}
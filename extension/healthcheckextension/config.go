@@ -37,6 +37,9 @@ type Config struct {
 
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
+
+	// ComponentStatus contains the settings of the detailed status of the pipelines and their components
+	ComponentStatus componentStatusSettings `mapstructure:"component_status"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -44,6 +47,10 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errInvalidComponentStatusPath              = errors.New("bad config: component_status path must start with / and differ from path")
+	errInvalidReadiness                        = errors.New("bad config: component_status readiness must be either any or all")
+	errInvalidFailureWindow                    = errors.New("bad config: component_status failure_window expects a positive duration")
+	errNoComponentStatusPipelines              = errors.New("bad config: component_status pipelines must be specified")
 )
 
 // Validate checks if the extension configuration is valid
@@ -61,6 +68,9 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	if cfg.ComponentStatus.Enabled {
+		return cfg.ComponentStatus.validate(cfg.Path)
+	}
 	return nil
 }
 
@@ -72,3 +82,46 @@ type checkCollectorPipelineSettings struct {
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
 }
+
+type componentStatusSettings struct {
+	// Enabled indicates whether to serve the detailed status of the pipelines and their components.
+	Enabled bool `mapstructure:"enabled"`
+	// Path represents the path the detailed status is served at.
+	Path string `mapstructure:"path"`
+	// Readiness indicates whether all the pipelines, or any pipeline, must be healthy for the
+	// detailed status to be returned with a 200 status code.
+	Readiness string `mapstructure:"readiness"`
+	// FailureWindow is the duration during which a component is unhealthy after a failure.
+	FailureWindow time.Duration `mapstructure:"failure_window"`
+	// Pipelines are the components of each pipeline, by pipeline name, as in the service
+	// configuration. The extension cannot access the pipelines of the service, they must be set.
+	Pipelines map[string]pipelineSettings `mapstructure:"pipelines"`
+}
+
+// pipelineSettings are the components of a pipeline whose health is reported.
+type pipelineSettings struct {
+	Receivers  []component.ID `mapstructure:"receivers"`
+	Processors []component.ID `mapstructure:"processors"`
+	Exporters  []component.ID `mapstructure:"exporters"`
+}
+
+const (
+	readinessAll = "all"
+	readinessAny = "any"
+)
+
+func (cfg *componentStatusSettings) validate(healthCheckPath string) error {
+	if !strings.HasPrefix(cfg.Path, "/") || cfg.Path == healthCheckPath {
+		return errInvalidComponentStatusPath
+	}
+	if cfg.Readiness != readinessAll && cfg.Readiness != readinessAny {
+		return errInvalidReadiness
+	}
+	if cfg.FailureWindow <= 0 {
+		return errInvalidFailureWindow
+	}
+	if len(cfg.Pipelines) == 0 {
+		return errNoComponentStatusPipelines
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package healthcheckextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,10 +44,38 @@ func TestLoadConfig(t *testing.T) {
 					},
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentStatus:        defaultComponentStatusSettings(),
 				Path:                   "/",
 				ResponseBody:           nil,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "componentstatus"),
+			expected: &Config{
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: defaultEndpoint,
+				},
+				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+				ComponentStatus: componentStatusSettings{
+					Enabled:       true,
+					Path:          "/health/status",
+					Readiness:     readinessAny,
+					FailureWindow: 2 * time.Minute,
+					Pipelines: map[string]pipelineSettings{
+						"traces": {
+							Receivers:  []component.ID{component.NewID("otlp")},
+							Processors: []component.ID{component.NewID("batch")},
+							Exporters:  []component.ID{component.NewID("otlp"), component.NewIDWithName("otlphttp", "backup")},
+						},
+						"metrics/internal": {
+							Receivers: []component.ID{component.NewID("prometheus")},
+							Exporters: []component.ID{component.NewID("prometheusremotewrite")},
+						},
+					},
+				},
+				Path: "/",
+			},
+		},
 		{
 			id:          component.NewIDWithName(metadata.Type, "missingendpoint"),
 			expectedErr: errNoEndpointProvided,
@@ -59,6 +88,22 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(metadata.Type, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidcomponentstatuspath"),
+			expectedErr: errInvalidComponentStatusPath,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidreadiness"),
+			expectedErr: errInvalidReadiness,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidfailurewindow"),
+			expectedErr: errInvalidFailureWindow,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missingcomponentstatuspipelines"),
+			expectedErr: errNoComponentStatusPipelines,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
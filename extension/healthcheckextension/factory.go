@@ -5,6 +5,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -35,6 +36,7 @@ func createDefaultConfig() component.Config {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentStatus:        defaultComponentStatusSettings(),
 		Path:                   "/",
 	}
 }
@@ -53,3 +55,13 @@ func defaultCheckCollectorPipelineSettings() checkCollectorPipelineSettings {
 		ExporterFailureThreshold: 5,
 	}
 }
+
+// defaultComponentStatusSettings returns the default settings for ComponentStatus.
+func defaultComponentStatusSettings() componentStatusSettings {
+	return componentStatusSettings{
+		Enabled:       false,
+		Path:          "/status",
+		Readiness:     readinessAll,
+		FailureWindow: time.Minute,
+	}
+}
@@ -23,6 +23,7 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 			Endpoint: defaultEndpoint,
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		ComponentStatus:        defaultComponentStatusSettings(),
 		Path:                   "/",
 	}, cfg)
 
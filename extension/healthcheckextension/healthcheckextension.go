@@ -5,6 +5,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -25,6 +26,8 @@ type healthCheckExtension struct {
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
 	settings component.TelemetrySettings
+
+	statusTracker *componentStatusTracker
 }
 
 var _ extension.PipelineWatcher = (*healthCheckExtension)(nil)
@@ -46,6 +49,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 		// Mount HC handler
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.baseHandler())
+		hc.mountComponentStatus(mux)
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -71,6 +75,7 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 
 		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.checkCollectorPipelineHandler())
+		hc.mountComponentStatus(mux)
 		hc.server.Handler = mux
 		hc.stopCh = make(chan struct{})
 		go func() {
@@ -131,6 +136,43 @@ func (hc *healthCheckExtension) checkCollectorPipelineHandler() http.Handler {
 	})
 }
 
+// mountComponentStatus mounts the handler of the detailed status, if enabled, and starts tracking the
+// failures of the components
+func (hc *healthCheckExtension) mountComponentStatus(mux *http.ServeMux) {
+	if !hc.config.ComponentStatus.Enabled {
+		return
+	}
+
+	hc.statusTracker = newComponentStatusTracker(hc.config.ComponentStatus.FailureWindow)
+	view.RegisterExporter(hc.statusTracker)
+
+	mux.Handle(hc.config.ComponentStatus.Path, hc.componentStatusHandler())
+}
+
+// handler function returning the detailed status of the pipelines and their components
+func (hc *healthCheckExtension) componentStatusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		state := hc.state.Get()
+		pipelines := hc.statusTracker.health(hc.config.ComponentStatus.Pipelines)
+		body := collectorHealth{
+			Status:    state.String(),
+			Timestamp: hc.statusTracker.now().UTC(),
+			Pipelines: pipelines,
+		}
+		body.Ready = state == healthcheck.Ready && ready(pipelines, hc.config.ComponentStatus.Readiness)
+
+		w.Header().Set("Content-Type", "application/json")
+		if body.Ready {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			hc.logger.Warn("Failed to write the component status", zap.Error(err))
+		}
+	})
+}
+
 func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
@@ -143,6 +185,9 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	if hc.stopCh != nil {
 		<-hc.stopCh
 	}
+	if hc.statusTracker != nil {
+		view.UnregisterExporter(hc.statusTracker)
+	}
 	return err
 }
 
@@ -5,6 +5,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"net"
 	"net/http"
@@ -405,6 +406,78 @@ func TestHealthCheckShutdownWithoutStart(t *testing.T) {
 	require.NoError(t, hcExt.Shutdown(context.Background()))
 }
 
+func TestHealthCheckComponentStatus(t *testing.T) {
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
+		Path:                   "/",
+		ComponentStatus: componentStatusSettings{
+			Enabled:       true,
+			Path:          "/status",
+			Readiness:     readinessAll,
+			FailureWindow: time.Minute,
+			Pipelines: map[string]pipelineSettings{
+				"traces":  {Receivers: []component.ID{component.NewID("otlp")}, Exporters: []component.ID{component.NewID("otlp")}},
+				"metrics": {Exporters: []component.ID{component.NewID("prometheusremotewrite")}},
+			},
+		},
+	}
+
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+
+	require.NoError(t, hcExt.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(config.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	get := func() (int, collectorHealth) {
+		resp, err := http.Get("http://" + config.Endpoint + config.ComponentStatus.Path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+		var body collectorHealth
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	// not ready until the pipelines are started
+	code, body := get()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, body.Ready)
+	assert.Equal(t, statusHealthy, body.Pipelines["traces"].Status)
+
+	require.NoError(t, hcExt.Ready())
+	code, body = get()
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, body.Ready)
+	assert.Equal(t, "ready", body.Status)
+
+	hcExt.statusTracker.ExportView(failedSpansData(t, map[string]float64{"otlp": 0}))
+	hcExt.statusTracker.ExportView(failedSpansData(t, map[string]float64{"otlp": 3}))
+	code, body = get()
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.False(t, body.Ready)
+	assert.Equal(t, statusUnhealthy, body.Pipelines["traces"].Status)
+	assert.Equal(t, "failed to send 3 spans", body.Pipelines["traces"].Exporters["otlp"].LastError)
+	assert.Equal(t, statusHealthy, body.Pipelines["traces"].Receivers["otlp"].Status)
+	assert.Equal(t, statusHealthy, body.Pipelines["metrics"].Status)
+
+	// a single healthy pipeline is enough with the any readiness
+	hcExt.config.ComponentStatus.Readiness = readinessAny
+	code, body = get()
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, body.Ready)
+
+	// the health check path is still served
+	resp, err := http.Get("http://" + config.Endpoint + config.Path)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
 func viewData() *view.Data {
 	currentTime := time.Now()
 	vd := &view.Data{
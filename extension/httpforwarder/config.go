@@ -4,7 +4,12 @@
 package httpforwarder // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 )
 
 // Config defines configuration for http forwarder extension.
@@ -13,6 +18,115 @@ type Config struct {
 	// Ingress holds config settings for HTTP server listening for requests.
 	Ingress confighttp.HTTPServerSettings `mapstructure:"ingress"`
 
-	// Egress holds config settings to use for forwarded requests.
+	// Egress holds config settings to use for forwarded requests not matching any of the routes.
+	Egress confighttp.HTTPClientSettings `mapstructure:"egress"`
+
+	// RequestHeaders holds the rules rewriting the headers of the requests forwarded to Egress.
+	RequestHeaders HeadersConfig `mapstructure:"request_headers"`
+
+	// ResponseHeaders holds the rules rewriting the headers of the responses received from Egress.
+	ResponseHeaders HeadersConfig `mapstructure:"response_headers"`
+
+	// Routes holds the routes forwarding the requests to other egresses, based on the prefix of their path.
+	Routes []RouteConfig `mapstructure:"routes"`
+}
+
+// RouteConfig defines the configuration of a route, forwarding the requests whose path starts
+// with a prefix to its own egress.
+type RouteConfig struct {
+	// Name identifies the route in the metrics, the path prefix is used when not set. The name
+	// "default" is reserved for the requests forwarded to the top-level egress.
+	Name string `mapstructure:"name"`
+
+	// PathPrefix is the prefix of the paths of the requests forwarded by the route. The longest
+	// matching prefix wins, and it matches whole path segments only: "/api" matches "/api" and
+	// "/api/sampling" but not "/apis".
+	PathPrefix string `mapstructure:"path_prefix"`
+
+	// StripPrefix indicates whether to remove the path prefix from the forwarded requests.
+	StripPrefix bool `mapstructure:"strip_prefix"`
+
+	// Egress holds config settings to use for the requests forwarded by the route.
 	Egress confighttp.HTTPClientSettings `mapstructure:"egress"`
+
+	// RequestHeaders holds the rules rewriting the headers of the requests forwarded by the route.
+	RequestHeaders HeadersConfig `mapstructure:"request_headers"`
+
+	// ResponseHeaders holds the rules rewriting the headers of the responses to the requests forwarded by the route.
+	ResponseHeaders HeadersConfig `mapstructure:"response_headers"`
+}
+
+// HeadersConfig defines the headers to remove from and to add to requests or responses.
+// The headers are removed first, the added headers replace any existing value.
+type HeadersConfig struct {
+	// Add holds the headers to add.
+	Add map[string]configopaque.String `mapstructure:"add"`
+
+	// Remove holds the names of the headers to remove.
+	Remove []string `mapstructure:"remove"`
+}
+
+var (
+	errNoRoutePathPrefix  = errors.New("'routes.path_prefix' config option must start with /")
+	errNoRouteEndpoint    = errors.New("'routes.egress.endpoint' config option cannot be empty")
+	errDuplicateRouteName = errors.New("'routes.name' config option must be unique")
+	errDuplicatePrefix    = errors.New("'routes.path_prefix' config option must be unique")
+	errEmptyHeaderName    = errors.New("header names cannot be empty")
+)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if err := cfg.RequestHeaders.validate(); err != nil {
+		return fmt.Errorf("request_headers: %w", err)
+	}
+	if err := cfg.ResponseHeaders.validate(); err != nil {
+		return fmt.Errorf("response_headers: %w", err)
+	}
+
+	names := map[string]struct{}{defaultRouteName: {}}
+	prefixes := map[string]struct{}{}
+	for i, rc := range cfg.Routes {
+		if !strings.HasPrefix(rc.PathPrefix, "/") {
+			return fmt.Errorf("route %d: %w", i, errNoRoutePathPrefix)
+		}
+		if rc.Egress.Endpoint == "" {
+			return fmt.Errorf("route %d: %w", i, errNoRouteEndpoint)
+		}
+		if _, ok := names[rc.name()]; ok {
+			return fmt.Errorf("route %d: %w", i, errDuplicateRouteName)
+		}
+		names[rc.name()] = struct{}{}
+		if _, ok := prefixes[rc.PathPrefix]; ok {
+			return fmt.Errorf("route %d: %w", i, errDuplicatePrefix)
+		}
+		prefixes[rc.PathPrefix] = struct{}{}
+		if err := rc.RequestHeaders.validate(); err != nil {
+			return fmt.Errorf("route %d: request_headers: %w", i, err)
+		}
+		if err := rc.ResponseHeaders.validate(); err != nil {
+			return fmt.Errorf("route %d: response_headers: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (cfg *RouteConfig) name() string {
+	if cfg.Name != "" {
+		return cfg.Name
+	}
+	return cfg.PathPrefix
+}
+
+func (cfg *HeadersConfig) validate() error {
+	for name := range cfg.Add {
+		if name == "" {
+			return errEmptyHeaderName
+		}
+	}
+	for _, name := range cfg.Remove {
+		if name == "" {
+			return errEmptyHeaderName
+		}
+	}
+	return nil
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
@@ -22,8 +23,9 @@ func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		id       component.ID
-		expected component.Config
+		id          component.ID
+		expected    component.Config
+		expectedErr error
 	}{
 		{
 			id:       component.NewID(metadata.Type),
@@ -44,6 +46,65 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "routes"),
+			expected: &Config{
+				Ingress: confighttp.HTTPServerSettings{
+					Endpoint: "http://localhost:7070",
+				},
+				Egress: confighttp.HTTPClientSettings{
+					Endpoint: "http://config-service/",
+					Timeout:  10 * time.Second,
+				},
+				RequestHeaders: HeadersConfig{
+					Remove: []string{"authorization"},
+				},
+				Routes: []RouteConfig{
+					{
+						Name:        "sampling",
+						PathPrefix:  "/sampling",
+						StripPrefix: true,
+						Egress: confighttp.HTTPClientSettings{
+							Endpoint: "http://jaeger-collector:5778",
+							Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("oauth2client")},
+						},
+						RequestHeaders: HeadersConfig{
+							Add:    map[string]configopaque.String{"x-tenant": "acme"},
+							Remove: []string{"cookie"},
+						},
+						ResponseHeaders: HeadersConfig{
+							Remove: []string{"server"},
+						},
+					},
+					{
+						PathPrefix: "/api/v2",
+						Egress: confighttp.HTTPClientSettings{
+							Endpoint: "http://config-service-v2/",
+						},
+					},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalidpathprefix"),
+			expectedErr: errNoRoutePathPrefix,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "noroutendpoint"),
+			expectedErr: errNoRouteEndpoint,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicateroutename"),
+			expectedErr: errDuplicateRouteName,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "duplicatepathprefix"),
+			expectedErr: errDuplicatePrefix,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "emptyheadername"),
+			expectedErr: errEmptyHeaderName,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -54,6 +115,10 @@ func TestLoadConfig(t *testing.T) {
 			sub, err := cm.Sub(tt.id.String())
 			require.NoError(t, err)
 			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
 			assert.NoError(t, component.ValidateConfig(cfg))
 			assert.Equal(t, tt.expected, cfg)
 		})
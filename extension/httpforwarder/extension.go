@@ -9,7 +9,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"net/url"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -17,11 +17,11 @@ import (
 )
 
 type httpForwarder struct {
-	forwardTo  *url.URL
-	httpClient *http.Client
-	server     *http.Server
-	settings   component.TelemetrySettings
-	config     *Config
+	routes    []*route
+	server    *http.Server
+	settings  component.TelemetrySettings
+	config    *Config
+	telemetry *telemetry
 }
 
 var _ extension.Extension = (*httpForwarder)(nil)
@@ -32,11 +32,16 @@ func (h *httpForwarder) Start(_ context.Context, host component.Host) error {
 		return fmt.Errorf("failed to bind to address %s: %w", h.config.Ingress.Endpoint, err)
 	}
 
-	httpClient, err := h.config.Egress.ToClient(host, h.settings)
-	if err != nil {
-		return fmt.Errorf("failed to create HTTP Client: %w", err)
+	for _, r := range h.routes {
+		httpClient, err := r.egress.ToClient(host, h.settings)
+		if err != nil {
+			if r.name != defaultRouteName {
+				err = fmt.Errorf("route %q: %w", r.name, err)
+			}
+			return fmt.Errorf("failed to create HTTP Client: %w", err)
+		}
+		r.httpClient = httpClient
 	}
-	h.httpClient = httpClient
 
 	handler := http.NewServeMux()
 	handler.HandleFunc("/", h.forwardRequest)
@@ -62,16 +67,45 @@ func (h *httpForwarder) Shutdown(_ context.Context) error {
 	return h.server.Close()
 }
 
+// routeFor returns the route of the requests with the path, or nil if no route matches it.
+func (h *httpForwarder) routeFor(path string) *route {
+	for _, r := range h.routes {
+		if r.matches(path) {
+			return r
+		}
+	}
+	return nil
+}
+
 func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http.Request) {
+	r := h.routeFor(request.URL.Path)
+	if r == nil {
+		http.Error(writer, "no route matches the request path", http.StatusNotFound)
+		return
+	}
+
+	start := time.Now()
+	statusCode := h.forward(r, writer, request)
+	h.telemetry.record(request.Context(), r.name, statusCode, time.Since(start))
+}
+
+// forward forwards the request to the egress of the route, and returns the status code of the response.
+func (h *httpForwarder) forward(r *route, writer http.ResponseWriter, request *http.Request) int {
 	forwarderRequest := request.Clone(request.Context())
-	forwarderRequest.URL.Host = h.forwardTo.Host
-	forwarderRequest.URL.Scheme = h.forwardTo.Scheme
-	forwarderRequest.Host = h.forwardTo.Host
+	forwarderRequest.URL.Host = r.forwardTo.Host
+	forwarderRequest.URL.Scheme = r.forwardTo.Scheme
+	forwarderRequest.Host = r.forwardTo.Host
+	if r.stripPrefix {
+		forwarderRequest.URL.Path = r.forwardedPath(request.URL.Path)
+		forwarderRequest.URL.RawPath = ""
+	}
 	// Clear RequestURI to avoid getting "http: Request.RequestURI can't be set in client requests" error.
 	forwarderRequest.RequestURI = ""
 
+	rewriteHeaders(forwarderRequest.Header, r.requestHeaders)
+
 	// Add additional headers.
-	for k, v := range h.config.Egress.Headers {
+	for k, v := range r.egress.Headers {
 		forwarderRequest.Header.Add(k, string(v))
 	}
 
@@ -79,13 +113,10 @@ func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http
 	// See https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Via.
 	addViaHeader(forwarderRequest.Header, request.Proto, request.Host)
 
-	response, err := h.httpClient.Do(forwarderRequest)
+	response, err := r.httpClient.Do(forwarderRequest)
 	if err != nil {
 		http.Error(writer, err.Error(), http.StatusBadGateway)
-	}
-
-	if response == nil {
-		return
+		return http.StatusBadGateway
 	}
 	defer response.Body.Close()
 
@@ -93,6 +124,7 @@ func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http
 	for k := range response.Header {
 		writer.Header().Set(k, response.Header.Get(k))
 	}
+	rewriteHeaders(writer.Header(), r.responseHeaders)
 	addViaHeader(writer.Header(), response.Proto, request.Host)
 
 	writer.WriteHeader(response.StatusCode)
@@ -104,6 +136,7 @@ func (h *httpForwarder) forwardRequest(writer http.ResponseWriter, request *http
 	if response.ContentLength != written {
 		h.settings.Logger.Warn("Response from target not fully copied, body might be corrupted")
 	}
+	return response.StatusCode
 }
 
 func addViaHeader(header http.Header, protocol string, host string) {
@@ -111,19 +144,25 @@ func addViaHeader(header http.Header, protocol string, host string) {
 }
 
 func newHTTPForwarder(config *Config, settings component.TelemetrySettings) (extension.Extension, error) {
-	if config.Egress.Endpoint == "" {
+	if config.Egress.Endpoint == "" && len(config.Routes) == 0 {
 		return nil, errors.New("'egress.endpoint' config option cannot be empty")
 	}
 
-	var url, err = url.Parse(config.Egress.Endpoint)
+	routes, err := newRoutes(config)
+	if err != nil {
+		return nil, err
+	}
+
+	telemetry, err := newTelemetry(settings)
 	if err != nil {
-		return nil, fmt.Errorf("enter a valid URL for 'egress.endpoint': %w", err)
+		return nil, err
 	}
 
 	h := &httpForwarder{
 		config:    config,
-		forwardTo: url,
+		routes:    routes,
 		settings:  settings,
+		telemetry: telemetry,
 	}
 
 	return h, nil
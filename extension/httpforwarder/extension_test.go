@@ -15,10 +15,15 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/auth"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 )
@@ -249,6 +254,149 @@ func TestExtension(t *testing.T) {
 	}
 }
 
+type authHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *authHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestExtensionRoutes(t *testing.T) {
+	listenAt := testutil.GetAvailableLocalAddress(t)
+
+	newBackend := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Server", name)
+			w.Header().Set("X-Path", r.URL.Path)
+			w.Header().Set("X-Tenant", r.Header.Get("X-Tenant"))
+			w.Header().Set("X-Cookie", r.Header.Get("Cookie"))
+			w.Header().Set("X-Authorization", r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(name))
+			assert.NoError(t, err)
+		}))
+	}
+	sampling := newBackend("sampling")
+	defer sampling.Close()
+	config := newBackend("config")
+	defer config.Close()
+
+	oauth2client := auth.NewClient(auth.WithClientRoundTripper(func(base http.RoundTripper) (http.RoundTripper, error) {
+		return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			r.Header.Set("Authorization", "Bearer injected")
+			return base.RoundTrip(r)
+		}), nil
+	}))
+	host := &authHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{component.NewID("oauth2client"): oauth2client},
+	}
+
+	reader := sdkmetric.NewManualReader()
+	settings := componenttest.NewNopTelemetrySettings()
+	settings.MeterProvider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	hf, err := newHTTPForwarder(&Config{
+		Ingress: confighttp.HTTPServerSettings{
+			Endpoint: listenAt,
+		},
+		Routes: []RouteConfig{
+			{
+				Name:        "sampling",
+				PathPrefix:  "/sampling",
+				StripPrefix: true,
+				Egress: confighttp.HTTPClientSettings{
+					Endpoint: sampling.URL,
+					Auth:     &configauth.Authentication{AuthenticatorID: component.NewID("oauth2client")},
+				},
+				RequestHeaders: HeadersConfig{
+					Add:    map[string]configopaque.String{"x-tenant": "acme"},
+					Remove: []string{"cookie"},
+				},
+				ResponseHeaders: HeadersConfig{
+					Remove: []string{"server"},
+				},
+			},
+			{
+				PathPrefix: "/config",
+				Egress: confighttp.HTTPClientSettings{
+					Endpoint: config.URL,
+				},
+			},
+		},
+	}, settings)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	require.NoError(t, hf.Start(ctx, host))
+	defer func() { require.NoError(t, hf.Shutdown(ctx)) }()
+
+	get := func(path string) *http.Response {
+		request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s", listenAt, path), nil)
+		require.NoError(t, err)
+		request.Header.Set("Cookie", "session=secret")
+		request.Header.Set("Authorization", "Bearer client")
+		response, err := http.DefaultClient.Do(request)
+		require.NoError(t, err)
+		return response
+	}
+
+	response := get("/sampling/api/sampling?service=frontend")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "sampling", string(readBody(response.Body)))
+	assert.Equal(t, "/api/sampling", response.Header.Get("X-Path"))
+	assert.Equal(t, "acme", response.Header.Get("X-Tenant"))
+	assert.Equal(t, "", response.Header.Get("X-Cookie"))
+	assert.Equal(t, "Bearer injected", response.Header.Get("X-Authorization"))
+	assert.Equal(t, "", response.Header.Get("Server"))
+	require.NoError(t, response.Body.Close())
+
+	response = get("/config/pipelines")
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "config", string(readBody(response.Body)))
+	assert.Equal(t, "/config/pipelines", response.Header.Get("X-Path"))
+	assert.Equal(t, "session=secret", response.Header.Get("X-Cookie"))
+	assert.Equal(t, "Bearer client", response.Header.Get("X-Authorization"))
+	assert.Equal(t, "config", response.Header.Get("Server"))
+	require.NoError(t, response.Body.Close())
+
+	// there's no default route without the top-level egress
+	response = get("/configs")
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	require.NoError(t, response.Body.Close())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(ctx, &rm))
+	requests := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		if sm.Scope.Name != scopeName {
+			continue
+		}
+		for _, m := range sm.Metrics {
+			if m.Name != "http_forwarder_extension_requests" {
+				continue
+			}
+			for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				route, _ := dp.Attributes.Value(routeKey)
+				statusCode, _ := dp.Attributes.Value(statusCodeKey)
+				requests[fmt.Sprintf("%s %d", route.AsString(), statusCode.AsInt64())] = dp.Value
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"sampling 200": 1,
+		"/config 200":  1,
+	}, requests)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
 func httpRequest(t *testing.T, args clientRequestArgs) *http.Request {
 	r, err := http.NewRequest(args.method, args.url, io.NopCloser(strings.NewReader(args.body)))
 	require.NoError(t, err)
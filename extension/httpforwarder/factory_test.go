@@ -47,6 +47,12 @@ func TestFactory(t *testing.T) {
 			name:   "Valid config",
 			config: &Config{Egress: confighttp.HTTPClientSettings{Endpoint: "localhost:9090"}},
 		},
+		{
+			name: "Valid config with routes only",
+			config: &Config{Routes: []RouteConfig{
+				{PathPrefix: "/sampling", Egress: confighttp.HTTPClientSettings{Endpoint: "localhost:9090"}},
+			}},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
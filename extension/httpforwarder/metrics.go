@@ -0,0 +1,62 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpforwarder // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder/internal/metadata"
+)
+
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+
+	metricSep     = "_"
+	extensionKey  = "extension"
+	routeKey      = "route"
+	statusCodeKey = "status_code"
+)
+
+type telemetry struct {
+	requests        metric.Int64Counter
+	requestDuration metric.Float64Histogram
+}
+
+// newTelemetry creates the instruments reporting the number and the duration of the forwarded requests.
+func newTelemetry(set component.TelemetrySettings) (*telemetry, error) {
+	meter := set.MeterProvider.Meter(scopeName)
+
+	requests, err := meter.Int64Counter(
+		metadata.Type+metricSep+extensionKey+metricSep+"requests",
+		metric.WithDescription("Number of requests forwarded by the route"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	requestDuration, err := meter.Float64Histogram(
+		metadata.Type+metricSep+extensionKey+metricSep+"request_duration",
+		metric.WithDescription("Duration of the requests forwarded by the route, until the response is fully copied"),
+		metric.WithUnit("ms"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &telemetry{
+		requests:        requests,
+		requestDuration: requestDuration,
+	}, nil
+}
+
+func (t *telemetry) record(ctx context.Context, routeName string, statusCode int, duration time.Duration) {
+	attrs := metric.WithAttributes(attribute.String(routeKey, routeName), attribute.Int(statusCodeKey, statusCode))
+	t.requests.Add(ctx, 1, attrs)
+	t.requestDuration.Record(ctx, float64(duration)/float64(time.Millisecond), attrs)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpforwarder // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/httpforwarder"
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// defaultRouteName is the name of the route forwarding the requests to the top-level egress.
+const defaultRouteName = "default"
+
+// route forwards the requests whose path starts with its prefix to its egress.
+type route struct {
+	name        string
+	prefix      string
+	stripPrefix bool
+
+	egress          *confighttp.HTTPClientSettings
+	requestHeaders  HeadersConfig
+	responseHeaders HeadersConfig
+
+	forwardTo  *url.URL
+	httpClient *http.Client
+}
+
+// newRoutes returns the routes of the configuration, by order of precedence: the longest
+// prefixes first, then the top-level egress, if any, matching all the requests.
+func newRoutes(config *Config) ([]*route, error) {
+	routes := make([]*route, 0, len(config.Routes)+1)
+	for i := range config.Routes {
+		rc := &config.Routes[i]
+		forwardTo, err := url.Parse(rc.Egress.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("enter a valid URL for 'routes.egress.endpoint' of route %q: %w", rc.name(), err)
+		}
+		routes = append(routes, &route{
+			name:            rc.name(),
+			prefix:          rc.PathPrefix,
+			stripPrefix:     rc.StripPrefix,
+			egress:          &rc.Egress,
+			requestHeaders:  rc.RequestHeaders,
+			responseHeaders: rc.ResponseHeaders,
+			forwardTo:       forwardTo,
+		})
+	}
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(strings.TrimSuffix(routes[i].prefix, "/")) > len(strings.TrimSuffix(routes[j].prefix, "/"))
+	})
+
+	if config.Egress.Endpoint != "" {
+		forwardTo, err := url.Parse(config.Egress.Endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("enter a valid URL for 'egress.endpoint': %w", err)
+		}
+		routes = append(routes, &route{
+			name:            defaultRouteName,
+			prefix:          "/",
+			egress:          &config.Egress,
+			requestHeaders:  config.RequestHeaders,
+			responseHeaders: config.ResponseHeaders,
+			forwardTo:       forwardTo,
+		})
+	}
+	return routes, nil
+}
+
+// matches tells whether the path starts with the prefix of the route, on whole path segments.
+func (r *route) matches(path string) bool {
+	prefix := strings.TrimSuffix(r.prefix, "/")
+	return strings.HasPrefix(path, prefix) && (len(path) == len(prefix) || path[len(prefix)] == '/')
+}
+
+// forwardedPath returns the path of the forwarded request, without the prefix if it must be stripped.
+func (r *route) forwardedPath(path string) string {
+	if !r.stripPrefix {
+		return path
+	}
+	path = strings.TrimPrefix(path, strings.TrimSuffix(r.prefix, "/"))
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return path
+}
+
+// rewriteHeaders removes, then adds, the headers of the rules.
+func rewriteHeaders(header http.Header, rules HeadersConfig) {
+	for _, name := range rules.Remove {
+		header.Del(name)
+	}
+	for name, value := range rules.Add {
+		header.Set(name, string(value))
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpforwarder
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+func TestNewRoutes(t *testing.T) {
+	routes, err := newRoutes(&Config{
+		Egress: confighttp.HTTPClientSettings{Endpoint: "http://default/"},
+		Routes: []RouteConfig{
+			{PathPrefix: "/api", Egress: confighttp.HTTPClientSettings{Endpoint: "http://api/"}},
+			{Name: "sampling", PathPrefix: "/api/sampling/", Egress: confighttp.HTTPClientSettings{Endpoint: "http://sampling:5778"}},
+			{PathPrefix: "/config", Egress: confighttp.HTTPClientSettings{Endpoint: "http://config/"}},
+		},
+	})
+	require.NoError(t, err)
+
+	var names []string
+	for _, r := range routes {
+		names = append(names, r.name)
+	}
+	assert.Equal(t, []string{"sampling", "/config", "/api", defaultRouteName}, names)
+	assert.Equal(t, "sampling:5778", routes[0].forwardTo.Host)
+
+	_, err = newRoutes(&Config{
+		Routes: []RouteConfig{
+			{PathPrefix: "/api", Egress: confighttp.HTTPClientSettings{Endpoint: "123.456.7.89:9090"}},
+		},
+	})
+	assert.ErrorContains(t, err, "enter a valid URL for 'routes.egress.endpoint' of route \"/api\"")
+}
+
+func TestRouteMatches(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		path    string
+		matches bool
+	}{
+		{prefix: "/", path: "/", matches: true},
+		{prefix: "/", path: "/api", matches: true},
+		{prefix: "/api", path: "/api", matches: true},
+		{prefix: "/api", path: "/api/sampling", matches: true},
+		{prefix: "/api", path: "/apis", matches: false},
+		{prefix: "/api", path: "/", matches: false},
+		{prefix: "/api/", path: "/api", matches: true},
+		{prefix: "/api/", path: "/api/sampling", matches: true},
+		{prefix: "/api/", path: "/apis", matches: false},
+	}
+	for _, tt := range tests {
+		r := &route{prefix: tt.prefix}
+		assert.Equal(t, tt.matches, r.matches(tt.path), "prefix %q, path %q", tt.prefix, tt.path)
+	}
+}
+
+func TestRouteForwardedPath(t *testing.T) {
+	tests := []struct {
+		prefix      string
+		stripPrefix bool
+		path        string
+		expected    string
+	}{
+		{prefix: "/api", path: "/api/sampling", expected: "/api/sampling"},
+		{prefix: "/api", stripPrefix: true, path: "/api/sampling", expected: "/sampling"},
+		{prefix: "/api/", stripPrefix: true, path: "/api/sampling", expected: "/sampling"},
+		{prefix: "/api", stripPrefix: true, path: "/api", expected: "/"},
+		{prefix: "/", stripPrefix: true, path: "/sampling", expected: "/sampling"},
+	}
+	for _, tt := range tests {
+		r := &route{prefix: tt.prefix, stripPrefix: tt.stripPrefix}
+		assert.Equal(t, tt.expected, r.forwardedPath(tt.path), "prefix %q, path %q", tt.prefix, tt.path)
+	}
+}
+
+func TestRewriteHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Add("Authorization", "Bearer token")
+	header.Add("X-Tenant", "globex")
+	header.Add("X-Tenant", "initech")
+	header.Add("Accept", "application/json")
+
+	rewriteHeaders(header, HeadersConfig{
+		Add: map[string]configopaque.String{
+			"x-tenant": "acme",
+			"x-source": "collector",
+		},
+		Remove: []string{"authorization", "x-tenant"},
+	})
+
+	assert.Equal(t, http.Header{
+		"Accept":   {"application/json"},
+		"X-Source": {"collector"},
+		"X-Tenant": {"acme"},
+	}, header)
+}
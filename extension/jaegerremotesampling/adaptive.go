@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"go.uber.org/zap"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const (
+	// probabilitiesKey is the key of the probabilities in the storage.
+	probabilitiesKey = "probabilities"
+
+	// maxProbabilityIncrease caps the increase of a probability at each calculation, as the
+	// observed throughput of an operation sampled with a low probability is not accurate.
+	maxProbabilityIncrease = 2
+)
+
+var errAdaptiveNotConfigured = errors.New("the adaptive source is not configured")
+
+// Throughput is the number of sampled traces observed for an operation of a service.
+type Throughput struct {
+	Service   string
+	Operation string
+	Count     int64
+}
+
+// ThroughputRecorder records the observed throughput from which the adaptive source computes
+// the sampling probabilities. It is implemented by the extension, and used by the
+// samplingthroughput processor.
+type ThroughputRecorder interface {
+	// AdaptiveConfigured returns whether the adaptive source is configured, the throughput
+	// can't be recorded otherwise.
+	AdaptiveConfigured() bool
+	RecordThroughput(throughput []Throughput) error
+}
+
+type serviceOperation struct {
+	service   string
+	operation string
+}
+
+// adaptiveStore is a strategy store which computes per-operation probabilistic strategies
+// so that the sampled throughput of each operation reaches the target.
+type adaptiveStore struct {
+	cfg    AdaptiveSettings
+	logger *zap.Logger
+	client storage.Client
+
+	mu sync.RWMutex
+	// counts is the throughput observed since the last calculation.
+	counts map[serviceOperation]int64
+	// probabilities are the current probabilities, by service and operation.
+	probabilities map[string]map[string]float64
+
+	lastCalculation time.Time
+	done            chan struct{}
+	wg              sync.WaitGroup
+}
+
+func newAdaptiveStore(cfg AdaptiveSettings, logger *zap.Logger, client storage.Client) *adaptiveStore {
+	return &adaptiveStore{
+		cfg:           cfg.withDefaults(),
+		logger:        logger,
+		client:        client,
+		counts:        map[serviceOperation]int64{},
+		probabilities: map[string]map[string]float64{},
+		done:          make(chan struct{}),
+	}
+}
+
+// start loads the probabilities saved in the storage, and starts computing them periodically.
+func (s *adaptiveStore) start(ctx context.Context) error {
+	if s.client != nil {
+		data, err := s.client.Get(ctx, probabilitiesKey)
+		if err != nil {
+			return err
+		}
+		if data != nil {
+			if err = json.Unmarshal(data, &s.probabilities); err != nil {
+				s.logger.Warn("Ignoring invalid saved sampling probabilities", zap.Error(err))
+				s.probabilities = map[string]map[string]float64{}
+			}
+		}
+	}
+
+	s.lastCalculation = time.Now()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.cfg.CalculationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.done:
+				return
+			case now := <-ticker.C:
+				s.calculate(now.Sub(s.lastCalculation))
+				s.lastCalculation = now
+				s.save()
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *adaptiveStore) shutdown(ctx context.Context) error {
+	close(s.done)
+	s.wg.Wait()
+	if s.client != nil {
+		return s.client.Close(ctx)
+	}
+	return nil
+}
+
+// RecordThroughput adds the throughput to the throughput observed since the last calculation.
+func (s *adaptiveStore) RecordThroughput(throughput []Throughput) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range throughput {
+		s.counts[serviceOperation{service: t.Service, operation: t.Operation}] += t.Count
+	}
+}
+
+// calculate computes the probabilities of the operations observed during the elapsed duration.
+func (s *adaptiveStore) calculate(elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seconds := elapsed.Seconds()
+	if seconds <= 0 {
+		return
+	}
+	for key, count := range s.counts {
+		operations, ok := s.probabilities[key.service]
+		if !ok {
+			operations = map[string]float64{}
+			s.probabilities[key.service] = operations
+		}
+		probability, ok := operations[key.operation]
+		if !ok {
+			probability = s.cfg.InitialSamplingProbability
+		}
+		operations[key.operation] = s.newProbability(probability, float64(count)/seconds)
+	}
+	s.counts = map[serviceOperation]int64{}
+}
+
+// newProbability returns the probability at which the throughput of an operation sampled
+// with the given probability reaches the target.
+func (s *adaptiveStore) newProbability(probability float64, throughput float64) float64 {
+	target := s.cfg.TargetSamplesPerSecond
+	if throughput <= 0 || math.Abs(throughput-target)/target <= s.cfg.DeltaTolerance {
+		return probability
+	}
+
+	newProbability := probability * target / throughput
+	if newProbability > probability*maxProbabilityIncrease {
+		newProbability = probability * maxProbabilityIncrease
+	}
+	return math.Max(s.cfg.MinSamplingProbability, math.Min(s.cfg.MaxSamplingProbability, newProbability))
+}
+
+// save saves the probabilities in the storage, if any.
+func (s *adaptiveStore) save() {
+	if s.client == nil {
+		return
+	}
+	s.mu.RLock()
+	data, err := json.Marshal(s.probabilities)
+	s.mu.RUnlock()
+	if err == nil {
+		err = s.client.Set(context.Background(), probabilitiesKey, data)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to save the sampling probabilities", zap.Error(err))
+	}
+}
+
+// GetSamplingStrategy returns the per-operation strategies of the service.
+func (s *adaptiveStore) GetSamplingStrategy(_ context.Context, serviceName string) (*sampling.SamplingStrategyResponse, error) {
+	s.mu.RLock()
+	operations := s.probabilities[serviceName]
+	strategies := make([]*sampling.OperationSamplingStrategy, 0, len(operations))
+	for operation, probability := range operations {
+		strategies = append(strategies, &sampling.OperationSamplingStrategy{
+			Operation:             operation,
+			ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: probability},
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(strategies, func(i, j int) bool {
+		return strategies[i].Operation < strategies[j].Operation
+	})
+
+	return &sampling.SamplingStrategyResponse{
+		StrategyType: sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{
+			SamplingRate: s.cfg.InitialSamplingProbability,
+		},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       s.cfg.InitialSamplingProbability,
+			DefaultLowerBoundTracesPerSecond: s.cfg.MinSamplesPerSecond,
+			PerOperationStrategies:           strategies,
+		},
+	}, nil
+}
@@ -0,0 +1,152 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jaegerremotesampling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/thrift-gen/sampling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestAdaptiveNewProbability(t *testing.T) {
+	s := newAdaptiveStore(AdaptiveSettings{TargetSamplesPerSecond: 10, MinSamplingProbability: 0.01, MaxSamplingProbability: 0.5}, zap.NewNop(), nil)
+
+	testCases := []struct {
+		desc        string
+		probability float64
+		throughput  float64
+		expected    float64
+	}{
+		{
+			desc:        "no throughput",
+			probability: 0.1,
+			throughput:  0,
+			expected:    0.1,
+		},
+		{
+			desc:        "within tolerance",
+			probability: 0.1,
+			throughput:  12,
+			expected:    0.1,
+		},
+		{
+			desc:        "too many samples",
+			probability: 0.1,
+			throughput:  40,
+			expected:    0.025,
+		},
+		{
+			desc:        "increase is capped",
+			probability: 0.1,
+			throughput:  1,
+			expected:    0.2,
+		},
+		{
+			desc:        "min probability",
+			probability: 0.02,
+			throughput:  1000,
+			expected:    0.01,
+		},
+		{
+			desc:        "max probability",
+			probability: 0.4,
+			throughput:  2,
+			expected:    0.5,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.InDelta(t, tC.expected, s.newProbability(tC.probability, tC.throughput), 1e-9)
+		})
+	}
+}
+
+func TestAdaptiveCalculate(t *testing.T) {
+	s := newAdaptiveStore(AdaptiveSettings{TargetSamplesPerSecond: 1, InitialSamplingProbability: 0.1}, zap.NewNop(), nil)
+
+	s.RecordThroughput([]Throughput{
+		{Service: "foo", Operation: "op1", Count: 30},
+		{Service: "foo", Operation: "op2", Count: 5},
+		{Service: "bar", Operation: "op1", Count: 10},
+	})
+	s.RecordThroughput([]Throughput{
+		{Service: "foo", Operation: "op1", Count: 10},
+	})
+	s.calculate(10 * time.Second)
+
+	assert.Equal(t, map[string]map[string]float64{
+		"foo": {"op1": 0.025, "op2": 0.2},
+		"bar": {"op1": 0.1},
+	}, s.probabilities)
+	assert.Empty(t, s.counts)
+
+	// operations which are not observed keep their probability
+	s.RecordThroughput([]Throughput{{Service: "foo", Operation: "op2", Count: 100}})
+	s.calculate(10 * time.Second)
+	assert.Equal(t, 0.025, s.probabilities["foo"]["op1"])
+	assert.InDelta(t, 0.02, s.probabilities["foo"]["op2"], 1e-9)
+}
+
+func TestAdaptiveGetSamplingStrategy(t *testing.T) {
+	s := newAdaptiveStore(AdaptiveSettings{InitialSamplingProbability: 0.1, MinSamplesPerSecond: 0.5}, zap.NewNop(), nil)
+	s.probabilities = map[string]map[string]float64{
+		"foo": {"op2": 0.2, "op1": 0.3},
+	}
+
+	resp, err := s.GetSamplingStrategy(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, &sampling.SamplingStrategyResponse{
+		StrategyType:          sampling.SamplingStrategyType_PROBABILISTIC,
+		ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.1},
+		OperationSampling: &sampling.PerOperationSamplingStrategies{
+			DefaultSamplingProbability:       0.1,
+			DefaultLowerBoundTracesPerSecond: 0.5,
+			PerOperationStrategies: []*sampling.OperationSamplingStrategy{
+				{Operation: "op1", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.3}},
+				{Operation: "op2", ProbabilisticSampling: &sampling.ProbabilisticSamplingStrategy{SamplingRate: 0.2}},
+			},
+		},
+	}, resp)
+
+	// unknown services get the initial probability
+	resp, err = s.GetSamplingStrategy(context.Background(), "bar")
+	require.NoError(t, err)
+	assert.Equal(t, 0.1, resp.ProbabilisticSampling.SamplingRate)
+	assert.Empty(t, resp.OperationSampling.PerOperationStrategies)
+}
+
+func TestAdaptiveStorage(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindExtension, component.NewID(metadata.Type), "")
+
+	s := newAdaptiveStore(AdaptiveSettings{InitialSamplingProbability: 0.1}, zap.NewNop(), client)
+	require.NoError(t, s.start(context.Background()))
+	s.RecordThroughput([]Throughput{{Service: "foo", Operation: "op1", Count: 40}})
+	s.calculate(10 * time.Second)
+	s.save()
+
+	data, err := client.Get(context.Background(), probabilitiesKey)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"foo":{"op1":0.025}}`, string(data))
+	require.NoError(t, s.shutdown(context.Background()))
+
+	// the probabilities are loaded from the storage on start
+	client = storagetest.NewInMemoryClient(component.KindExtension, component.NewID(metadata.Type), "")
+	require.NoError(t, client.Set(context.Background(), probabilitiesKey, data))
+
+	s = newAdaptiveStore(AdaptiveSettings{}, zap.NewNop(), client)
+	require.NoError(t, s.start(context.Background()))
+	defer func() {
+		assert.NoError(t, s.shutdown(context.Background()))
+	}()
+	assert.Equal(t, map[string]map[string]float64{"foo": {"op1": 0.025}}, s.probabilities)
+}
@@ -13,9 +13,13 @@ import (
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
+	errTooManySources     = errors.New("too many sources specified, has to be either 'file', 'remote' or 'adaptive'")
+	errNoSources          = errors.New("no sources specified, has to be either 'file', 'remote' or 'adaptive'")
 	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+
+	errNegativeAdaptiveSetting = errors.New("the adaptive settings must not be negative")
+	errInvalidProbability      = errors.New("the adaptive sampling probabilities must be between 0 and 1")
+	errInvalidProbabilityRange = errors.New("the adaptive min_sampling_probability must not be greater than max_sampling_probability")
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -24,7 +28,7 @@ type Config struct {
 	*confighttp.HTTPServerSettings `mapstructure:"http"`
 	*configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 
-	// Source configures the source for the strategies file. One of `remote` or `file` has to be specified.
+	// Source configures the source for the strategies file. One of `remote`, `file` or `adaptive` has to be specified.
 	Source Source `mapstructure:"source"`
 }
 
@@ -37,6 +41,40 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive computes the strategies from the throughput observed by the samplingthroughput processor
+	Adaptive *AdaptiveSettings `mapstructure:"adaptive"`
+}
+
+// AdaptiveSettings configures the computation of the per-operation sampling probabilities
+// from the observed throughput. The zero values are replaced with the defaults.
+type AdaptiveSettings struct {
+	// TargetSamplesPerSecond is the number of traces to sample per second for each operation. Default is 1.
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// DeltaTolerance is the relative difference between the observed and the target throughput under
+	// which the probabilities are not changed. Default is 0.3.
+	DeltaTolerance float64 `mapstructure:"delta_tolerance"`
+
+	// CalculationInterval is the interval at which the probabilities are computed. Default is 1 minute.
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// InitialSamplingProbability is the probability of the operations which have not been observed yet. Default is 0.001.
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the minimum probability of an operation. Default is 0.00001.
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MaxSamplingProbability is the maximum probability of an operation. Default is 1.
+	MaxSamplingProbability float64 `mapstructure:"max_sampling_probability"`
+
+	// MinSamplesPerSecond is the number of traces sampled per second for each operation regardless of its
+	// probability, so that rare operations are sampled too. Default is 1/60.
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
+
+	// StorageID is the ID of a storage extension in which the probabilities are saved, so that they are
+	// not computed again after a restart.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -47,13 +85,66 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive != nil {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive != nil {
+		return cfg.Source.Adaptive.Validate()
+	}
+
 	return nil
 }
+
+// Validate checks if the adaptive settings are valid
+func (cfg *AdaptiveSettings) Validate() error {
+	if cfg.TargetSamplesPerSecond < 0 || cfg.DeltaTolerance < 0 || cfg.CalculationInterval < 0 || cfg.MinSamplesPerSecond < 0 {
+		return errNegativeAdaptiveSetting
+	}
+
+	for _, p := range []float64{cfg.InitialSamplingProbability, cfg.MinSamplingProbability, cfg.MaxSamplingProbability} {
+		if p < 0 || p > 1 {
+			return errInvalidProbability
+		}
+	}
+
+	if cfg.MaxSamplingProbability != 0 && cfg.MinSamplingProbability > cfg.MaxSamplingProbability {
+		return errInvalidProbabilityRange
+	}
+
+	return nil
+}
+
+// withDefaults returns the settings with the zero values replaced with the defaults.
+func (cfg AdaptiveSettings) withDefaults() AdaptiveSettings {
+	setDefault := func(v *float64, d float64) {
+		if *v == 0 {
+			*v = d
+		}
+	}
+	setDefault(&cfg.TargetSamplesPerSecond, 1)
+	setDefault(&cfg.DeltaTolerance, 0.3)
+	setDefault(&cfg.InitialSamplingProbability, 0.001)
+	setDefault(&cfg.MinSamplingProbability, 0.00001)
+	setDefault(&cfg.MaxSamplingProbability, 1)
+	setDefault(&cfg.MinSamplesPerSecond, 1.0/60)
+	if cfg.CalculationInterval == 0 {
+		cfg.CalculationInterval = time.Minute
+	}
+	return cfg
+}
@@ -22,6 +22,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -55,6 +56,23 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "2"),
+			expected: &Config{
+				HTTPServerSettings: &confighttp.HTTPServerSettings{Endpoint: ":5778"},
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{NetAddr: confignet.NetAddr{
+					Endpoint:  ":14250",
+					Transport: "tcp",
+				}},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{
+						TargetSamplesPerSecond: 2,
+						CalculationInterval:    30 * time.Second,
+						StorageID:              &storageID,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -101,6 +119,56 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "file and adaptive sources",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					File:     "/tmp/some-file",
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive source with defaults",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+		},
+		{
+			desc: "negative target",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{TargetSamplesPerSecond: -1},
+				},
+			},
+			expected: errNegativeAdaptiveSetting,
+		},
+		{
+			desc: "probability greater than 1",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{InitialSamplingProbability: 1.5},
+				},
+			},
+			expected: errInvalidProbability,
+		},
+		{
+			desc: "min probability greater than max probability",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{MinSamplingProbability: 0.5, MaxSamplingProbability: 0.1},
+				},
+			},
+			expected: errInvalidProbabilityRange,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -12,16 +12,20 @@ import (
 	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
 )
 
 var _ extension.Extension = (*jrsExtension)(nil)
+var _ ThroughputRecorder = (*jrsExtension)(nil)
 
 type jrsExtension struct {
+	id        component.ID
 	cfg       *Config
 	telemetry component.TelemetrySettings
+	adaptive  *adaptiveStore
 
 	httpServer    component.Component
 	grpcServer    component.Component
@@ -30,8 +34,9 @@ type jrsExtension struct {
 	closers []func() error
 }
 
-func newExtension(cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
+func newExtension(id component.ID, cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
 	jrse := &jrsExtension{
+		id:        id,
 		cfg:       cfg,
 		telemetry: telemetry,
 	}
@@ -43,6 +48,7 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	// source of the sampling config:
 	// - remote (gRPC)
 	// - local file
+	// - adaptive
 	// we can then use a simplified logic here to assign the appropriate store
 	if jrse.cfg.Source.File != "" {
 		opts := static.Options{
@@ -71,6 +77,26 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		})
 	}
 
+	if jrse.cfg.Source.Adaptive != nil {
+		var client storage.Client
+		if jrse.cfg.Source.Adaptive.StorageID != nil {
+			var err error
+			if client, err = jrse.getStorageClient(ctx, host, *jrse.cfg.Source.Adaptive.StorageID); err != nil {
+				return fmt.Errorf("failed to get the storage client of the adaptive source: %w", err)
+			}
+		}
+
+		adaptive := newAdaptiveStore(*jrse.cfg.Source.Adaptive, jrse.telemetry.Logger, client)
+		if err := adaptive.start(ctx); err != nil {
+			return fmt.Errorf("failed to start the adaptive source: %w", err)
+		}
+		jrse.adaptive = adaptive
+		jrse.samplingStore = adaptive
+		jrse.closers = append(jrse.closers, func() error {
+			return adaptive.shutdown(context.Background())
+		})
+	}
+
 	if jrse.cfg.HTTPServerSettings != nil {
 		httpServer, err := internal.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerSettings, jrse.samplingStore)
 		if err != nil {
@@ -98,6 +124,35 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	return nil
 }
 
+func (jrse *jrsExtension) getStorageClient(ctx context.Context, host component.Host, storageID component.ID) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindExtension, jrse.id, "")
+}
+
+// AdaptiveConfigured returns whether the adaptive source is configured. It is only known once
+// the extension is started.
+func (jrse *jrsExtension) AdaptiveConfigured() bool {
+	return jrse.adaptive != nil
+}
+
+// RecordThroughput records the throughput observed by the samplingthroughput processor.
+func (jrse *jrsExtension) RecordThroughput(throughput []Throughput) error {
+	if jrse.adaptive == nil {
+		return errAdaptiveNotConfigured
+	}
+	jrse.adaptive.RecordThroughput(throughput)
+	return nil
+}
+
 func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 	// we probably don't want to break whenever an error occurs, we want to continue and close the other resources
 	if jrse.httpServer != nil {
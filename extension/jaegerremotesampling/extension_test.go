@@ -13,16 +13,20 @@ import (
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestNewExtension(t *testing.T) {
 	// test
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(component.NewID(metadata.Type), cfg, componenttest.NewNopTelemetrySettings())
 
 	// verify
 	assert.NotNil(t, e)
@@ -33,7 +37,7 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
 
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(component.NewID(metadata.Type), cfg, componenttest.NewNopTelemetrySettings())
 	require.NotNil(t, e)
 	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -66,7 +70,7 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	}
 
 	// create the extension
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(component.NewID(metadata.Type), cfg, componenttest.NewNopTelemetrySettings())
 	require.NotNil(t, e)
 
 	// test
@@ -74,6 +78,69 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	assert.NoError(t, e.Shutdown(context.Background()))
 }
 
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveSettings{}
+
+	e := newExtension(component.NewID(metadata.Type), cfg, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, e)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	// test
+	assert.NoError(t, e.RecordThroughput([]Throughput{{Service: "foo", Operation: "op1", Count: 1}}))
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestStartAdaptiveWithStorage(t *testing.T) {
+	storageID := storagetest.NewStorageID("adaptive")
+
+	testCases := []struct {
+		desc        string
+		host        component.Host
+		expectedErr string
+	}{
+		{
+			desc: "storage extension",
+			host: storagetest.NewStorageHost().WithInMemoryStorageExtension("adaptive"),
+		},
+		{
+			desc:        "missing storage extension",
+			host:        storagetest.NewStorageHost(),
+			expectedErr: "storage extension 'test_storage/adaptive' not found",
+		},
+		{
+			desc:        "non-storage extension",
+			host:        storagetest.NewStorageHost().WithExtension(storageID, storagetest.NewNonStorageExtension("adaptive")),
+			expectedErr: "non-storage extension 'test_storage/adaptive' found",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := testConfig()
+			cfg.Source.Adaptive = &AdaptiveSettings{StorageID: &storageID}
+
+			e := newExtension(component.NewID(metadata.Type), cfg, componenttest.NewNopTelemetrySettings())
+			err := e.Start(context.Background(), tC.host)
+			if tC.expectedErr != "" {
+				assert.ErrorContains(t, err, tC.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, e.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestRecordThroughputWithoutAdaptive(t *testing.T) {
+	cfg := testConfig()
+	cfg.Source.File = filepath.Join("testdata", "strategy.json")
+
+	e := newExtension(component.NewID(metadata.Type), cfg, componenttest.NewNopTelemetrySettings())
+	assert.False(t, e.AdaptiveConfigured())
+	assert.ErrorIs(t, e.RecordThroughput([]Throughput{{Service: "foo", Operation: "op1", Count: 1}}), errAdaptiveNotConfigured)
+}
+
 type samplingServer struct {
 	api_v2.UnimplementedSamplingManagerServer
 }
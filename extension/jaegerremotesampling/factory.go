@@ -41,5 +41,5 @@ func createDefaultConfig() component.Config {
 }
 
 func createExtension(_ context.Context, set extension.CreateSettings, cfg component.Config) (extension.Extension, error) {
-	return newExtension(cfg.(*Config), set.TelemetrySettings), nil
+	return newExtension(set.ID, cfg.(*Config), set.TelemetrySettings), nil
 }
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+)
+
+var (
+	errNoCAFile       = errors.New("no ca_file provided")
+	errNoTenantName   = errors.New("the tenant name must not be empty")
+	errNoTenantFilter = errors.New("either common_name or san must be set in a tenant mapping")
+	errNoTrustedProxy = errors.New("trusted_proxies must be set when certificate_header is set")
+)
+
+// Config has the configuration for the mTLS authenticator extension.
+type Config struct {
+	// CAFile is the path of the PEM file with the certificate authorities the client certificates must chain to.
+	CAFile string `mapstructure:"ca_file"`
+
+	// AllowedCommonNames are regular expressions, one of which must match the whole common name
+	// of the client certificate. Any common name is allowed if empty.
+	AllowedCommonNames []string `mapstructure:"allowed_common_names"`
+
+	// AllowedSANs are regular expressions, one of which must match the whole value of one of the
+	// subject alternative names (DNS names, email addresses, IP addresses and URIs) of the client
+	// certificate. Any subject alternative name is allowed if empty.
+	AllowedSANs []string `mapstructure:"allowed_sans"`
+
+	// Tenants maps the client certificates to tenants. The first matching tenant is used.
+	Tenants []TenantMapping `mapstructure:"tenants"`
+
+	// CertificateHeader is the name of the HTTP header or gRPC metadata containing the URL-encoded
+	// PEM client certificate, when the mutual TLS connection is terminated by a trusted proxy.
+	// The certificate is only read from the header when the connection itself has no client certificate.
+	CertificateHeader string `mapstructure:"certificate_header"`
+
+	// TrustedProxies are the CIDR ranges of the proxies allowed to send the certificate header. The
+	// header is rejected when the request comes from any other address.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
+}
+
+// TenantMapping maps the client certificates matching the regular expressions to a tenant.
+// When both are set, both have to match.
+type TenantMapping struct {
+	// Tenant is the name of the tenant.
+	Tenant string `mapstructure:"tenant"`
+
+	// CommonName is a regular expression which must match the whole common name.
+	CommonName string `mapstructure:"common_name"`
+
+	// SAN is a regular expression which must match the whole value of one of the subject alternative names.
+	SAN string `mapstructure:"san"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.CAFile == "" {
+		return errNoCAFile
+	}
+
+	for _, pattern := range append(append([]string{}, cfg.AllowedCommonNames...), cfg.AllowedSANs...) {
+		if _, err := compilePattern(pattern); err != nil {
+			return err
+		}
+	}
+
+	if cfg.CertificateHeader != "" && len(cfg.TrustedProxies) == 0 {
+		return errNoTrustedProxy
+	}
+	if _, err := parseCIDRs(cfg.TrustedProxies); err != nil {
+		return err
+	}
+
+	for _, tenant := range cfg.Tenants {
+		if tenant.Tenant == "" {
+			return errNoTenantName
+		}
+		if tenant.CommonName == "" && tenant.SAN == "" {
+			return errNoTenantFilter
+		}
+		for _, pattern := range []string{tenant.CommonName, tenant.SAN} {
+			if _, err := compilePattern(pattern); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// compilePattern compiles a regular expression matching whole values.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return re, nil
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		expectedErr bool
+	}{
+		{
+			id:          component.NewID(metadata.Type),
+			expectedErr: true,
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "full"),
+			expected: &Config{
+				CAFile:             "/etc/otelcol/ca.pem",
+				AllowedCommonNames: []string{"agent-.*"},
+				AllowedSANs:        []string{`.*\.example\.com`},
+				Tenants: []TenantMapping{
+					{Tenant: "acme", CommonName: "agent-acme-.*"},
+					{Tenant: "globex", SAN: "spiffe://globex/.*"},
+				},
+				CertificateHeader: "X-Client-Cert",
+				TrustedProxies:    []string{"10.0.0.0/8"},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_pattern"),
+			expectedErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+			if tt.expectedErr {
+				assert.Error(t, component.ValidateConfig(cfg))
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateTenants(t *testing.T) {
+	cfg := &Config{CAFile: "ca.pem", Tenants: []TenantMapping{{CommonName: "foo"}}}
+	assert.Equal(t, errNoTenantName, cfg.Validate())
+
+	cfg.Tenants = []TenantMapping{{Tenant: "foo"}}
+	assert.Equal(t, errNoTenantFilter, cfg.Validate())
+
+	cfg.Tenants = []TenantMapping{{Tenant: "foo", SAN: "("}}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestValidateTrustedProxies(t *testing.T) {
+	cfg := &Config{CAFile: "ca.pem", CertificateHeader: "X-Client-Cert"}
+	assert.Equal(t, errNoTrustedProxy, cfg.Validate())
+
+	cfg.TrustedProxies = []string{"10.0.0.1"}
+	assert.ErrorContains(t, cfg.Validate(), "invalid trusted proxy")
+
+	cfg.TrustedProxies = []string{"10.0.0.0/8", "fd00::/8"}
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/auth"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	errNoCertificate         = errors.New("no client certificate provided")
+	errInvalidCertificate    = errors.New("invalid client certificate")
+	errCommonNameNotAllowed  = errors.New("the common name of the client certificate is not allowed")
+	errSANNotAllowed         = errors.New("no subject alternative name of the client certificate is allowed")
+	errNoCertificateInHeader = errors.New("no certificate found in the certificate header")
+	errUntrustedProxy        = errors.New("the certificate header is only accepted from the trusted proxies")
+)
+
+type tenantMatcher struct {
+	tenant     string
+	commonName *regexp.Regexp
+	san        *regexp.Regexp
+}
+
+type mtlsAuth struct {
+	cfg *Config
+
+	roots              *x509.CertPool
+	allowedCommonNames []*regexp.Regexp
+	allowedSANs        []*regexp.Regexp
+	tenants            []tenantMatcher
+	trustedProxies     []*net.IPNet
+}
+
+func newServerAuthExtension(cfg *Config) (auth.Server, error) {
+	if cfg.CAFile == "" {
+		return nil, errNoCAFile
+	}
+
+	if cfg.CertificateHeader != "" && len(cfg.TrustedProxies) == 0 {
+		return nil, errNoTrustedProxy
+	}
+
+	ma := &mtlsAuth{cfg: cfg}
+	var err error
+	if ma.trustedProxies, err = parseCIDRs(cfg.TrustedProxies); err != nil {
+		return nil, err
+	}
+	if ma.allowedCommonNames, err = compilePatterns(cfg.AllowedCommonNames); err != nil {
+		return nil, err
+	}
+	if ma.allowedSANs, err = compilePatterns(cfg.AllowedSANs); err != nil {
+		return nil, err
+	}
+	for _, tenant := range cfg.Tenants {
+		m := tenantMatcher{tenant: tenant.Tenant}
+		if tenant.CommonName != "" {
+			if m.commonName, err = compilePattern(tenant.CommonName); err != nil {
+				return nil, err
+			}
+		}
+		if tenant.SAN != "" {
+			if m.san, err = compilePattern(tenant.SAN); err != nil {
+				return nil, err
+			}
+		}
+		ma.tenants = append(ma.tenants, m)
+	}
+
+	return auth.NewServer(
+		auth.WithServerStart(ma.serverStart),
+		auth.WithServerAuthenticate(ma.authenticate),
+	), nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := compilePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+func (ma *mtlsAuth) serverStart(_ context.Context, _ component.Host) error {
+	data, err := os.ReadFile(ma.cfg.CAFile)
+	if err != nil {
+		return fmt.Errorf("read CA file: %w", err)
+	}
+
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return fmt.Errorf("no CA certificate found in %s", ma.cfg.CAFile)
+	}
+	ma.roots = roots
+
+	return nil
+}
+
+func (ma *mtlsAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	certs, err := ma.peerCertificates(ctx, headers)
+	if err != nil {
+		return ctx, err
+	}
+
+	leaf := certs[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         ma.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}); err != nil {
+		return ctx, fmt.Errorf("%w: %v", errInvalidCertificate, err)
+	}
+
+	data := newAuthData(leaf)
+	if !matchesAny(ma.allowedCommonNames, data.commonName) {
+		return ctx, errCommonNameNotAllowed
+	}
+	if !matchesAny(ma.allowedSANs, data.sans...) {
+		return ctx, errSANNotAllowed
+	}
+	data.tenant = ma.tenantOf(data)
+
+	cl := client.FromContext(ctx)
+	cl.Auth = data
+	return client.NewContext(ctx, cl), nil
+}
+
+// peerCertificates returns the client certificate chain, starting with the leaf certificate.
+// The chain presented on the TLS connection is used first, the certificate header otherwise.
+func (ma *mtlsAuth) peerCertificates(ctx context.Context, headers map[string][]string) ([]*x509.Certificate, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			return tlsInfo.State.PeerCertificates, nil
+		}
+	}
+
+	if ma.cfg.CertificateHeader == "" {
+		return nil, errNoCertificate
+	}
+	value := getHeader(headers, ma.cfg.CertificateHeader)
+	if value == "" {
+		return nil, errNoCertificate
+	}
+	// anyone can send a certificate, which is public, so it's only trusted when sent by
+	// the proxy which verified that the client holds its private key
+	if !ma.fromTrustedProxy(ctx) {
+		return nil, errUntrustedProxy
+	}
+	return parseCertificateHeader(value)
+}
+
+// fromTrustedProxy tells whether the remote address of the request is one of the trusted proxies.
+func (ma *mtlsAuth) fromTrustedProxy(ctx context.Context) bool {
+	var addr net.Addr
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		addr = p.Addr
+	} else {
+		addr = client.FromContext(ctx).Addr
+	}
+	ip := addrIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, ipNet := range ma.trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func addrIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case nil:
+		return nil
+	case *net.TCPAddr:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	default:
+		host, _, err := net.SplitHostPort(addr.String())
+		if err != nil {
+			host = addr.String()
+		}
+		return net.ParseIP(host)
+	}
+}
+
+func parseCertificateHeader(value string) ([]*x509.Certificate, error) {
+	decoded, err := url.QueryUnescape(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidCertificate, err)
+	}
+
+	var certs []*x509.Certificate
+	rest := []byte(decoded)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidCertificate, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errNoCertificateInHeader
+	}
+	return certs, nil
+}
+
+func getHeader(h map[string][]string, name string) string {
+	values, ok := h[name]
+
+	if !ok {
+		for k, v := range h {
+			if strings.EqualFold(k, name) {
+				values = v
+				break
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+// matchesAny tells whether one of the values matches one of the patterns, or if there are no patterns.
+func matchesAny(patterns []*regexp.Regexp, values ...string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		for _, value := range values {
+			if pattern.MatchString(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (ma *mtlsAuth) tenantOf(data *authData) string {
+	for _, m := range ma.tenants {
+		if m.commonName != nil && !m.commonName.MatchString(data.commonName) {
+			continue
+		}
+		if m.san != nil && !matchesAny([]*regexp.Regexp{m.san}, data.sans...) {
+			continue
+		}
+		return m.tenant
+	}
+	return ""
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+type authData struct {
+	subject    string
+	commonName string
+	issuer     string
+	sans       []string
+	tenant     string
+}
+
+func newAuthData(cert *x509.Certificate) *authData {
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.EmailAddresses)+len(cert.IPAddresses)+len(cert.URIs))
+	sans = append(sans, cert.DNSNames...)
+	sans = append(sans, cert.EmailAddresses...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	return &authData{
+		subject:    cert.Subject.String(),
+		commonName: cert.Subject.CommonName,
+		issuer:     cert.Issuer.String(),
+		sans:       sans,
+	}
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	switch name {
+	case "subject":
+		return a.subject
+	case "common_name":
+		return a.commonName
+	case "issuer":
+		return a.issuer
+	case "sans":
+		return a.sans
+	case "tenant":
+		return a.tenant
+	default:
+		return nil
+	}
+}
+
+func (*authData) GetAttributeNames() []string {
+	return []string{"subject", "common_name", "issuer", "sans", "tenant"}
+}
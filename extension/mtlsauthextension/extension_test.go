@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, commonName string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+func (ca *testCA) writePEM(t *testing.T) string {
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}), 0600))
+	return file
+}
+
+func (ca *testCA) issue(t *testing.T, template *x509.Certificate) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(2)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	if template.ExtKeyUsage == nil {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func peerContext(certs ...*x509.Certificate) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: certs}},
+	})
+}
+
+func TestAuthenticate(t *testing.T) {
+	ca := newTestCA(t, "root")
+	otherCA := newTestCA(t, "other")
+	cfg := &Config{
+		CAFile:             ca.writePEM(t),
+		AllowedCommonNames: []string{"agent-.*"},
+		AllowedSANs:        []string{`.*\.example\.com`, "spiffe://.*"},
+		Tenants: []TenantMapping{
+			{Tenant: "acme", CommonName: "agent-acme-.*"},
+			{Tenant: "globex", SAN: "spiffe://globex/.*"},
+		},
+	}
+	ext, err := newServerAuthExtension(cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	globexURI, err := url.Parse("spiffe://globex/agent")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc        string
+		ctx         context.Context
+		expectedErr error
+		expected    map[string]interface{}
+	}{
+		{
+			desc: "tenant by common name",
+			ctx: peerContext(ca.issue(t, &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "agent-acme-1", Organization: []string{"ACME"}},
+				DNSNames: []string{"agent1.example.com"},
+			})),
+			expected: map[string]interface{}{
+				"subject":     "CN=agent-acme-1,O=ACME",
+				"common_name": "agent-acme-1",
+				"issuer":      "CN=root",
+				"sans":        []string{"agent1.example.com"},
+				"tenant":      "acme",
+			},
+		},
+		{
+			desc: "tenant by SAN",
+			ctx: peerContext(ca.issue(t, &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "agent-2"},
+				DNSNames: []string{"agent2.example.com"},
+				URIs:     []*url.URL{globexURI},
+			})),
+			expected: map[string]interface{}{
+				"subject":     "CN=agent-2",
+				"common_name": "agent-2",
+				"issuer":      "CN=root",
+				"sans":        []string{"agent2.example.com", "spiffe://globex/agent"},
+				"tenant":      "globex",
+			},
+		},
+		{
+			desc: "no tenant",
+			ctx: peerContext(ca.issue(t, &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "agent-3"},
+				DNSNames: []string{"agent3.example.com"},
+			})),
+			expected: map[string]interface{}{
+				"subject":     "CN=agent-3",
+				"common_name": "agent-3",
+				"issuer":      "CN=root",
+				"sans":        []string{"agent3.example.com"},
+				"tenant":      "",
+			},
+		},
+		{
+			desc:        "no certificate",
+			ctx:         context.Background(),
+			expectedErr: errNoCertificate,
+		},
+		{
+			desc: "unknown CA",
+			ctx: peerContext(otherCA.issue(t, &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "agent-4"},
+				DNSNames: []string{"agent4.example.com"},
+			})),
+			expectedErr: errInvalidCertificate,
+		},
+		{
+			desc: "not a client certificate",
+			ctx: peerContext(ca.issue(t, &x509.Certificate{
+				Subject:     pkix.Name{CommonName: "agent-5"},
+				DNSNames:    []string{"agent5.example.com"},
+				ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+			})),
+			expectedErr: errInvalidCertificate,
+		},
+		{
+			desc: "common name not allowed",
+			ctx: peerContext(ca.issue(t, &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "admin"},
+				DNSNames: []string{"admin.example.com"},
+			})),
+			expectedErr: errCommonNameNotAllowed,
+		},
+		{
+			desc: "the whole common name must match",
+			ctx: peerContext(ca.issue(t, &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "my-agent-6"},
+				DNSNames: []string{"agent6.example.com"},
+			})),
+			expectedErr: errCommonNameNotAllowed,
+		},
+		{
+			desc: "SAN not allowed",
+			ctx: peerContext(ca.issue(t, &x509.Certificate{
+				Subject:  pkix.Name{CommonName: "agent-7"},
+				DNSNames: []string{"agent7.example.org"},
+			})),
+			expectedErr: errSANNotAllowed,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx, err := ext.Authenticate(tC.ctx, map[string][]string{})
+			if tC.expectedErr != nil {
+				assert.ErrorIs(t, err, tC.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			data := client.FromContext(ctx).Auth
+			require.NotNil(t, data)
+			assert.ElementsMatch(t, []string{"subject", "common_name", "issuer", "sans", "tenant"}, data.GetAttributeNames())
+			for name, value := range tC.expected {
+				assert.Equal(t, value, data.GetAttribute(name), name)
+			}
+			assert.Nil(t, data.GetAttribute("unknown"))
+		})
+	}
+}
+
+func TestAuthenticateCertificateHeader(t *testing.T) {
+	ca := newTestCA(t, "root")
+	cert := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "agent"}})
+	encoded := url.QueryEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+
+	cfg := &Config{CAFile: ca.writePEM(t)}
+	ext, err := newServerAuthExtension(cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	proxyCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.1.2.3"), Port: 4317}})
+
+	// the header is ignored when not configured
+	_, err = ext.Authenticate(proxyCtx, map[string][]string{"X-Client-Cert": {encoded}})
+	assert.ErrorIs(t, err, errNoCertificate)
+
+	cfg = &Config{CAFile: ca.writePEM(t), CertificateHeader: "X-Client-Cert", TrustedProxies: []string{"10.0.0.0/8"}}
+	ext, err = newServerAuthExtension(cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	ctx, err := ext.Authenticate(proxyCtx, map[string][]string{"x-client-cert": {encoded}})
+	require.NoError(t, err)
+	assert.Equal(t, "agent", client.FromContext(ctx).Auth.GetAttribute("common_name"))
+
+	// the address of the HTTP requests is in the client info
+	httpCtx := client.NewContext(context.Background(), client.Info{Addr: &net.IPAddr{IP: net.ParseIP("10.4.5.6")}})
+	_, err = ext.Authenticate(httpCtx, map[string][]string{"X-Client-Cert": {encoded}})
+	require.NoError(t, err)
+
+	_, err = ext.Authenticate(proxyCtx, map[string][]string{"X-Client-Cert": {"invalid"}})
+	assert.ErrorIs(t, err, errNoCertificateInHeader)
+
+	_, err = ext.Authenticate(proxyCtx, map[string][]string{})
+	assert.ErrorIs(t, err, errNoCertificate)
+}
+
+func TestAuthenticateCertificateHeaderUntrustedPeer(t *testing.T) {
+	ca := newTestCA(t, "root")
+	// the certificate of another client, which is public, forged in the header
+	cert := ca.issue(t, &x509.Certificate{Subject: pkix.Name{CommonName: "agent-acme-1"}})
+	encoded := url.QueryEscape(string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})))
+
+	ext, err := newServerAuthExtension(&Config{
+		CAFile:            ca.writePEM(t),
+		CertificateHeader: "X-Client-Cert",
+		TrustedProxies:    []string{"10.0.0.0/8"},
+		Tenants:           []TenantMapping{{Tenant: "acme", CommonName: "agent-acme-.*"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	for name, ctx := range map[string]context.Context{
+		"grpc":       peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.168.1.10"), Port: 4317}}),
+		"http":       client.NewContext(context.Background(), client.Info{Addr: &net.IPAddr{IP: net.ParseIP("192.168.1.10")}}),
+		"no address": context.Background(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ext.Authenticate(ctx, map[string][]string{"X-Client-Cert": {encoded}})
+			assert.ErrorIs(t, err, errUntrustedProxy)
+		})
+	}
+}
+
+func TestNewServerAuthExtensionWithoutTrustedProxies(t *testing.T) {
+	_, err := newServerAuthExtension(&Config{CAFile: "ca.pem", CertificateHeader: "X-Client-Cert"})
+	assert.ErrorIs(t, err, errNoTrustedProxy)
+}
+
+func TestServerStart(t *testing.T) {
+	ext, err := newServerAuthExtension(&Config{CAFile: filepath.Join(t.TempDir(), "missing.pem")})
+	require.NoError(t, err)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, []byte("not a certificate"), 0600))
+	ext, err = newServerAuthExtension(&Config{CAFile: file})
+	require.NoError(t, err)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+}
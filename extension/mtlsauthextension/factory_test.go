@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension/internal/metadata"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	expected := &Config{}
+	actual := createDefaultConfig()
+	assert.Equal(t, expected, createDefaultConfig())
+	assert.NoError(t, componenttest.CheckConfigStruct(actual))
+}
+
+func TestCreateExtension_DefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	assert.Equal(t, err, errNoCAFile)
+	assert.Nil(t, ext)
+}
+
+func TestCreateExtension_ValidConfig(t *testing.T) {
+	cfg := &Config{
+		CAFile: "ca.pem",
+	}
+
+	ext, err := createExtension(context.Background(), extensiontest.NewNopCreateSettings(), cfg)
+	assert.NoError(t, err)
+	assert.NotNil(t, ext)
+}
+
+func TestNewFactory(t *testing.T) {
+	f := NewFactory()
+	assert.NotNil(t, f)
+	assert.Equal(t, f.Type(), component.Type(metadata.Type))
+}
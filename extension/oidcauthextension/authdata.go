@@ -11,6 +11,7 @@ type authData struct {
 	raw        string
 	subject    string
 	membership []string
+	issuer     string
 }
 
 func (a *authData) GetAttribute(name string) interface{} {
@@ -19,6 +20,8 @@ func (a *authData) GetAttribute(name string) interface{} {
 		return a.subject
 	case "membership":
 		return a.membership
+	case "issuer":
+		return a.issuer
 	case "raw":
 		return a.raw
 	default:
@@ -27,5 +30,5 @@ func (a *authData) GetAttribute(name string) interface{} {
 }
 
 func (*authData) GetAttributeNames() []string {
-	return []string{"subject", "membership", "raw"}
+	return []string{"subject", "membership", "issuer", "raw"}
 }
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	operatorExists   = "exists"
+	operatorEquals   = "equals"
+	operatorContains = "contains"
+)
+
+var (
+	errNoClaimInRule   = errors.New("no claim provided for a required claim rule")
+	errNoValueInRule   = errors.New("no value provided for a required claim rule")
+	errUnknownOperator = errors.New("unknown operator for a required claim rule, must be one of exists, equals and contains")
+)
+
+func validateClaimRules(rules []ClaimRule) error {
+	for _, rule := range rules {
+		if rule.Claim == "" {
+			return errNoClaimInRule
+		}
+		switch rule.Operator {
+		case operatorExists:
+		case "", operatorEquals, operatorContains:
+			if rule.Value == "" {
+				return fmt.Errorf("%w: claim %q", errNoValueInRule, rule.Claim)
+			}
+		default:
+			return fmt.Errorf("%w: %q", errUnknownOperator, rule.Operator)
+		}
+	}
+	return nil
+}
+
+// checkClaimRules checks that the claims satisfy all the rules. The error is a gRPC status with the
+// PermissionDenied code, as the token itself is valid.
+func checkClaimRules(rules []ClaimRule, claims map[string]interface{}) error {
+	for _, rule := range rules {
+		if !rule.matches(claims) {
+			return status.Error(codes.PermissionDenied, rule.describe())
+		}
+	}
+	return nil
+}
+
+func (r ClaimRule) matches(claims map[string]interface{}) bool {
+	value, found := claims[r.Claim]
+	if !found {
+		return false
+	}
+
+	switch r.Operator {
+	case operatorExists:
+		return true
+	case operatorContains:
+		switch v := value.(type) {
+		case string:
+			for _, field := range strings.Fields(v) {
+				if field == r.Value {
+					return true
+				}
+			}
+		case []interface{}:
+			for i := range v {
+				if fmt.Sprintf("%v", v[i]) == r.Value {
+					return true
+				}
+			}
+		}
+		return false
+	default:
+		return fmt.Sprintf("%v", value) == r.Value
+	}
+}
+
+func (r ClaimRule) describe() string {
+	switch r.Operator {
+	case operatorExists:
+		return fmt.Sprintf("the token doesn't satisfy the required claim rule: %s exists", r.Claim)
+	case operatorContains:
+		return fmt.Sprintf("the token doesn't satisfy the required claim rule: %s contains %q", r.Claim, r.Value)
+	default:
+		return fmt.Sprintf("the token doesn't satisfy the required claim rule: %s equals %q", r.Claim, r.Value)
+	}
+}
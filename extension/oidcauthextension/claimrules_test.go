@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestValidateClaimRules(t *testing.T) {
+	assert.NoError(t, validateClaimRules([]ClaimRule{
+		{Claim: "scope", Operator: "contains", Value: "telemetry:write"},
+		{Claim: "tenant", Operator: "exists"},
+		{Claim: "azp", Value: "collector"},
+	}))
+	assert.ErrorIs(t, validateClaimRules([]ClaimRule{{Operator: "exists"}}), errNoClaimInRule)
+	assert.ErrorIs(t, validateClaimRules([]ClaimRule{{Claim: "scope", Operator: "contains"}}), errNoValueInRule)
+	assert.ErrorIs(t, validateClaimRules([]ClaimRule{{Claim: "scope", Operator: "matches", Value: "foo"}}), errUnknownOperator)
+}
+
+func TestCheckClaimRules(t *testing.T) {
+	claims := map[string]interface{}{
+		"scope":  "openid telemetry:write",
+		"groups": []interface{}{"ops", "dev"},
+		"azp":    "collector",
+		"level":  float64(3),
+	}
+
+	for _, tt := range []struct {
+		casename string
+		rule     ClaimRule
+		matches  bool
+	}{
+		{"containsInString", ClaimRule{Claim: "scope", Operator: "contains", Value: "telemetry:write"}, true},
+		{"containsPartOfString", ClaimRule{Claim: "scope", Operator: "contains", Value: "telemetry"}, false},
+		{"containsInList", ClaimRule{Claim: "groups", Operator: "contains", Value: "ops"}, true},
+		{"containsNotInList", ClaimRule{Claim: "groups", Operator: "contains", Value: "admin"}, false},
+		{"equals", ClaimRule{Claim: "azp", Operator: "equals", Value: "collector"}, true},
+		{"equalsByDefault", ClaimRule{Claim: "azp", Value: "other"}, false},
+		{"equalsNumber", ClaimRule{Claim: "level", Value: "3"}, true},
+		{"exists", ClaimRule{Claim: "azp", Operator: "exists"}, true},
+		{"missingClaim", ClaimRule{Claim: "tenant", Operator: "exists"}, false},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			err := checkClaimRules([]ClaimRule{tt.rule}, claims)
+			if tt.matches {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		})
+	}
+}
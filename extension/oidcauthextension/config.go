@@ -3,6 +3,8 @@
 
 package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
 
+import "time"
+
 // Config has the configuration for the OIDC Authenticator extension.
 type Config struct {
 
@@ -10,11 +12,51 @@ type Config struct {
 	Attribute string `mapstructure:"attribute"`
 
 	// IssuerURL is the base URL for the OIDC provider.
-	// Required.
+	// Required, unless Providers are configured.
 	IssuerURL string `mapstructure:"issuer_url"`
 
 	// Audience of the token, used during the verification.
 	// For example: "https://accounts.google.com" or "https://login.salesforce.com".
+	// Required, unless Providers are configured.
+	Audience string `mapstructure:"audience"`
+
+	// The local path for the issuer CA's TLS server cert.
+	// Optional.
+	IssuerCAPath string `mapstructure:"issuer_ca_path"`
+
+	// JWKSFile is the local path of a JSON Web Key Set used to verify the tokens, instead of the keys
+	// obtained through the discovery of the OIDC provider. Useful when the OIDC provider isn't reachable.
+	// Optional.
+	JWKSFile string `mapstructure:"jwks_file"`
+
+	// JWKSReloadInterval is the interval at which the JWKSFile is reloaded. Optional, default value: 5m.
+	JWKSReloadInterval time.Duration `mapstructure:"jwks_reload_interval"`
+
+	// The claim to use as the username, in case the token's 'sub' isn't the suitable source.
+	// Optional.
+	UsernameClaim string `mapstructure:"username_claim"`
+
+	// The claim that holds the subject's group membership information.
+	// Optional.
+	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// Providers are additional OIDC providers whose tokens are accepted. The provider verifying a token
+	// is selected by the token's issuer.
+	// Optional.
+	Providers []ProviderConfig `mapstructure:"providers"`
+
+	// RequiredClaims are rules which all have to be satisfied by the claims of the tokens.
+	// Optional.
+	RequiredClaims []ClaimRule `mapstructure:"required_claims"`
+}
+
+// ProviderConfig has the configuration of an OIDC provider.
+type ProviderConfig struct {
+	// IssuerURL is the base URL for the OIDC provider.
+	// Required.
+	IssuerURL string `mapstructure:"issuer_url"`
+
+	// Audience of the token, used during the verification.
 	// Required.
 	Audience string `mapstructure:"audience"`
 
@@ -22,6 +64,14 @@ type Config struct {
 	// Optional.
 	IssuerCAPath string `mapstructure:"issuer_ca_path"`
 
+	// JWKSFile is the local path of a JSON Web Key Set used to verify the tokens, instead of the keys
+	// obtained through the discovery of the OIDC provider.
+	// Optional.
+	JWKSFile string `mapstructure:"jwks_file"`
+
+	// JWKSReloadInterval is the interval at which the JWKSFile is reloaded. Optional, default value: 5m.
+	JWKSReloadInterval time.Duration `mapstructure:"jwks_reload_interval"`
+
 	// The claim to use as the username, in case the token's 'sub' isn't the suitable source.
 	// Optional.
 	UsernameClaim string `mapstructure:"username_claim"`
@@ -30,3 +80,35 @@ type Config struct {
 	// Optional.
 	GroupsClaim string `mapstructure:"groups_claim"`
 }
+
+// ClaimRule is a condition on a claim of the tokens.
+type ClaimRule struct {
+	// Claim is the name of the claim.
+	// Required.
+	Claim string `mapstructure:"claim"`
+
+	// Operator is one of "exists", "equals" and "contains". "contains" matches a value of a list claim,
+	// or a value of a space-separated string claim such as "scope".
+	// Optional, default value: "equals".
+	Operator string `mapstructure:"operator"`
+
+	// Value is the value the claim is compared to. Not used by the "exists" operator.
+	Value string `mapstructure:"value"`
+}
+
+// providers returns the configuration of all the OIDC providers, starting with the top-level one, if any.
+func (cfg *Config) providers() []ProviderConfig {
+	var providers []ProviderConfig
+	if cfg.IssuerURL != "" || cfg.Audience != "" {
+		providers = append(providers, ProviderConfig{
+			IssuerURL:          cfg.IssuerURL,
+			Audience:           cfg.Audience,
+			IssuerCAPath:       cfg.IssuerCAPath,
+			JWKSFile:           cfg.JWKSFile,
+			JWKSReloadInterval: cfg.JWKSReloadInterval,
+			UsernameClaim:      cfg.UsernameClaim,
+			GroupsClaim:        cfg.GroupsClaim,
+		})
+	}
+	return append(providers, cfg.Providers...)
+}
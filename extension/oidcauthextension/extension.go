@@ -7,6 +7,8 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -27,30 +29,55 @@ import (
 type oidcExtension struct {
 	cfg *Config
 
-	provider *oidc.Provider
-	verifier *oidc.IDTokenVerifier
+	// providers are the OIDC providers, by issuer URL
+	providers map[string]*oidcProvider
+	// defaultProvider verifies the tokens when there's a single provider
+	defaultProvider *oidcProvider
 
 	logger *zap.Logger
 }
 
+type oidcProvider struct {
+	cfg ProviderConfig
+
+	verifier *oidc.IDTokenVerifier
+	keySet   *fileKeySet
+}
+
 var (
 	errNoAudienceProvided                = errors.New("no Audience provided for the OIDC configuration")
 	errNoIssuerURL                       = errors.New("no IssuerURL provided for the OIDC configuration")
+	errDuplicateIssuerURL                = errors.New("duplicate IssuerURL in the OIDC configuration")
 	errInvalidAuthenticationHeaderFormat = errors.New("invalid authorization header format")
 	errFailedToObtainClaimsFromToken     = errors.New("failed to get the subject from the token issued by the OIDC provider")
 	errClaimNotFound                     = errors.New("username claim from the OIDC configuration not found on the token returned by the OIDC provider")
 	errUsernameNotString                 = errors.New("the username returned by the OIDC provider isn't a regular string")
 	errGroupsClaimNotFound               = errors.New("groups claim from the OIDC configuration not found on the token returned by the OIDC provider")
 	errNotAuthenticated                  = errors.New("authentication didn't succeed")
+	errUnknownIssuer                     = errors.New("the token isn't issued by one of the configured OIDC providers")
 )
 
 func newExtension(cfg *Config, logger *zap.Logger) (auth.Server, error) {
-	if cfg.Audience == "" {
-		return nil, errNoAudienceProvided
-	}
-	if cfg.IssuerURL == "" {
+	providers := cfg.providers()
+	if len(providers) == 0 {
 		return nil, errNoIssuerURL
 	}
+	issuers := map[string]struct{}{}
+	for _, provider := range providers {
+		if provider.Audience == "" {
+			return nil, errNoAudienceProvided
+		}
+		if provider.IssuerURL == "" {
+			return nil, errNoIssuerURL
+		}
+		if _, ok := issuers[provider.IssuerURL]; ok {
+			return nil, fmt.Errorf("%w: %s", errDuplicateIssuerURL, provider.IssuerURL)
+		}
+		issuers[provider.IssuerURL] = struct{}{}
+	}
+	if err := validateClaimRules(cfg.RequiredClaims); err != nil {
+		return nil, err
+	}
 
 	if cfg.Attribute == "" {
 		cfg.Attribute = defaultAttribute
@@ -60,20 +87,62 @@ func newExtension(cfg *Config, logger *zap.Logger) (auth.Server, error) {
 		cfg:    cfg,
 		logger: logger,
 	}
-	return auth.NewServer(auth.WithServerStart(oe.start), auth.WithServerAuthenticate(oe.authenticate)), nil
+	return auth.NewServer(
+		auth.WithServerStart(oe.start),
+		auth.WithServerAuthenticate(oe.authenticate),
+		auth.WithServerShutdown(oe.shutdown),
+	), nil
 }
 
 func (e *oidcExtension) start(context.Context, component.Host) error {
-	provider, err := getProviderForConfig(e.cfg)
-	if err != nil {
-		return fmt.Errorf("failed to get configuration from the auth server: %w", err)
+	providers := e.cfg.providers()
+	e.providers = make(map[string]*oidcProvider, len(providers))
+	for _, cfg := range providers {
+		provider, err := newOIDCProvider(cfg, e.logger)
+		if err != nil {
+			return err
+		}
+		e.providers[cfg.IssuerURL] = provider
+	}
+	if len(providers) == 1 {
+		e.defaultProvider = e.providers[providers[0].IssuerURL]
 	}
-	e.provider = provider
 
-	e.verifier = e.provider.Verifier(&oidc.Config{
-		ClientID: e.cfg.Audience,
-	})
+	return nil
+}
+
+func newOIDCProvider(cfg ProviderConfig, logger *zap.Logger) (*oidcProvider, error) {
+	p := &oidcProvider{cfg: cfg}
+	oidcConfig := &oidc.Config{
+		ClientID: cfg.Audience,
+	}
 
+	// the keys of the JWKS file are used without the discovery, which requires the OIDC provider to be reachable
+	if cfg.JWKSFile != "" {
+		keySet, err := newFileKeySet(cfg.JWKSFile, cfg.JWKSReloadInterval, logger)
+		if err != nil {
+			return nil, err
+		}
+		keySet.start()
+		p.keySet = keySet
+		p.verifier = oidc.NewVerifier(cfg.IssuerURL, keySet, oidcConfig)
+		return p, nil
+	}
+
+	provider, err := getProviderForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get configuration from the auth server: %w", err)
+	}
+	p.verifier = provider.Verifier(oidcConfig)
+	return p, nil
+}
+
+func (e *oidcExtension) shutdown(context.Context) error {
+	for _, provider := range e.providers {
+		if provider.keySet != nil {
+			provider.keySet.shutdown()
+		}
+	}
 	return nil
 }
 
@@ -92,7 +161,11 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 	}
 
 	raw := parts[1]
-	idToken, err := e.verifier.Verify(ctx, raw)
+	provider, err := e.providerFor(raw)
+	if err != nil {
+		return ctx, err
+	}
+	idToken, err := provider.verifier.Verify(ctx, raw)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to verify token: %w", err)
 	}
@@ -108,11 +181,15 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 		return ctx, errFailedToObtainClaimsFromToken
 	}
 
-	subject, err := getSubjectFromClaims(claims, e.cfg.UsernameClaim, idToken.Subject)
+	if err = checkClaimRules(e.cfg.RequiredClaims, claims); err != nil {
+		return ctx, err
+	}
+
+	subject, err := getSubjectFromClaims(claims, provider.cfg.UsernameClaim, idToken.Subject)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get subject from claims in the token: %w", err)
 	}
-	membership, err := getGroupsFromClaims(claims, e.cfg.GroupsClaim)
+	membership, err := getGroupsFromClaims(claims, provider.cfg.GroupsClaim)
 	if err != nil {
 		return ctx, fmt.Errorf("failed to get groups from claims in the token: %w", err)
 	}
@@ -122,10 +199,46 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 		raw:        raw,
 		subject:    subject,
 		membership: membership,
+		issuer:     idToken.Issuer,
 	}
 	return client.NewContext(ctx, cl), nil
 }
 
+// providerFor returns the provider of the token's issuer. The token is verified afterwards by the provider.
+func (e *oidcExtension) providerFor(raw string) (*oidcProvider, error) {
+	if e.defaultProvider != nil {
+		return e.defaultProvider, nil
+	}
+
+	issuer, err := getUnverifiedIssuer(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify token: %w", err)
+	}
+	provider, ok := e.providers[issuer]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownIssuer, issuer)
+	}
+	return provider, nil
+}
+
+func getUnverifiedIssuer(raw string) (string, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return "", errors.New("malformed jwt, expected 3 parts")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed jwt payload: %w", err)
+	}
+	var claims struct {
+		Issuer string `json:"iss"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("malformed jwt claims: %w", err)
+	}
+	return claims.Issuer, nil
+}
+
 func getSubjectFromClaims(claims map[string]interface{}, usernameClaim string, fallback string) (string, error) {
 	if len(usernameClaim) > 0 {
 		username, found := claims[usernameClaim]
@@ -169,7 +282,7 @@ func getGroupsFromClaims(claims map[string]interface{}, groupsClaim string) ([]s
 	return []string{}, nil
 }
 
-func getProviderForConfig(config *Config) (*oidc.Provider, error) {
+func getProviderForConfig(config ProviderConfig) (*oidc.Provider, error) {
 	t := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		DialContext: (&net.Dialer{
@@ -15,13 +15,17 @@ import (
 	"math/big"
 	"net"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestOIDCAuthenticationSucceeded(t *testing.T) {
@@ -70,6 +74,208 @@ func TestOIDCAuthenticationSucceeded(t *testing.T) {
 	// TODO(jpkroehling): assert that the authentication routine set the subject/membership to the resource
 }
 
+func TestOIDCMultipleProviders(t *testing.T) {
+	// prepare
+	first, err := newOIDCServer()
+	require.NoError(t, err)
+	first.Start()
+	defer first.Close()
+
+	// the second provider isn't reachable, its keys are read from a JWKS file
+	second, err := newOIDCServer()
+	require.NoError(t, err)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, second.writeJWKS(jwksFile))
+
+	config := &Config{
+		IssuerURL: first.URL,
+		Audience:  "unit-test",
+		Providers: []ProviderConfig{
+			{
+				IssuerURL:     "https://second.example.com",
+				Audience:      "other-audience",
+				JWKSFile:      jwksFile,
+				UsernameClaim: "email",
+			},
+		},
+	}
+	p, err := newExtension(config, zap.NewNop())
+	require.NoError(t, err)
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}()
+
+	for _, tt := range []struct {
+		casename        string
+		server          *oidcServer
+		claims          map[string]interface{}
+		expectedSubject string
+		expectedError   error
+	}{
+		{
+			"firstProvider",
+			first,
+			map[string]interface{}{
+				"sub": "jdoe@example.com",
+				"iss": first.URL,
+				"aud": "unit-test",
+			},
+			"jdoe@example.com",
+			nil,
+		},
+		{
+			"secondProvider",
+			second,
+			map[string]interface{}{
+				"sub":   "1234",
+				"email": "jane@example.com",
+				"iss":   "https://second.example.com",
+				"aud":   "other-audience",
+			},
+			"jane@example.com",
+			nil,
+		},
+		{
+			"unknownIssuer",
+			second,
+			map[string]interface{}{
+				"sub": "1234",
+				"iss": "https://third.example.com",
+				"aud": "other-audience",
+			},
+			"",
+			errUnknownIssuer,
+		},
+		{
+			"signedByAnotherProvider",
+			first,
+			map[string]interface{}{
+				"sub": "1234",
+				"iss": "https://second.example.com",
+				"aud": "other-audience",
+			},
+			"",
+			errNoMatchingKey,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			tt.claims["exp"] = time.Now().Add(time.Minute).Unix()
+			payload, _ := json.Marshal(tt.claims)
+			token, err := tt.server.token(payload)
+			require.NoError(t, err)
+
+			// test
+			ctx, err := p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+			// verify
+			if tt.expectedError != nil {
+				// the OIDC library doesn't wrap the errors of the key sets
+				assert.ErrorContains(t, err, tt.expectedError.Error())
+				return
+			}
+			require.NoError(t, err)
+			authData := client.FromContext(ctx).Auth
+			assert.Equal(t, tt.expectedSubject, authData.GetAttribute("subject"))
+			assert.Equal(t, tt.claims["iss"], authData.GetAttribute("issuer"))
+		})
+	}
+}
+
+func TestOIDCRequiredClaims(t *testing.T) {
+	// prepare
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	p, err := newExtension(&Config{
+		IssuerURL: oidcServer.URL,
+		Audience:  "unit-test",
+		RequiredClaims: []ClaimRule{
+			{Claim: "scope", Operator: "contains", Value: "telemetry:write"},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		scope        string
+		expectedCode codes.Code
+	}{
+		{"openid telemetry:write", codes.OK},
+		{"openid telemetry:read", codes.PermissionDenied},
+	} {
+		t.Run(tt.scope, func(t *testing.T) {
+			payload, _ := json.Marshal(map[string]interface{}{
+				"sub":   "jdoe@example.com",
+				"iss":   oidcServer.URL,
+				"aud":   "unit-test",
+				"exp":   time.Now().Add(time.Minute).Unix(),
+				"scope": tt.scope,
+			})
+			token, err := oidcServer.token(payload)
+			require.NoError(t, err)
+
+			// test
+			_, err = p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+			// verify
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+		})
+	}
+}
+
+func TestInvalidProviders(t *testing.T) {
+	for _, tt := range []struct {
+		casename      string
+		config        *Config
+		expectedError error
+	}{
+		{
+			"noProvider",
+			&Config{},
+			errNoIssuerURL,
+		},
+		{
+			"missingAudience",
+			&Config{Providers: []ProviderConfig{{IssuerURL: "http://example.com/"}}},
+			errNoAudienceProvided,
+		},
+		{
+			"duplicateIssuer",
+			&Config{
+				IssuerURL: "http://example.com/",
+				Audience:  "some-audience",
+				Providers: []ProviderConfig{{IssuerURL: "http://example.com/", Audience: "other-audience"}},
+			},
+			errDuplicateIssuerURL,
+		},
+		{
+			"invalidRule",
+			&Config{
+				IssuerURL:      "http://example.com/",
+				Audience:       "some-audience",
+				RequiredClaims: []ClaimRule{{Claim: "scope", Operator: "matches"}},
+			},
+			errUnknownOperator,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			// test
+			p, err := newExtension(tt.config, zap.NewNop())
+
+			// verify
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
 func TestOIDCProviderForConfigWithTLS(t *testing.T) {
 	// prepare the CA cert for the TLS handler
 	cert := x509.Certificate{
@@ -106,7 +312,7 @@ func TestOIDCProviderForConfigWithTLS(t *testing.T) {
 	oidcServer.StartTLS()
 
 	// prepare the processor configuration
-	config := &Config{
+	config := ProviderConfig{
 		IssuerURL:    oidcServer.URL,
 		IssuerCAPath: caFile.Name(),
 		Audience:     "unit-test",
@@ -181,7 +387,7 @@ func TestOIDCFailedToLoadIssuerCAFromPathInvalidContent(t *testing.T) {
 	_, err = file.Write([]byte("foobar"))
 	require.NoError(t, err)
 
-	config := &Config{
+	config := ProviderConfig{
 		IssuerCAPath: file.Name(),
 	}
 
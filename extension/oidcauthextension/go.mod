@@ -10,6 +10,8 @@ require (
 	go.opentelemetry.io/collector/extension v0.81.0
 	go.opentelemetry.io/collector/extension/auth v0.81.0
 	go.uber.org/zap v1.24.0
+	google.golang.org/grpc v1.56.2
+	gopkg.in/square/go-jose.v2 v2.5.1
 )
 
 require (
@@ -39,9 +41,7 @@ require (
 	golang.org/x/text v0.11.0 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
 	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
-	google.golang.org/grpc v1.56.2 // indirect
 	google.golang.org/protobuf v1.31.0 // indirect
-	gopkg.in/square/go-jose.v2 v2.5.1 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
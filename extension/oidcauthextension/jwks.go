@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"gopkg.in/square/go-jose.v2"
+)
+
+const defaultJWKSReloadInterval = 5 * time.Minute
+
+var errNoMatchingKey = errors.New("failed to verify the token signature with the keys of the JWKS file")
+
+// fileKeySet is an oidc.KeySet with the keys read from a local JWKS file, periodically reloaded.
+type fileKeySet struct {
+	path     string
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu      sync.RWMutex
+	keys    []jose.JSONWebKey
+	modTime time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newFileKeySet(path string, interval time.Duration, logger *zap.Logger) (*fileKeySet, error) {
+	if interval <= 0 {
+		interval = defaultJWKSReloadInterval
+	}
+	ks := &fileKeySet{
+		path:     filepath.Clean(path),
+		interval: interval,
+		logger:   logger,
+	}
+	if err := ks.load(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// load reads the keys from the file, if it was modified since the last time it was read.
+func (ks *fileKeySet) load() error {
+	info, err := os.Stat(ks.path)
+	if err != nil {
+		return fmt.Errorf("could not read the JWKS file %q: %w", ks.path, err)
+	}
+
+	ks.mu.RLock()
+	unchanged := info.ModTime().Equal(ks.modTime)
+	ks.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	raw, err := os.ReadFile(ks.path)
+	if err != nil {
+		return fmt.Errorf("could not read the JWKS file %q: %w", ks.path, err)
+	}
+	var keySet jose.JSONWebKeySet
+	if err = json.Unmarshal(raw, &keySet); err != nil {
+		return fmt.Errorf("cannot decode the contents of the JWKS file %q: %w", ks.path, err)
+	}
+	if len(keySet.Keys) == 0 {
+		return fmt.Errorf("no key found in the JWKS file %q", ks.path)
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	ks.keys = keySet.Keys
+	ks.modTime = info.ModTime()
+	return nil
+}
+
+// start reloads the file in the background until shutdown is called.
+func (ks *fileKeySet) start() {
+	ks.stop = make(chan struct{})
+	ks.done = make(chan struct{})
+	go func() {
+		defer close(ks.done)
+		ticker := time.NewTicker(ks.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// the previous keys are kept when the file can't be loaded
+				if err := ks.load(); err != nil {
+					ks.logger.Warn("Failed to reload the JWKS file", zap.Error(err))
+				}
+			case <-ks.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (ks *fileKeySet) shutdown() {
+	if ks.stop == nil {
+		return
+	}
+	close(ks.stop)
+	<-ks.done
+	ks.stop = nil
+}
+
+// VerifySignature implements oidc.KeySet.
+func (ks *fileKeySet) VerifySignature(_ context.Context, jwt string) ([]byte, error) {
+	jws, err := jose.ParseSigned(jwt)
+	if err != nil {
+		return nil, fmt.Errorf("malformed jwt: %w", err)
+	}
+
+	// multiple signatures aren't supported, as by the key sets of the OIDC library
+	keyID := ""
+	if len(jws.Signatures) > 0 {
+		keyID = jws.Signatures[0].Header.KeyID
+	}
+
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	for i := range ks.keys {
+		if keyID == "" || ks.keys[i].KeyID == keyID {
+			if payload, err := jws.Verify(&ks.keys[i]); err == nil {
+				return payload, nil
+			}
+		}
+	}
+	return nil, errNoMatchingKey
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package oidcauthextension
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileKeySet(t *testing.T) {
+	// prepare
+	first, err := newOIDCServer()
+	require.NoError(t, err)
+	second, err := newOIDCServer()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, first.writeJWKS(path))
+
+	ks, err := newFileKeySet(path, time.Minute, zap.NewNop())
+	require.NoError(t, err)
+
+	payload, _ := json.Marshal(map[string]interface{}{"sub": "jdoe@example.com"})
+	firstToken, err := first.token(payload)
+	require.NoError(t, err)
+	secondToken, err := second.token(payload)
+	require.NoError(t, err)
+
+	// test
+	verified, err := ks.VerifySignature(context.Background(), firstToken)
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, payload, verified)
+	_, err = ks.VerifySignature(context.Background(), secondToken)
+	assert.ErrorIs(t, err, errNoMatchingKey)
+	_, err = ks.VerifySignature(context.Background(), "some-token")
+	assert.Error(t, err)
+
+	// the keys are replaced once the file is modified
+	require.NoError(t, second.writeJWKS(path))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	require.NoError(t, ks.load())
+
+	_, err = ks.VerifySignature(context.Background(), firstToken)
+	assert.ErrorIs(t, err, errNoMatchingKey)
+	_, err = ks.VerifySignature(context.Background(), secondToken)
+	assert.NoError(t, err)
+
+	// the previous keys are kept when the file is invalid
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+	require.NoError(t, os.Chtimes(path, modTime.Add(time.Minute), modTime.Add(time.Minute)))
+	assert.Error(t, ks.load())
+	_, err = ks.VerifySignature(context.Background(), secondToken)
+	assert.NoError(t, err)
+}
+
+func TestFileKeySetReload(t *testing.T) {
+	// prepare
+	first, err := newOIDCServer()
+	require.NoError(t, err)
+	second, err := newOIDCServer()
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, first.writeJWKS(path))
+
+	ks, err := newFileKeySet(path, 10*time.Millisecond, zap.NewNop())
+	require.NoError(t, err)
+	ks.start()
+	defer ks.shutdown()
+
+	payload, _ := json.Marshal(map[string]interface{}{"sub": "jdoe@example.com"})
+	token, err := second.token(payload)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, second.writeJWKS(path))
+	modTime := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		_, err := ks.VerifySignature(context.Background(), token)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileKeySetInvalidFile(t *testing.T) {
+	_, err := newFileKeySet(filepath.Join(t.TempDir(), "missing.json"), 0, zap.NewNop())
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte("foobar"), 0600))
+	_, err = newFileKeySet(path, 0, zap.NewNop())
+	assert.Error(t, err)
+}
@@ -19,7 +19,10 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"time"
+
+	"gopkg.in/square/go-jose.v2"
 )
 
 // oidcServer is an overly simplified OIDC mock server, good enough to sign the tokens required by the test
@@ -130,3 +133,16 @@ func createPrivateKey() (*rsa.PrivateKey, error) {
 	}
 	return priv, nil
 }
+
+// writeJWKS writes the JSON Web Key Set with the public key of the server to the file
+func (s *oidcServer) writeJWKS(path string) error {
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{{
+		Key:       &s.privateKey.PublicKey,
+		Algorithm: "RS256",
+		Use:       "sig",
+	}}})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, jwks, 0600)
+}
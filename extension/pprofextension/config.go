@@ -4,6 +4,11 @@
 package pprofextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
 
 import (
+	"errors"
+	"fmt"
+	"runtime/pprof"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confignet"
 )
@@ -29,11 +34,125 @@ type Config struct {
 	// Optional file name to save the CPU profile to. The profiling starts when the
 	// Collector starts and is saved to the file when the Collector is terminated.
 	SaveToFile string `mapstructure:"save_to_file"`
+
+	// Snapshots configures the periodic capture of profiles to a directory.
+	// Optional, the profiles are not captured if not set.
+	Snapshots *SnapshotsConfig `mapstructure:"snapshots"`
+}
+
+// SnapshotsConfig configures the periodic capture of profiles to a directory.
+type SnapshotsConfig struct {
+	// Directory is where the profiles are written, named after the profile and the time
+	// at which it was captured. Required.
+	Directory string `mapstructure:"directory"`
+
+	// Interval between two captures. Default value: 5m.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// CPUDuration is the duration of the CPU profile captured at each interval, which must be
+	// shorter than the interval. Optional, the CPU profiles are not captured if not set.
+	CPUDuration time.Duration `mapstructure:"cpu_duration"`
+
+	// Profiles are the names of the runtime profiles captured at each interval, such as
+	// heap, goroutine, mutex, block, allocs and threadcreate. Default value: [heap, goroutine].
+	Profiles []string `mapstructure:"profiles"`
+
+	// MaxFiles is the maximum number of profiles kept in the directory, the oldest ones are
+	// deleted first. Default value: 100.
+	MaxFiles int `mapstructure:"max_files"`
+
+	// MaxSizeMiB is the maximum total size of the profiles kept in the directory, the oldest
+	// ones are deleted first. A value of 0 disables the limit.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+
+	// MemoryTrigger captures a heap profile when the resident memory of the process crosses
+	// a threshold. Optional.
+	MemoryTrigger *MemoryTriggerConfig `mapstructure:"memory_trigger"`
+}
+
+// MemoryTriggerConfig configures the capture of heap profiles when the resident memory of the
+// process crosses a threshold.
+type MemoryTriggerConfig struct {
+	// RSSLimitMiB is the resident memory above which a heap profile is captured. Required.
+	RSSLimitMiB uint64 `mapstructure:"rss_limit_mib"`
+
+	// CheckInterval is the interval at which the resident memory is checked. Default value: 10s.
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// Cooldown is the minimum duration between two heap profiles captured by the trigger,
+	// while the resident memory stays above the threshold. Default value: 10m.
+	Cooldown time.Duration `mapstructure:"cooldown"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the extension configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.Snapshots == nil {
+		return nil
+	}
+	if cfg.SaveToFile != "" && cfg.Snapshots.CPUDuration > 0 {
+		return errors.New("\"save_to_file\" and the CPU profiles of \"snapshots\" can't be used together, set \"snapshots::cpu_duration\" to 0")
+	}
+	return cfg.Snapshots.Validate()
+}
+
+// Validate checks if the snapshots configuration is valid
+func (cfg *SnapshotsConfig) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("\"directory\" is required when capturing snapshots")
+	}
+	if cfg.Interval < 0 {
+		return errors.New("\"interval\" must not be negative")
+	}
+	if cfg.CPUDuration < 0 || cfg.CPUDuration >= cfg.withDefaults().Interval {
+		return errors.New("\"cpu_duration\" must be shorter than \"interval\"")
+	}
+	for _, profile := range cfg.Profiles {
+		if pprof.Lookup(profile) == nil {
+			return fmt.Errorf("unknown profile %q", profile)
+		}
+	}
+	if cfg.MaxFiles < 0 {
+		return errors.New("\"max_files\" must not be negative")
+	}
+	if cfg.MaxSizeMiB < 0 {
+		return errors.New("\"max_size_mib\" must not be negative")
+	}
+	if trigger := cfg.MemoryTrigger; trigger != nil {
+		if trigger.RSSLimitMiB == 0 {
+			return errors.New("\"memory_trigger::rss_limit_mib\" must be positive")
+		}
+		if trigger.CheckInterval < 0 {
+			return errors.New("\"memory_trigger::check_interval\" must not be negative")
+		}
+		if trigger.Cooldown < 0 {
+			return errors.New("\"memory_trigger::cooldown\" must not be negative")
+		}
+	}
 	return nil
 }
+
+// withDefaults returns a copy of the configuration with the default values of the unset settings.
+func (cfg SnapshotsConfig) withDefaults() SnapshotsConfig {
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultSnapshotsInterval
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = []string{"heap", "goroutine"}
+	}
+	if cfg.MaxFiles == 0 {
+		cfg.MaxFiles = defaultSnapshotsMaxFiles
+	}
+	if cfg.MemoryTrigger != nil {
+		trigger := *cfg.MemoryTrigger
+		if trigger.CheckInterval == 0 {
+			trigger.CheckInterval = defaultMemoryTriggerCheckInterval
+		}
+		if trigger.Cooldown == 0 {
+			trigger.Cooldown = defaultMemoryTriggerCooldown
+		}
+		cfg.MemoryTrigger = &trigger
+	}
+	return cfg
+}
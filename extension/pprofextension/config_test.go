@@ -6,6 +6,7 @@ package pprofextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,8 +21,9 @@ func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
-		id       component.ID
-		expected component.Config
+		id          component.ID
+		expected    component.Config
+		expectedErr string
 	}{
 		{
 			id:       component.NewID(metadata.Type),
@@ -35,6 +37,43 @@ func TestLoadConfig(t *testing.T) {
 				MutexProfileFraction: 5,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "snapshots"),
+			expected: &Config{
+				TCPAddr: confignet.TCPAddr{Endpoint: defaultEndpoint},
+				Snapshots: &SnapshotsConfig{
+					Directory:   "/var/lib/otelcol/profiles",
+					Interval:    10 * time.Minute,
+					CPUDuration: 30 * time.Second,
+					Profiles:    []string{"heap", "goroutine", "mutex"},
+					MaxFiles:    50,
+					MaxSizeMiB:  200,
+					MemoryTrigger: &MemoryTriggerConfig{
+						RSSLimitMiB: 1024,
+					},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_profile"),
+			expectedErr: `unknown profile "unknown"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "cpu_too_long"),
+			expectedErr: `"cpu_duration" must be shorter than "interval"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "cpu_and_save_to_file"),
+			expectedErr: `"save_to_file" and the CPU profiles of "snapshots" can't be used together`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_directory"),
+			expectedErr: `"directory" is required`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_rss_limit"),
+			expectedErr: `"memory_trigger::rss_limit_mib" must be positive`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -46,6 +85,10 @@ func TestLoadConfig(t *testing.T) {
 			require.NoError(t, err)
 			require.NoError(t, component.UnmarshalConfig(sub, cfg))
 
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
 			assert.NoError(t, component.ValidateConfig(cfg))
 			assert.Equal(t, tt.expected, cfg)
 		})
@@ -6,6 +6,7 @@ package pprofextension // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -16,6 +17,11 @@ import (
 
 const (
 	defaultEndpoint = "localhost:1777"
+
+	defaultSnapshotsInterval          = 5 * time.Minute
+	defaultSnapshotsMaxFiles          = 100
+	defaultMemoryTriggerCheckInterval = 10 * time.Second
+	defaultMemoryTriggerCooldown      = 10 * time.Minute
 )
 
 // NewFactory creates a factory for pprof extension.
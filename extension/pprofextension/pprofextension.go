@@ -21,11 +21,12 @@ import (
 var running = &atomic.Bool{}
 
 type pprofExtension struct {
-	config Config
-	logger *zap.Logger
-	file   *os.File
-	server http.Server
-	stopCh chan struct{}
+	config      Config
+	logger      *zap.Logger
+	file        *os.File
+	server      http.Server
+	stopCh      chan struct{}
+	snapshotter *snapshotter
 }
 
 func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
@@ -78,7 +79,14 @@ func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
 			return startErr
 		}
 		p.file = f
-		startErr = pprof.StartCPUProfile(f)
+		if startErr = pprof.StartCPUProfile(f); startErr != nil {
+			return startErr
+		}
+	}
+
+	if p.config.Snapshots != nil {
+		p.snapshotter = newSnapshotter(*p.config.Snapshots, p.logger)
+		startErr = p.snapshotter.start()
 	}
 
 	return startErr
@@ -86,6 +94,9 @@ func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
 
 func (p *pprofExtension) Shutdown(context.Context) error {
 	defer running.Store(false)
+	if p.snapshotter != nil {
+		p.snapshotter.shutdown()
+	}
 	if p.file != nil {
 		pprof.StopCPUProfile()
 		_ = p.file.Close() // ignore the error
@@ -10,7 +10,9 @@ import (
 	"os"
 	"runtime"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confignet"
@@ -131,3 +133,27 @@ func TestPerformanceProfilerLifecycleWithFile(t *testing.T) {
 	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
 	require.NoError(t, pprofExt.Shutdown(context.Background()))
 }
+
+func TestPerformanceProfilerLifecycleWithSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	config := Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		Snapshots: &SnapshotsConfig{
+			Directory: dir,
+			Interval:  10 * time.Millisecond,
+			Profiles:  []string{"heap"},
+		},
+	}
+
+	pprofExt := newServer(config, zap.NewNop())
+	require.NotNil(t, pprofExt)
+
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		return err == nil && len(entries) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+}
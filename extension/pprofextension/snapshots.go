@@ -0,0 +1,236 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/pprof"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	cpuProfile      = "cpu"
+	memoryTrigger   = "rss"
+	timestampLayout = "20060102T150405.000Z"
+	profileFileExt  = ".pprof"
+	mib             = 1024 * 1024
+)
+
+// snapshotFileRegexp matches the names of the files written by the snapshotter, so that
+// the retention never deletes other files of the directory.
+var snapshotFileRegexp = regexp.MustCompile(`^[a-z]+(-` + memoryTrigger + `)?-\d{8}T\d{6}\.\d{3}Z\` + profileFileExt + `$`)
+
+// snapshotter periodically captures profiles to a directory, and deletes the oldest ones
+// once the retention limits are exceeded.
+type snapshotter struct {
+	cfg    SnapshotsConfig
+	logger *zap.Logger
+
+	now     func() time.Time
+	readRSS func() (uint64, error)
+
+	// mu guards the enforcement of the retention and the time of the last triggered profile
+	mu            sync.Mutex
+	lastTriggered time.Time
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newSnapshotter(cfg SnapshotsConfig, logger *zap.Logger) *snapshotter {
+	return &snapshotter{
+		cfg:     cfg.withDefaults(),
+		logger:  logger,
+		now:     time.Now,
+		readRSS: readRSS,
+	}
+}
+
+func (s *snapshotter) start() error {
+	if err := os.MkdirAll(s.cfg.Directory, 0700); err != nil {
+		return fmt.Errorf("failed to create the snapshots directory: %w", err)
+	}
+
+	s.stopCh = make(chan struct{})
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(s.cfg.Interval, s.captureScheduled)
+	}()
+
+	if s.cfg.MemoryTrigger != nil {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.run(s.cfg.MemoryTrigger.CheckInterval, s.checkMemory)
+		}()
+	}
+	return nil
+}
+
+func (s *snapshotter) shutdown() {
+	if s.stopCh == nil {
+		return
+	}
+	close(s.stopCh)
+	s.wg.Wait()
+	s.stopCh = nil
+}
+
+func (s *snapshotter) run(interval time.Duration, f func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f()
+		case <-s.stopCh:
+			return
+		}
+	}
+}
+
+// captureScheduled captures the CPU profile, if enabled, then the configured profiles.
+func (s *snapshotter) captureScheduled() {
+	if s.cfg.CPUDuration > 0 {
+		if err := s.captureCPU(); err != nil {
+			s.logger.Warn("Failed to capture the CPU profile", zap.Error(err))
+		}
+	}
+	for _, profile := range s.cfg.Profiles {
+		if err := s.capture(profile, ""); err != nil {
+			s.logger.Warn("Failed to capture the profile", zap.String("profile", profile), zap.Error(err))
+		}
+	}
+	s.enforceRetention()
+}
+
+// checkMemory captures a heap profile if the resident memory is above the limit, at most once per cooldown.
+func (s *snapshotter) checkMemory() {
+	rss, err := s.readRSS()
+	if err != nil {
+		s.logger.Warn("Failed to read the resident memory", zap.Error(err))
+		return
+	}
+	if rss < s.cfg.MemoryTrigger.RSSLimitMiB*mib {
+		return
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	if !s.lastTriggered.IsZero() && now.Sub(s.lastTriggered) < s.cfg.MemoryTrigger.Cooldown {
+		s.mu.Unlock()
+		return
+	}
+	s.lastTriggered = now
+	s.mu.Unlock()
+
+	s.logger.Info("Resident memory above the limit, capturing a heap profile",
+		zap.Uint64("rss_mib", rss/mib), zap.Uint64("rss_limit_mib", s.cfg.MemoryTrigger.RSSLimitMiB))
+	if err = s.capture("heap", memoryTrigger); err != nil {
+		s.logger.Warn("Failed to capture the heap profile", zap.Error(err))
+	}
+	s.enforceRetention()
+}
+
+// captureCPU captures a CPU profile for the configured duration, or until the shutdown.
+func (s *snapshotter) captureCPU() error {
+	return s.writeFile(cpuProfile, "", func(f *os.File) error {
+		// fails if a CPU profile is already being captured, for instance through the HTTP endpoint
+		if err := pprof.StartCPUProfile(f); err != nil {
+			return err
+		}
+		timer := time.NewTimer(s.cfg.CPUDuration)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-s.stopCh:
+		}
+		pprof.StopCPUProfile()
+		return nil
+	})
+}
+
+func (s *snapshotter) capture(profile string, trigger string) error {
+	p := pprof.Lookup(profile)
+	if p == nil {
+		return fmt.Errorf("unknown profile %q", profile)
+	}
+	return s.writeFile(profile, trigger, func(f *os.File) error {
+		return p.WriteTo(f, 0)
+	})
+}
+
+// writeFile writes a profile to a temporary file, renamed once complete, so that incomplete
+// profiles are never found in the directory.
+func (s *snapshotter) writeFile(profile string, trigger string, write func(f *os.File) error) error {
+	name := profile
+	if trigger != "" {
+		name += "-" + trigger
+	}
+	name += "-" + s.now().UTC().Format(timestampLayout) + profileFileExt
+
+	f, err := os.CreateTemp(s.cfg.Directory, "."+name+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if err = write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(s.cfg.Directory, name))
+}
+
+// enforceRetention deletes the oldest profiles until the retention limits are satisfied.
+func (s *snapshotter) enforceRetention() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := os.ReadDir(s.cfg.Directory)
+	if err != nil {
+		s.logger.Warn("Failed to list the snapshots", zap.Error(err))
+		return
+	}
+
+	var files []os.FileInfo
+	var totalSize int64
+	for _, entry := range entries {
+		if entry.IsDir() || !snapshotFileRegexp.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		totalSize += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if !files[i].ModTime().Equal(files[j].ModTime()) {
+			return files[i].ModTime().Before(files[j].ModTime())
+		}
+		return files[i].Name() < files[j].Name()
+	})
+
+	maxSize := s.cfg.MaxSizeMiB * mib
+	for len(files) > 0 && (len(files) > s.cfg.MaxFiles || (maxSize > 0 && totalSize > maxSize)) {
+		if err := os.Remove(filepath.Join(s.cfg.Directory, files[0].Name())); err != nil {
+			s.logger.Warn("Failed to delete a snapshot", zap.String("file", files[0].Name()), zap.Error(err))
+		}
+		totalSize -= files[0].Size()
+		files = files[1:]
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package pprofextension
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func listSnapshots(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestSnapshotterCaptureScheduled(t *testing.T) {
+	dir := t.TempDir()
+	s := newSnapshotter(SnapshotsConfig{
+		Directory:   dir,
+		CPUDuration: 10 * time.Millisecond,
+		Profiles:    []string{"heap", "goroutine", "mutex"},
+	}, zap.NewNop())
+	s.now = func() time.Time { return time.Date(2023, 7, 12, 3, 0, 0, 0, time.UTC) }
+
+	s.captureScheduled()
+
+	assert.Equal(t, []string{
+		"cpu-20230712T030000.000Z.pprof",
+		"goroutine-20230712T030000.000Z.pprof",
+		"heap-20230712T030000.000Z.pprof",
+		"mutex-20230712T030000.000Z.pprof",
+	}, listSnapshots(t, dir))
+	for _, name := range listSnapshots(t, dir) {
+		assert.Regexp(t, snapshotFileRegexp, name)
+		info, err := os.Stat(filepath.Join(dir, name))
+		require.NoError(t, err)
+		assert.NotZero(t, info.Size(), name)
+	}
+}
+
+func TestSnapshotterRetention(t *testing.T) {
+	dir := t.TempDir()
+	s := newSnapshotter(SnapshotsConfig{
+		Directory:  dir,
+		MaxFiles:   3,
+		MaxSizeMiB: 1,
+	}, zap.NewNop())
+
+	now := time.Now()
+	write := func(name string, size int, age time.Duration) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0600))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+	}
+	write("heap-20230712T030000.000Z.pprof", 10, 5*time.Minute)
+	write("goroutine-20230712T030000.000Z.pprof", 10, 4*time.Minute)
+	write("heap-rss-20230712T030100.000Z.pprof", 10, 3*time.Minute)
+	write("heap-20230712T030500.000Z.pprof", 10, 2*time.Minute)
+	write("goroutine-20230712T030500.000Z.pprof", 10, time.Minute)
+	// the files not written by the snapshotter are kept
+	write("notes.txt", 10, time.Hour)
+
+	s.enforceRetention()
+	assert.Equal(t, []string{
+		"goroutine-20230712T030500.000Z.pprof",
+		"heap-20230712T030500.000Z.pprof",
+		"heap-rss-20230712T030100.000Z.pprof",
+		"notes.txt",
+	}, listSnapshots(t, dir))
+
+	// the size limit is enforced as well
+	write("heap-20230712T031000.000Z.pprof", mib, 0)
+	s.enforceRetention()
+	assert.Equal(t, []string{
+		"heap-20230712T031000.000Z.pprof",
+		"notes.txt",
+	}, listSnapshots(t, dir))
+}
+
+func TestSnapshotterMemoryTrigger(t *testing.T) {
+	dir := t.TempDir()
+	s := newSnapshotter(SnapshotsConfig{
+		Directory:     dir,
+		MemoryTrigger: &MemoryTriggerConfig{RSSLimitMiB: 100, Cooldown: time.Minute},
+	}, zap.NewNop())
+
+	now := time.Date(2023, 7, 12, 3, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	rss := uint64(50 * mib)
+	s.readRSS = func() (uint64, error) { return rss, nil }
+
+	s.checkMemory()
+	assert.Empty(t, listSnapshots(t, dir))
+
+	rss = 150 * mib
+	s.checkMemory()
+	assert.Equal(t, []string{"heap-rss-20230712T030000.000Z.pprof"}, listSnapshots(t, dir))
+
+	// no profile is captured again until the cooldown elapsed
+	now = now.Add(30 * time.Second)
+	s.checkMemory()
+	assert.Len(t, listSnapshots(t, dir), 1)
+
+	now = now.Add(30 * time.Second)
+	s.checkMemory()
+	assert.Equal(t, []string{
+		"heap-rss-20230712T030000.000Z.pprof",
+		"heap-rss-20230712T030100.000Z.pprof",
+	}, listSnapshots(t, dir))
+}
+
+func TestSnapshotterLifecycle(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	s := newSnapshotter(SnapshotsConfig{
+		Directory: dir,
+		Interval:  10 * time.Millisecond,
+		Profiles:  []string{"goroutine"},
+		MaxFiles:  2,
+	}, zap.NewNop())
+
+	require.NoError(t, s.start())
+	assert.Eventually(t, func() bool {
+		return len(listSnapshots(t, dir)) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	s.shutdown()
+	s.shutdown()
+
+	assert.LessOrEqual(t, len(listSnapshots(t, dir)), 2)
+}
+
+func TestReadRSS(t *testing.T) {
+	rss, err := readRSS()
+	require.NoError(t, err)
+	assert.Positive(t, rss)
+}
@@ -19,6 +19,8 @@ import (
 
 var defaultBucket = []byte(`default`)
 
+var errClientClosed = errors.New("client is closed")
+
 const (
 	elapsedKey       = "elapsed"
 	directoryKey     = "directory"
@@ -32,9 +34,19 @@ type fileStorageClient struct {
 	compactionMutex sync.RWMutex
 	db              *bbolt.DB
 	compactionCfg   *CompactionConfig
+	cipher          *valueCipher
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+
+	limits clientLimits
+	// batchMutex serializes the batches, so that storedBytes and storedEntries match the committed transactions
+	batchMutex sync.Mutex
+	// storedBytes is the size of the keys and values, only tracked when the client has limits
+	storedBytes int64
+	// storedEntries is the number of entries
+	storedEntries int64
+	now           func() time.Time
 }
 
 func bboltOptions(timeout time.Duration) *bbolt.Options {
@@ -46,23 +58,49 @@ func bboltOptions(timeout time.Duration) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, cipher *valueCipher, limits clientLimits) (*fileStorageClient, error) {
 	options := bboltOptions(timeout)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
 		return nil, err
 	}
 
+	var storedBytes, storedEntries int64
+	var encrypted int
 	initBucket := func(tx *bbolt.Tx) error {
-		_, err := tx.CreateBucketIfNotExists(defaultBucket)
+		bucket, err := tx.CreateBucketIfNotExists(defaultBucket)
+		if err != nil {
+			return err
+		}
+		storedEntries = int64(bucket.Stats().KeyN)
+		if encrypted, err = initEncryption(tx, cipher); err != nil {
+			return err
+		}
+		if !limits.tracked() {
+			return removeEntryTracking(tx)
+		}
+		storedBytes, err = initEntries(tx, time.Now())
 		return err
 	}
 	if err := db.Update(initBucket); err != nil {
 		_ = db.Close()
 		return nil, err
 	}
+	if encrypted > 0 {
+		logger.Info("encrypted the values stored before the encryption was enabled", zap.Int("count", encrypted))
+	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{
+		logger:        logger,
+		db:            db,
+		compactionCfg: compactionCfg,
+		cipher:        cipher,
+		openTimeout:   timeout,
+		limits:        limits,
+		storedBytes:   storedBytes,
+		storedEntries: storedEntries,
+		now:           time.Now,
+	}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -93,6 +131,9 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 
 // Batch executes the specified operations in order. Get operation results are updated in place
 func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) error {
+	var tracked *trackedEntries
+	// entriesDelta is the change of the number of entries, when they are not tracked
+	var entriesDelta int64
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
@@ -100,22 +141,56 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 		}
 
 		var err error
+		if c.limits.tracked() {
+			if tracked, err = newTrackedEntries(tx, c.limits, c.now()); err != nil {
+				return err
+			}
+			// the expired entries are removed before the operations, so that they are never returned
+			if err = tracked.expire(); err != nil {
+				return err
+			}
+		}
+
 		for _, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case c.cipher != nil:
+					// decrypting allocates a new slice, which remains valid after the transaction
+					op.Value, err = c.cipher.decrypt(value)
+				default:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.cipher != nil {
+					if value, err = c.cipher.encrypt(value); err != nil {
+						return err
+					}
+				}
+				if tracked != nil {
+					err = tracked.set([]byte(op.Key), value)
+				} else {
+					if bucket.Get([]byte(op.Key)) == nil {
+						entriesDelta++
+					}
+					err = bucket.Put([]byte(op.Key), value)
+				}
 			case storage.Delete:
-				err = bucket.Delete([]byte(op.Key))
+				if tracked != nil {
+					err = tracked.delete([]byte(op.Key))
+				} else {
+					if bucket.Get([]byte(op.Key)) != nil {
+						entriesDelta--
+					}
+					err = bucket.Delete([]byte(op.Key))
+				}
 			default:
 				return errors.New("wrong operation type")
 			}
@@ -125,12 +200,25 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...storage.Operation) e
 			}
 		}
 
+		if tracked != nil {
+			return tracked.enforceQuota(c.storedBytes)
+		}
 		return nil
 	}
 
 	c.compactionMutex.RLock()
 	defer c.compactionMutex.RUnlock()
-	return c.db.Update(batch)
+	c.batchMutex.Lock()
+	defer c.batchMutex.Unlock()
+	if err := c.db.Update(batch); err != nil {
+		return err
+	}
+	if tracked != nil {
+		c.storedBytes += tracked.delta
+		entriesDelta = tracked.entriesDelta
+	}
+	c.storedEntries += entriesDelta
+	return nil
 }
 
 // Close will close the database
@@ -145,6 +233,12 @@ func (c *fileStorageClient) Close(_ context.Context) error {
 	return c.db.Close()
 }
 
+func (c *fileStorageClient) isClosed() bool {
+	c.compactionMutex.RLock()
+	defer c.compactionMutex.RUnlock()
+	return c.closed
+}
+
 // Compact database. Use temporary file as helper as we cannot replace database in-place
 func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Duration, maxTransactionSize int64) error {
 	var err error
@@ -185,6 +279,12 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 		zap.String(directoryKey, c.db.Path()),
 		zap.String(tempDirectoryKey, file.Name()))
 
+	// re-encrypt the values encrypted with a previous key before compacting,
+	// so that the space of the old values is reclaimed by the compaction
+	if err = c.reencrypt(maxTransactionSize); err != nil {
+		return fmt.Errorf("failed to re-encrypt values: %w", err)
+	}
+
 	// cannot reuse newClient as db shouldn't contain any bucket
 	compactedDb, err = bbolt.Open(file.Name(), 0600, options)
 	if err != nil {
@@ -237,6 +337,80 @@ func (c *fileStorageClient) Compact(compactionDirectory string, timeout time.Dur
 	return nil
 }
 
+// reencrypt encrypts the values encrypted with a previous key with the current key,
+// with at most maxTransactionSize values per transaction
+func (c *fileStorageClient) reencrypt(maxTransactionSize int64) error {
+	if c.cipher == nil {
+		return nil
+	}
+
+	var reencrypted int
+	// delta is the change of the size of the values, applied to storedBytes
+	var delta int64
+	defer func() {
+		if c.limits.tracked() {
+			c.batchMutex.Lock()
+			c.storedBytes += delta
+			c.batchMutex.Unlock()
+		}
+	}()
+	var next []byte
+	for first := true; first || next != nil; first = false {
+		err := c.db.Update(func(tx *bbolt.Tx) error {
+			bucket := tx.Bucket(defaultBucket)
+			if bucket == nil {
+				return errors.New("storage not initialized")
+			}
+
+			// the bucket can't be modified while iterating, so the values are collected first
+			var keys, values [][]byte
+			var txDelta int64
+			cursor := bucket.Cursor()
+			k, v := cursor.First()
+			if next != nil {
+				k, v = cursor.Seek(next)
+			}
+			next = nil
+			for ; k != nil; k, v = cursor.Next() {
+				if maxTransactionSize > 0 && int64(len(keys)) >= maxTransactionSize {
+					next = append([]byte{}, k...)
+					break
+				}
+				if !c.cipher.needsReencryption(v) {
+					continue
+				}
+				value, err := c.cipher.decrypt(v)
+				if err != nil {
+					return err
+				}
+				if value, err = c.cipher.encrypt(value); err != nil {
+					return err
+				}
+				keys = append(keys, append([]byte{}, k...))
+				values = append(values, value)
+				txDelta += int64(len(value) - len(v))
+			}
+
+			for i, key := range keys {
+				if err := bucket.Put(key, values[i]); err != nil {
+					return err
+				}
+			}
+			reencrypted += len(keys)
+			delta += txDelta
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if reencrypted > 0 {
+		c.logger.Info("re-encrypted values with the current key", zap.Int("count", reencrypted))
+	}
+	return nil
+}
+
 // startCompactionLoop provides asynchronous compaction function
 func (c *fileStorageClient) startCompactionLoop(ctx context.Context) {
 	ctx, c.cancel = context.WithCancel(ctx)
@@ -295,6 +469,24 @@ func (c *fileStorageClient) shouldCompact() bool {
 	return true
 }
 
+// stats returns the size of the database and the number of entries
+func (c *fileStorageClient) stats() (int64, int64, error) {
+	c.compactionMutex.RLock()
+	defer c.compactionMutex.RUnlock()
+	if c.closed {
+		return 0, 0, errClientClosed
+	}
+
+	totalSize, _, err := c.getDbSize()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	c.batchMutex.Lock()
+	defer c.batchMutex.Unlock()
+	return totalSize, c.storedEntries, nil
+}
+
 func (c *fileStorageClient) getDbSize() (totalSizeResult int64, dataSizeResult int64, errResult error) {
 	var totalSize int64
 
@@ -17,6 +17,30 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	// Encryption enables the encryption of the stored values
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+
+	// TTL is the duration after which the entries expire, from the time they were last set.
+	// Entries never expire if it is 0
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+
+	// Quota limits the size of the data stored by each client
+	Quota *QuotaConfig `mapstructure:"quota,omitempty"`
+}
+
+const (
+	onExceededReject      = "reject"
+	onExceededEvictOldest = "evict_oldest"
+)
+
+// QuotaConfig defines configuration for the optional limit of the data stored by each client.
+type QuotaConfig struct {
+	// MaxSizeMiB is the maximum size of the keys and values stored by each client. There is no limit if it is 0
+	MaxSizeMiB int64 `mapstructure:"max_size_mib,omitempty"`
+	// OnExceeded specifies what happens when an operation would exceed the maximum size:
+	// either `reject` the operation (default), or `evict_oldest` entries until the data fits
+	OnExceeded string `mapstructure:"on_exceeded,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -44,6 +68,20 @@ type CompactionConfig struct {
 	CheckInterval time.Duration `mapstructure:"check_interval,omitempty"`
 }
 
+// EncryptionConfig defines configuration for the optional AES-GCM encryption of the stored values.
+// The keys are base64 encoded, and are 16, 24 or 32 bytes long to select AES-128, AES-192 or AES-256.
+type EncryptionConfig struct {
+	// KeyFile is the path of the file containing the key used to encrypt the values
+	KeyFile string `mapstructure:"key_file,omitempty"`
+	// KeyEnv is the name of the environment variable containing the key used to encrypt the values
+	KeyEnv string `mapstructure:"key_env,omitempty"`
+	// PreviousKeyFiles are the paths of the files containing keys which were previously used.
+	// The values encrypted with them can still be read, and are re-encrypted with the current key on compaction
+	PreviousKeyFiles []string `mapstructure:"previous_key_files,omitempty"`
+	// PreviousKeyEnvs are the names of the environment variables containing keys which were previously used
+	PreviousKeyEnvs []string `mapstructure:"previous_key_envs,omitempty"`
+}
+
 func (cfg *Config) Validate() error {
 	var dirs []string
 	if cfg.Compaction.OnStart {
@@ -76,5 +114,24 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.TTL < 0 {
+		return errors.New("ttl cannot be negative")
+	}
+
+	if cfg.Quota != nil {
+		if cfg.Quota.MaxSizeMiB < 0 {
+			return errors.New("quota max size cannot be less than 0")
+		}
+		switch cfg.Quota.OnExceeded {
+		case "", onExceededReject, onExceededEvictOldest:
+		default:
+			return fmt.Errorf("unknown quota on_exceeded policy %q, must be %q or %q", cfg.Quota.OnExceeded, onExceededReject, onExceededEvictOldest)
+		}
+	}
+
+	if cfg.Encryption != nil && (cfg.Encryption.KeyFile == "") == (cfg.Encryption.KeyEnv == "") {
+		return errors.New("exactly one of the encryption key file and key environment variable must be set")
+	}
+
 	return nil
 }
@@ -49,6 +49,31 @@ func TestLoadConfig(t *testing.T) {
 				Timeout: 2 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "encryption"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.Encryption = &EncryptionConfig{
+					KeyFile:         "/etc/otelcol/file_storage.key",
+					PreviousKeyEnvs: []string{"FILE_STORAGE_PREVIOUS_KEY"},
+				}
+				return ret
+			}(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "limits"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.TTL = 24 * time.Hour
+				ret.Quota = &QuotaConfig{
+					MaxSizeMiB: 512,
+					OnExceeded: "evict_oldest",
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -93,3 +118,37 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestEncryptionKeySourceValidation(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = "."
+
+	cfg.Encryption = &EncryptionConfig{}
+	assert.EqualError(t, component.ValidateConfig(cfg), "exactly one of the encryption key file and key environment variable must be set")
+
+	cfg.Encryption = &EncryptionConfig{KeyFile: "key", KeyEnv: "KEY"}
+	assert.EqualError(t, component.ValidateConfig(cfg), "exactly one of the encryption key file and key environment variable must be set")
+
+	cfg.Encryption = &EncryptionConfig{KeyEnv: "KEY"}
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
+
+func TestLimitsValidation(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = "."
+
+	cfg.TTL = -time.Second
+	assert.EqualError(t, component.ValidateConfig(cfg), "ttl cannot be negative")
+	cfg.TTL = 0
+
+	cfg.Quota = &QuotaConfig{MaxSizeMiB: -1}
+	assert.EqualError(t, component.ValidateConfig(cfg), "quota max size cannot be less than 0")
+
+	cfg.Quota = &QuotaConfig{MaxSizeMiB: 1, OnExceeded: "drop"}
+	assert.EqualError(t, component.ValidateConfig(cfg), `unknown quota on_exceeded policy "drop", must be "reject" or "evict_oldest"`)
+
+	cfg.Quota = &QuotaConfig{MaxSizeMiB: 1, OnExceeded: "reject"}
+	assert.NoError(t, component.ValidateConfig(cfg))
+}
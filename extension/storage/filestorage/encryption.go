@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.etcd.io/bbolt"
+)
+
+const (
+	// encryptionVersion is the first byte of the encrypted values, identifying their format:
+	// the version, the key ID, the nonce, and the ciphertext with the authentication tag.
+	encryptionVersion = 1
+	keyIDSize         = 4
+)
+
+var (
+	// encryptionBucket marks the databases whose values are all encrypted. It is created once the values
+	// stored before the encryption was enabled are encrypted.
+	encryptionBucket = []byte(`encryption`)
+
+	errUnknownKey              = errors.New("value is encrypted with an unknown key")
+	errNotEncrypted            = errors.New("value is not encrypted")
+	errEncryptionNotConfigured = errors.New("the stored values are encrypted, but the encryption is not configured")
+)
+
+// valueCipher encrypts the values with AES-GCM. The values are prefixed with the ID of
+// the key they are encrypted with, so that values encrypted with a previous key can still
+// be decrypted, and re-encrypted with the current key during compaction.
+type valueCipher struct {
+	keyID [keyIDSize]byte
+	aead  cipher.AEAD
+	// previous are the AEADs of the previous keys, by key ID
+	previous map[[keyIDSize]byte]cipher.AEAD
+}
+
+func newValueCipher(cfg *EncryptionConfig) (*valueCipher, error) {
+	key, err := loadKey(cfg.KeyFile, cfg.KeyEnv)
+	if err != nil {
+		return nil, err
+	}
+	c := &valueCipher{previous: map[[keyIDSize]byte]cipher.AEAD{}}
+	if c.aead, err = newAEAD(key); err != nil {
+		return nil, err
+	}
+	c.keyID = keyID(key)
+
+	previousKeys := make([][]byte, 0, len(cfg.PreviousKeyFiles)+len(cfg.PreviousKeyEnvs))
+	for _, file := range cfg.PreviousKeyFiles {
+		if key, err = loadKey(file, ""); err != nil {
+			return nil, err
+		}
+		previousKeys = append(previousKeys, key)
+	}
+	for _, env := range cfg.PreviousKeyEnvs {
+		if key, err = loadKey("", env); err != nil {
+			return nil, err
+		}
+		previousKeys = append(previousKeys, key)
+	}
+	for _, key := range previousKeys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		if id := keyID(key); id != c.keyID {
+			c.previous[id] = aead
+		}
+	}
+	return c, nil
+}
+
+// loadKey reads a base64 encoded key from the file or the environment variable.
+func loadKey(file string, env string) ([]byte, error) {
+	var encoded string
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the encryption key file: %w", err)
+		}
+		encoded = string(data)
+	} else {
+		var ok bool
+		if encoded, ok = os.LookupEnv(env); !ok {
+			return nil, fmt.Errorf("the encryption key environment variable %s is not set", env)
+		}
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("the encryption key is not base64 encoded: %w", err)
+	}
+	return key, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encryption key: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func keyID(key []byte) [keyIDSize]byte {
+	var id [keyIDSize]byte
+	sum := sha256.Sum256(key)
+	copy(id[:], sum[:])
+	return id
+}
+
+// encrypt returns the value encrypted with the current key.
+func (c *valueCipher) encrypt(value []byte) ([]byte, error) {
+	headerSize := 1 + keyIDSize + c.aead.NonceSize()
+	out := make([]byte, headerSize, headerSize+len(value)+c.aead.Overhead())
+	out[0] = encryptionVersion
+	copy(out[1:], c.keyID[:])
+	if _, err := rand.Read(out[1+keyIDSize : headerSize]); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(out, out[1+keyIDSize:headerSize], value, out[:1+keyIDSize]), nil
+}
+
+// decrypt returns the value decrypted with the key it was encrypted with.
+func (c *valueCipher) decrypt(value []byte) ([]byte, error) {
+	if len(value) < 1+keyIDSize+c.aead.NonceSize()+c.aead.Overhead() || value[0] != encryptionVersion {
+		return nil, errNotEncrypted
+	}
+	var id [keyIDSize]byte
+	copy(id[:], value[1:])
+	aead, ok := c.aeadFor(id)
+	if !ok {
+		return nil, errUnknownKey
+	}
+
+	headerSize := 1 + keyIDSize + aead.NonceSize()
+	out, err := aead.Open(make([]byte, 0, len(value)-headerSize), value[1+keyIDSize:headerSize], value[headerSize:], value[:1+keyIDSize])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return out, nil
+}
+
+func (c *valueCipher) aeadFor(id [keyIDSize]byte) (cipher.AEAD, bool) {
+	if id == c.keyID {
+		return c.aead, true
+	}
+	aead, ok := c.previous[id]
+	return aead, ok
+}
+
+// needsReencryption tells whether the value is encrypted with one of the previous keys.
+func (c *valueCipher) needsReencryption(value []byte) bool {
+	if len(value) < 1+keyIDSize {
+		return false
+	}
+	var id [keyIDSize]byte
+	copy(id[:], value[1:])
+	_, ok := c.previous[id]
+	return ok
+}
+
+// initEncryption encrypts the values stored in plain text when the encryption is enabled on an existing
+// database, and marks the database as encrypted, so that the values are never mistaken for plain text
+// afterwards. It returns the number of encrypted values, and fails if the database is encrypted but c is nil.
+func initEncryption(tx *bbolt.Tx, c *valueCipher) (int, error) {
+	encrypted := tx.Bucket(encryptionBucket) != nil
+	if c == nil {
+		if encrypted {
+			return 0, errEncryptionNotConfigured
+		}
+		return 0, nil
+	}
+	if encrypted {
+		return 0, nil
+	}
+
+	// the bucket can't be modified while iterating, so the values are collected first
+	bucket := tx.Bucket(defaultBucket)
+	var keys, values [][]byte
+	err := bucket.ForEach(func(k, v []byte) error {
+		value, err := c.encrypt(v)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, append([]byte{}, k...))
+		values = append(values, value)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for i, key := range keys {
+		if err = bucket.Put(key, values[i]); err != nil {
+			return 0, err
+		}
+	}
+	_, err = tx.CreateBucket(encryptionBucket)
+	return len(keys), err
+}
@@ -0,0 +1,274 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+func TestValueCipherRoundTrip(t *testing.T) {
+	c := newTestCipher(t, testKey(1))
+
+	for _, value := range [][]byte{[]byte("value"), {}, bytes.Repeat([]byte{0}, 1024)} {
+		encrypted, err := c.encrypt(value)
+		require.NoError(t, err)
+		assert.Len(t, encrypted, 1+keyIDSize+c.aead.NonceSize()+len(value)+c.aead.Overhead())
+
+		decrypted, err := c.decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, value, decrypted)
+		assert.NotNil(t, decrypted)
+	}
+}
+
+func TestValueCipherErrors(t *testing.T) {
+	c := newTestCipher(t, testKey(1))
+	other := newTestCipher(t, testKey(2))
+
+	encrypted, err := other.encrypt([]byte("value"))
+	require.NoError(t, err)
+	_, err = c.decrypt(encrypted)
+	assert.ErrorIs(t, err, errUnknownKey)
+
+	_, err = c.decrypt([]byte("plain text"))
+	assert.ErrorIs(t, err, errNotEncrypted)
+	assert.False(t, c.needsReencryption([]byte("plain text")))
+
+	encrypted, err = c.encrypt([]byte("value"))
+	require.NoError(t, err)
+	encrypted[len(encrypted)-1] ^= 1
+	_, err = c.decrypt(encrypted)
+	assert.ErrorContains(t, err, "failed to decrypt value")
+}
+
+func TestLoadKey(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(testKey(1)+"\n"), 0600))
+	invalidFile := filepath.Join(dir, "invalid")
+	require.NoError(t, os.WriteFile(invalidFile, []byte("not base64!"), 0600))
+	t.Setenv("FILE_STORAGE_TEST_KEY", testKey(2))
+
+	key, err := loadKey(keyFile, "")
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	key, err = loadKey("", "FILE_STORAGE_TEST_KEY")
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	_, err = loadKey(filepath.Join(dir, "missing"), "")
+	assert.ErrorContains(t, err, "failed to read the encryption key file")
+
+	_, err = loadKey("", "FILE_STORAGE_TEST_MISSING_KEY")
+	assert.EqualError(t, err, "the encryption key environment variable FILE_STORAGE_TEST_MISSING_KEY is not set")
+
+	_, err = loadKey(invalidFile, "")
+	assert.ErrorContains(t, err, "the encryption key is not base64 encoded")
+
+	_, err = newValueCipher(&EncryptionConfig{KeyFile: invalidFile})
+	assert.Error(t, err)
+
+	t.Setenv("FILE_STORAGE_TEST_SHORT_KEY", base64.StdEncoding.EncodeToString([]byte("short")))
+	_, err = newValueCipher(&EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_SHORT_KEY"})
+	assert.ErrorContains(t, err, "invalid encryption key")
+}
+
+func TestEncryptedClientOperations(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	c := newTestCipher(t, testKey(1))
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, c, clientLimits{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+
+	ctx := context.Background()
+	testValue := []byte("some customer log contents")
+
+	require.NoError(t, client.Set(ctx, "testKey", testValue))
+	value, err := client.Get(ctx, "testKey")
+	require.NoError(t, err)
+	assert.Equal(t, testValue, value)
+
+	// the value is not stored in plain text
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		stored := tx.Bucket(defaultBucket).Get([]byte("testKey"))
+		assert.NotContains(t, string(stored), string(testValue))
+		return nil
+	}))
+
+	getOp := storage.GetOperation("testKey")
+	missingOp := storage.GetOperation("missingKey")
+	require.NoError(t, client.Batch(ctx, storage.SetOperation("otherKey", []byte("other")), getOp, missingOp))
+	assert.Equal(t, testValue, getOp.Value)
+	assert.Nil(t, missingOp.Value)
+
+	value, err = client.Get(ctx, "otherKey")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("other"), value)
+}
+
+func TestEncryptedClientKeyRotation(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := context.Background()
+
+	oldCipher := newTestCipher(t, testKey(1))
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, oldCipher, clientLimits{})
+	require.NoError(t, err)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, client.Set(ctx, key, []byte("value "+key)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the client with the new key can't read the old values without the previous key
+	newCipher := newTestCipher(t, testKey(2))
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newCipher, clientLimits{})
+	require.NoError(t, err)
+	_, err = client.Get(ctx, "a")
+	assert.ErrorIs(t, err, errUnknownKey)
+	require.NoError(t, client.Close(ctx))
+
+	t.Setenv("FILE_STORAGE_TEST_KEY", testKey(2))
+	t.Setenv("FILE_STORAGE_TEST_PREVIOUS_KEY", testKey(1))
+	rotatingCipher, err := newValueCipher(&EncryptionConfig{
+		KeyEnv:          "FILE_STORAGE_TEST_KEY",
+		PreviousKeyEnvs: []string{"FILE_STORAGE_TEST_PREVIOUS_KEY"},
+	})
+	require.NoError(t, err)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, rotatingCipher, clientLimits{})
+	require.NoError(t, err)
+	value, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value a"), value)
+
+	// the compaction re-encrypts the values with the current key
+	require.NoError(t, client.Compact(tempDir, time.Second, 2))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newCipher, clientLimits{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+	for _, key := range []string{"a", "b", "c"} {
+		value, err = client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value "+key), value)
+	}
+}
+
+func TestEncryptedClientLegacyValues(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	ctx := context.Background()
+
+	// a plain text value which looks like an encrypted one
+	header := append([]byte{encryptionVersion}, bytes.Repeat([]byte{0}, 40)...)
+	values := map[string][]byte{"a": []byte("value a"), "b": []byte("value b"), "header": header}
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil, clientLimits{})
+	require.NoError(t, err)
+	for key, value := range values {
+		require.NoError(t, client.Set(ctx, key, value))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the values stored before the encryption was enabled are encrypted when the client is opened
+	c := newTestCipher(t, testKey(1))
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, c, clientLimits{})
+	require.NoError(t, err)
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		assert.NotNil(t, tx.Bucket(encryptionBucket))
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			assert.NotEqual(t, values[string(k)], v)
+			return nil
+		})
+	}))
+	for key, value := range values {
+		var stored []byte
+		stored, err = client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, value, stored)
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the values are only encrypted once
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, c, clientLimits{})
+	require.NoError(t, err)
+	for key, value := range values {
+		var stored []byte
+		stored, err = client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, value, stored)
+	}
+	require.NoError(t, client.Close(ctx))
+
+	// the encrypted database can't be opened without the encryption
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil, clientLimits{})
+	assert.ErrorIs(t, err, errEncryptionNotConfigured)
+}
+
+func TestEncryptedClientReencryptionStoredBytes(t *testing.T) {
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	ctx := context.Background()
+	limits := clientLimits{maxBytes: oneMiB}
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, newTestCipher(t, testKey(1)), limits)
+	require.NoError(t, err)
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, client.Set(ctx, key, []byte("value "+key)))
+	}
+	require.NoError(t, client.Close(ctx))
+
+	t.Setenv("FILE_STORAGE_TEST_KEY", testKey(2))
+	t.Setenv("FILE_STORAGE_TEST_PREVIOUS_KEY", testKey(1))
+	rotatingCipher, err := newValueCipher(&EncryptionConfig{
+		KeyEnv:          "FILE_STORAGE_TEST_KEY",
+		PreviousKeyEnvs: []string{"FILE_STORAGE_TEST_PREVIOUS_KEY"},
+	})
+	require.NoError(t, err)
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, rotatingCipher, limits)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(context.TODO()))
+	})
+	require.NoError(t, client.Compact(tempDir, time.Second, 2))
+
+	// the size of the re-encrypted values is accounted for
+	var size int64
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(defaultBucket).ForEach(func(k, v []byte) error {
+			assert.False(t, rotatingCipher.needsReencryption(v))
+			size += int64(len(k) + len(v))
+			return nil
+		})
+	}))
+	assert.Equal(t, size, client.storedBytes)
+}
+
+// testKey returns a base64 encoded AES-256 key filled with the byte.
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{b}, 32))
+}
+
+func newTestCipher(t *testing.T, key string) *valueCipher {
+	t.Setenv("FILE_STORAGE_TEST_CIPHER_KEY", key)
+	c, err := newValueCipher(&EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_CIPHER_KEY"})
+	require.NoError(t, err)
+	return c
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -15,32 +16,71 @@ import (
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg       *Config
+	logger    *zap.Logger
+	settings  component.TelemetrySettings
+	cipher    *valueCipher
+	telemetry *telemetry
+
+	clientsMutex sync.Mutex
+	// clients are the clients returned by GetClient, by name
+	clients map[string]*fileStorageClient
 }
 
 // Ensure this storage extension implements the appropriate interface
 var _ storage.Extension = (*localFileStorage)(nil)
 
-func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extension, error) {
+func newLocalFileStorage(settings component.TelemetrySettings, config *Config) (extension.Extension, error) {
+	var cipher *valueCipher
+	if config.Encryption != nil {
+		var err error
+		if cipher, err = newValueCipher(config.Encryption); err != nil {
+			return nil, fmt.Errorf("failed to load encryption keys: %w", err)
+		}
+	}
+
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:      config,
+		logger:   settings.Logger,
+		settings: settings,
+		cipher:   cipher,
+		clients:  map[string]*fileStorageClient{},
 	}, nil
 }
 
-// Start does nothing
+// Start registers the metrics of the clients
 func (lfs *localFileStorage) Start(context.Context, component.Host) error {
-	return nil
+	var err error
+	lfs.telemetry, err = newTelemetry(lfs.settings, lfs.openClients)
+	return err
 }
 
 // Shutdown will close any open databases
 func (lfs *localFileStorage) Shutdown(context.Context) error {
 	// TODO clean up data files that did not have a client
 	// and are older than a threshold (possibly configurable)
+	if lfs.telemetry != nil {
+		return lfs.telemetry.shutdown()
+	}
 	return nil
 }
 
+// openClients returns the clients which are not closed yet
+func (lfs *localFileStorage) openClients() map[string]*fileStorageClient {
+	lfs.clientsMutex.Lock()
+	defer lfs.clientsMutex.Unlock()
+
+	clients := make(map[string]*fileStorageClient, len(lfs.clients))
+	for name, client := range lfs.clients {
+		if client.isClosed() {
+			delete(lfs.clients, name)
+			continue
+		}
+		clients[name] = client
+	}
+	return clients
+}
+
 // GetClient returns a storage client for an individual component
 func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, ent component.ID, name string) (storage.Client, error) {
 	var rawName string
@@ -51,7 +91,7 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 	}
 	// TODO sanitize rawName
 	absoluteName := filepath.Join(lfs.cfg.Directory, rawName)
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, lfs.cipher, newClientLimits(lfs.cfg))
 
 	if err != nil {
 		return nil, err
@@ -65,6 +105,10 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 		}
 	}
 
+	lfs.clientsMutex.Lock()
+	lfs.clients[rawName] = client
+	lfs.clientsMutex.Unlock()
+
 	return client, nil
 }
 
@@ -11,8 +11,10 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 )
@@ -139,6 +141,76 @@ func TestClientHandlesSimpleCases(t *testing.T) {
 
 }
 
+func TestEncryptedExtension(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Encryption = &EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_EXTENSION_KEY"}
+
+	// the key must be available when the extension is created
+	_, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.ErrorContains(t, err, "failed to load encryption keys")
+
+	t.Setenv("FILE_STORAGE_TEST_EXTENSION_KEY", testKey(1))
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+
+	se, ok := extension.(storage.Extension)
+	require.True(t, ok)
+
+	client, err := se.GetClient(ctx, component.KindExporter, newTestEntity("my_component"), "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+
+	myBytes := []byte("value")
+	require.NoError(t, client.Set(ctx, "key", myBytes))
+	data, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, myBytes, data)
+
+	require.NoError(t, client.Delete(ctx, "key"))
+	data, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Nil(t, data)
+}
+
+func TestExtensionOpenClients(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+
+	extension, err := f.CreateExtension(ctx, extensiontest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, extension.Start(ctx, componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, extension.Shutdown(ctx))
+	})
+	lfs := extension.(*localFileStorage)
+
+	clientOne, err := lfs.GetClient(ctx, component.KindReceiver, newTestEntity("one"), "")
+	require.NoError(t, err)
+	clientTwo, err := lfs.GetClient(ctx, component.KindExporter, newTestEntity("two"), "queue")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, clientTwo.Close(ctx))
+	})
+
+	clients := lfs.openClients()
+	require.Len(t, clients, 2)
+	assert.Contains(t, clients, "receiver_nop_one")
+	assert.Contains(t, clients, "exporter_nop_two_queue")
+
+	// closed clients are not reported anymore
+	require.NoError(t, clientOne.Close(ctx))
+	clients = lfs.openClients()
+	require.Len(t, clients, 1)
+	assert.Contains(t, clients, "exporter_nop_two_queue")
+}
+
 func TestTwoClientsWithDifferentNames(t *testing.T) {
 	ctx := context.Background()
 	se := newTestExtension(t)
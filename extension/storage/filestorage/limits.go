@@ -0,0 +1,247 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	// entriesBucket holds the time at which each entry was last set, by key
+	entriesBucket = []byte(`entries`)
+	// ageBucket holds the keys of the entries prefixed with the time at which they were last set,
+	// so that the entries can be iterated from the oldest to the most recent one
+	ageBucket = []byte(`age`)
+
+	errQuotaExceeded = errors.New("storage quota exceeded")
+)
+
+const timestampSize = 8
+
+// clientLimits defines the limits of the data stored by a client.
+type clientLimits struct {
+	// ttl is the duration after which the entries expire, 0 if they never expire
+	ttl time.Duration
+	// maxBytes is the maximum size of the keys and values, 0 if there is no limit
+	maxBytes int64
+	// evictOldest evicts the oldest entries when maxBytes is exceeded, instead of rejecting the operations
+	evictOldest bool
+}
+
+func newClientLimits(cfg *Config) clientLimits {
+	limits := clientLimits{ttl: cfg.TTL}
+	if cfg.Quota != nil {
+		limits.maxBytes = cfg.Quota.MaxSizeMiB * oneMiB
+		limits.evictOldest = cfg.Quota.OnExceeded == onExceededEvictOldest
+	}
+	return limits
+}
+
+// tracked tells whether the time at which the entries were set, and their size, are tracked
+func (l clientLimits) tracked() bool {
+	return l.ttl > 0 || l.maxBytes > 0
+}
+
+// initEntries creates the buckets tracking the entries, so that the entries stored while they were
+// not tracked are tracked from now on, and returns the size of the stored entries
+func initEntries(tx *bbolt.Tx, now time.Time) (int64, error) {
+	values := tx.Bucket(defaultBucket)
+	entries, err := tx.CreateBucketIfNotExists(entriesBucket)
+	if err != nil {
+		return 0, err
+	}
+	age, err := tx.CreateBucketIfNotExists(ageBucket)
+	if err != nil {
+		return 0, err
+	}
+
+	// the buckets can't be modified while iterating, so the keys are collected first
+	var deleted [][]byte
+	err = entries.ForEach(func(k, _ []byte) error {
+		if values.Get(k) == nil {
+			deleted = append(deleted, append([]byte{}, k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range deleted {
+		if err = untrackEntry(entries, age, key); err != nil {
+			return 0, err
+		}
+	}
+
+	var size int64
+	var untracked [][]byte
+	err = values.ForEach(func(k, v []byte) error {
+		size += int64(len(k) + len(v))
+		if entries.Get(k) == nil {
+			untracked = append(untracked, append([]byte{}, k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, key := range untracked {
+		if err = trackEntry(entries, age, key, now); err != nil {
+			return 0, err
+		}
+	}
+	return size, nil
+}
+
+// removeEntryTracking removes the buckets tracking the entries, as they would become outdated
+func removeEntryTracking(tx *bbolt.Tx) error {
+	for _, name := range [][]byte{entriesBucket, ageBucket} {
+		if err := tx.DeleteBucket(name); err != nil && !errors.Is(err, bbolt.ErrBucketNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackEntry records that the entry was set at the given time
+func trackEntry(entries, age *bbolt.Bucket, key []byte, now time.Time) error {
+	if err := untrackEntry(entries, age, key); err != nil {
+		return err
+	}
+	timestamp := make([]byte, timestampSize)
+	binary.BigEndian.PutUint64(timestamp, uint64(now.UnixNano()))
+	if err := entries.Put(key, timestamp); err != nil {
+		return err
+	}
+	return age.Put(append(timestamp, key...), []byte{})
+}
+
+// untrackEntry removes the tracking of the entry, if any
+func untrackEntry(entries, age *bbolt.Bucket, key []byte) error {
+	timestamp := entries.Get(key)
+	if timestamp == nil {
+		return nil
+	}
+	ageKey := append(append(make([]byte, 0, len(timestamp)+len(key)), timestamp...), key...)
+	if err := age.Delete(ageKey); err != nil {
+		return err
+	}
+	return entries.Delete(key)
+}
+
+// trackedEntries holds the buckets of a transaction used to enforce the limits of a client
+type trackedEntries struct {
+	limits  clientLimits
+	now     time.Time
+	values  *bbolt.Bucket
+	entries *bbolt.Bucket
+	age     *bbolt.Bucket
+	// delta is the change of the size of the stored entries in the transaction
+	delta int64
+	// entriesDelta is the change of the number of entries in the transaction
+	entriesDelta int64
+	// written are the keys set in the transaction, which are never evicted
+	written map[string]struct{}
+}
+
+func newTrackedEntries(tx *bbolt.Tx, limits clientLimits, now time.Time) (*trackedEntries, error) {
+	t := &trackedEntries{
+		limits:  limits,
+		now:     now,
+		values:  tx.Bucket(defaultBucket),
+		entries: tx.Bucket(entriesBucket),
+		age:     tx.Bucket(ageBucket),
+		written: map[string]struct{}{},
+	}
+	if t.entries == nil || t.age == nil {
+		return nil, errors.New("storage not initialized")
+	}
+	return t, nil
+}
+
+// set stores the value of the entry
+func (t *trackedEntries) set(key []byte, value []byte) error {
+	if previous := t.values.Get(key); previous != nil {
+		t.delta -= int64(len(key) + len(previous))
+	} else {
+		t.entriesDelta++
+	}
+	if err := t.values.Put(key, value); err != nil {
+		return err
+	}
+	t.delta += int64(len(key) + len(value))
+	t.written[string(key)] = struct{}{}
+	return trackEntry(t.entries, t.age, key, t.now)
+}
+
+// delete deletes the entry
+func (t *trackedEntries) delete(key []byte) error {
+	previous := t.values.Get(key)
+	if previous == nil {
+		return nil
+	}
+	t.delta -= int64(len(key) + len(previous))
+	t.entriesDelta--
+	if err := t.values.Delete(key); err != nil {
+		return err
+	}
+	delete(t.written, string(key))
+	return untrackEntry(t.entries, t.age, key)
+}
+
+// expire deletes the entries which were set more than the TTL ago
+func (t *trackedEntries) expire() error {
+	if t.limits.ttl <= 0 {
+		return nil
+	}
+	deadline := uint64(t.now.Add(-t.limits.ttl).UnixNano())
+
+	var expired [][]byte
+	cursor := t.age.Cursor()
+	for k, _ := cursor.First(); k != nil && binary.BigEndian.Uint64(k) <= deadline; k, _ = cursor.Next() {
+		expired = append(expired, append([]byte{}, k[timestampSize:]...))
+	}
+	for _, key := range expired {
+		if err := t.delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enforceQuota checks that the size of the stored entries doesn't exceed the quota, evicting the oldest
+// entries if configured so. The entries set in the transaction are never evicted.
+func (t *trackedEntries) enforceQuota(storedBytes int64) error {
+	if t.limits.maxBytes <= 0 || storedBytes+t.delta <= t.limits.maxBytes {
+		return nil
+	}
+
+	if t.limits.evictOldest {
+		var evicted [][]byte
+		excess := storedBytes + t.delta - t.limits.maxBytes
+		cursor := t.age.Cursor()
+		for k, _ := cursor.First(); k != nil && excess > 0; k, _ = cursor.Next() {
+			key := k[timestampSize:]
+			if _, ok := t.written[string(key)]; ok {
+				continue
+			}
+			excess -= int64(len(key) + len(t.values.Get(key)))
+			evicted = append(evicted, append([]byte{}, key...))
+		}
+		for _, key := range evicted {
+			if err := t.delete(key); err != nil {
+				return err
+			}
+		}
+		if storedBytes+t.delta <= t.limits.maxBytes {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %d bytes would be stored, the maximum is %d bytes", errQuotaExceeded, storedBytes+t.delta, t.limits.maxBytes)
+}
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+func TestClientTTL(t *testing.T) {
+	ctx := context.Background()
+	client, clock := newLimitedTestClient(t, filepath.Join(t.TempDir(), "my_db"), clientLimits{ttl: time.Minute})
+
+	require.NoError(t, client.Set(ctx, "old", []byte("old value")))
+	*clock = clock.Add(40 * time.Second)
+	require.NoError(t, client.Set(ctx, "new", []byte("new value")))
+
+	// setting an entry again renews it
+	require.NoError(t, client.Set(ctx, "renewed", []byte("value")))
+	*clock = clock.Add(10 * time.Second)
+	require.NoError(t, client.Set(ctx, "renewed", []byte("renewed value")))
+
+	*clock = clock.Add(30 * time.Second)
+	data, err := client.Get(ctx, "old")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+	data, err = client.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new value"), data)
+	data, err = client.Get(ctx, "renewed")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("renewed value"), data)
+
+	*clock = clock.Add(30 * time.Second)
+	data, err = client.Get(ctx, "new")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+	data, err = client.Get(ctx, "renewed")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+	assert.Equal(t, int64(0), client.storedBytes)
+}
+
+func TestClientQuotaReject(t *testing.T) {
+	ctx := context.Background()
+	client, _ := newLimitedTestClient(t, filepath.Join(t.TempDir(), "my_db"), clientLimits{maxBytes: 100})
+
+	require.NoError(t, client.Set(ctx, "a", bytes.Repeat([]byte{1}, 49)))
+	require.NoError(t, client.Set(ctx, "b", bytes.Repeat([]byte{1}, 49)))
+	assert.Equal(t, int64(100), client.storedBytes)
+
+	err := client.Set(ctx, "c", []byte{1})
+	assert.ErrorIs(t, err, errQuotaExceeded)
+	assert.EqualError(t, err, "storage quota exceeded: 102 bytes would be stored, the maximum is 100 bytes")
+
+	// the whole batch is rejected
+	err = client.Batch(ctx, storage.DeleteOperation("a"), storage.SetOperation("c", bytes.Repeat([]byte{1}, 60)))
+	assert.ErrorIs(t, err, errQuotaExceeded)
+	data, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.NotNil(t, data)
+	assert.Equal(t, int64(100), client.storedBytes)
+
+	// replacing an entry with a smaller one or deleting one frees space
+	require.NoError(t, client.Set(ctx, "a", []byte{1}))
+	require.NoError(t, client.Delete(ctx, "b"))
+	require.NoError(t, client.Set(ctx, "c", bytes.Repeat([]byte{1}, 60)))
+	assert.Equal(t, int64(63), client.storedBytes)
+}
+
+func TestClientQuotaEvictOldest(t *testing.T) {
+	ctx := context.Background()
+	client, clock := newLimitedTestClient(t, filepath.Join(t.TempDir(), "my_db"), clientLimits{maxBytes: 100, evictOldest: true})
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, client.Set(ctx, key, bytes.Repeat([]byte{1}, 19)))
+		*clock = clock.Add(time.Second)
+	}
+	// setting an entry again makes it the most recent one
+	require.NoError(t, client.Set(ctx, "a", bytes.Repeat([]byte{1}, 19)))
+	*clock = clock.Add(time.Second)
+
+	require.NoError(t, client.Set(ctx, "e", bytes.Repeat([]byte{1}, 59)))
+	for key, expected := range map[string]bool{"a": true, "b": false, "c": false, "d": true, "e": true} {
+		data, err := client.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, data != nil, key)
+	}
+	assert.Equal(t, int64(100), client.storedBytes)
+
+	// the entries set in the batch are not evicted
+	err := client.Batch(ctx, storage.SetOperation("f", bytes.Repeat([]byte{1}, 60)), storage.SetOperation("g", bytes.Repeat([]byte{1}, 60)))
+	assert.ErrorIs(t, err, errQuotaExceeded)
+	data, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.NotNil(t, data)
+}
+
+func TestClientLimitsReopen(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+
+	// entries stored without limits are tracked when the client is opened with limits
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil, clientLimits{})
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "a", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, _ = newLimitedTestClient(t, dbFile, clientLimits{ttl: time.Minute, maxBytes: 100})
+	assert.Equal(t, int64(6), client.storedBytes)
+	require.NoError(t, client.Set(ctx, "b", []byte("value")))
+	require.NoError(t, client.Delete(ctx, "b"))
+	require.NoError(t, client.Close(ctx))
+
+	// the tracking buckets are removed when the client is opened without limits
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil, clientLimits{})
+	require.NoError(t, err)
+	require.NoError(t, client.db.View(func(tx *bbolt.Tx) error {
+		assert.Nil(t, tx.Bucket(entriesBucket))
+		assert.Nil(t, tx.Bucket(ageBucket))
+		return nil
+	}))
+	require.NoError(t, client.Set(ctx, "c", []byte("value")))
+	require.NoError(t, client.Close(ctx))
+
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil, clientLimits{ttl: time.Minute})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+	assert.Equal(t, int64(12), client.storedBytes)
+
+	// the entries expire from the time they were first tracked
+	client.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	data, err := client.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestClientStats(t *testing.T) {
+	ctx := context.Background()
+	client, err := newClient(zap.NewNop(), filepath.Join(t.TempDir(), "my_db"), time.Second, &CompactionConfig{}, nil, clientLimits{})
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(ctx, "a", []byte("value")))
+	require.NoError(t, client.Set(ctx, "b", []byte("value")))
+
+	size, entries, err := client.stats()
+	require.NoError(t, err)
+	assert.Greater(t, size, int64(0))
+	assert.Equal(t, int64(2), entries)
+
+	require.NoError(t, client.Set(ctx, "a", []byte("other")))
+	require.NoError(t, client.Delete(ctx, "b"))
+	require.NoError(t, client.Delete(ctx, "c"))
+	_, entries, err = client.stats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), entries)
+
+	require.NoError(t, client.Close(ctx))
+	_, _, err = client.stats()
+	assert.ErrorIs(t, err, errClientClosed)
+}
+
+func TestClientStatsLimited(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	client, clock := newLimitedTestClient(t, dbFile, clientLimits{ttl: time.Minute})
+
+	require.NoError(t, client.Set(ctx, "a", []byte("value")))
+	require.NoError(t, client.Set(ctx, "a", []byte("other")))
+	*clock = clock.Add(30 * time.Second)
+	require.NoError(t, client.Set(ctx, "b", []byte("value")))
+	_, entries, err := client.stats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entries)
+
+	// "a" expires
+	*clock = clock.Add(45 * time.Second)
+	require.NoError(t, client.Set(ctx, "c", []byte("value")))
+	_, entries, err = client.stats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entries)
+
+	// the entries are counted when the client is opened
+	require.NoError(t, client.Close(ctx))
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil, clientLimits{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close(ctx))
+	})
+	_, entries, err = client.stats()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entries)
+}
+
+// newLimitedTestClient returns a client with the limits, and the clock used by the client
+func newLimitedTestClient(t *testing.T, dbFile string, limits clientLimits) (*fileStorageClient, *time.Time) {
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, nil, limits)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close(context.Background())
+	})
+
+	clock := time.Now()
+	client.now = func() time.Time { return clock }
+	return client, &clock
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage/internal/metadata"
+)
+
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+	metricSep    = "_"
+	extensionKey = "extension"
+	clientKey    = "client"
+)
+
+type telemetry struct {
+	registration metric.Registration
+}
+
+// newTelemetry registers the gauges reporting the size and the number of entries of the database
+// of each client returned by clients.
+func newTelemetry(set component.TelemetrySettings, clients func() map[string]*fileStorageClient) (*telemetry, error) {
+	meter := set.MeterProvider.Meter(scopeName)
+
+	dbSize, err := meter.Int64ObservableGauge(
+		metadata.Type+metricSep+extensionKey+metricSep+"db_size",
+		metric.WithDescription("Size of the database file of the client, including the free pages"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	dbEntries, err := meter.Int64ObservableGauge(
+		metadata.Type+metricSep+extensionKey+metricSep+"db_entries",
+		metric.WithDescription("Number of entries stored by the client"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for name, client := range clients() {
+			size, entries, err := client.stats()
+			if err != nil {
+				continue
+			}
+			attrs := metric.WithAttributes(attribute.String(clientKey, name))
+			o.ObserveInt64(dbSize, size, attrs)
+			o.ObserveInt64(dbEntries, entries, attrs)
+		}
+		return nil
+	}, dbSize, dbEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &telemetry{registration: registration}, nil
+}
+
+func (t *telemetry) shutdown() error {
+	return t.registration.Unregister()
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestClientOperations(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	// Get missing data
+	data, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	// Set and get the data
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	data, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), data)
+
+	// Delete the data, twice
+	require.NoError(t, client.Delete(ctx, "key"))
+	require.NoError(t, client.Delete(ctx, "key"))
+	data, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestClientBatch(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	require.NoError(t, client.Set(ctx, "existing", []byte("existing value")))
+
+	// the operations are executed in order
+	getExisting := storage.GetOperation("existing")
+	getNew := storage.GetOperation("new")
+	getMissing := storage.GetOperation("missing")
+	getDeleted := storage.GetOperation("existing")
+	require.NoError(t, client.Batch(ctx,
+		getExisting,
+		storage.SetOperation("new", []byte("new value")),
+		getNew,
+		getMissing,
+		storage.DeleteOperation("existing"),
+		getDeleted,
+	))
+	assert.Equal(t, []byte("existing value"), getExisting.Value)
+	assert.Equal(t, []byte("new value"), getNew.Value)
+	assert.Nil(t, getMissing.Value)
+	assert.Nil(t, getDeleted.Value)
+
+	// an empty batch does nothing
+	require.NoError(t, client.Batch(ctx))
+}
+
+func TestClientBatchInvalidOperation(t *testing.T) {
+	client := newTestClient(t)
+
+	op := storage.SetOperation("key", []byte("value"))
+	op.Type = storage.Delete + 1
+	assert.EqualError(t, client.Batch(context.Background(), op), "wrong operation type")
+
+	data, err := client.Get(context.Background(), "key")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestClientServerError(t *testing.T) {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		require.NoError(t, rdb.Close())
+	})
+	client := newClient(rdb, "prefix_", 0)
+
+	server.SetError("server unavailable")
+	_, err := client.Get(context.Background(), "key")
+	assert.ErrorContains(t, err, "server unavailable")
+}
+
+func newTestClient(t *testing.T) *redisStorageClient {
+	server := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() {
+		require.NoError(t, rdb.Close())
+	})
+	return newClient(rdb, "prefix_", 0)
+}
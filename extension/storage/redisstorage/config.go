@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/redisstorage"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines configuration for the Redis storage extension.
+type Config struct {
+	// Endpoint is the address of the Redis server, in the host:port form.
+	Endpoint string `mapstructure:"endpoint"`
+	// Username is the optional username, used with the Redis ACL system.
+	Username string `mapstructure:"username"`
+	// Password is the optional password. Must match the password specified in the
+	// requirepass server configuration option, or the password of the ACL user.
+	Password configopaque.String `mapstructure:"password"`
+	// DB is the Redis database to use.
+	DB int `mapstructure:"db"`
+	// Prefix is prepended to the keys of all the components, to share a Redis database
+	// between several collector deployments.
+	Prefix string `mapstructure:"prefix"`
+	// Expiration is the TTL of the stored keys, renewed each time they are set.
+	// Keys never expire if it is 0.
+	Expiration time.Duration `mapstructure:"expiration"`
+
+	TLS configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("missing endpoint")
+	}
+	if cfg.DB < 0 {
+		return errors.New("db must not be negative")
+	}
+	if cfg.Expiration < 0 {
+		return errors.New("expiration must not be negative")
+	}
+	return nil
+}
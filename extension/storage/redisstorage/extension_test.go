@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redisstorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+func TestExtensionKeyPrefixes(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	cfg := testConfig(server)
+	cfg.Prefix = "collector:"
+	se := newTestExtension(t, cfg)
+
+	receiverClient, err := se.GetClient(ctx, component.KindReceiver, component.NewIDWithName("filelog", "one"), "")
+	require.NoError(t, err)
+	exporterClient, err := se.GetClient(ctx, component.KindExporter, component.NewID("otlp"), "queue")
+	require.NoError(t, err)
+
+	require.NoError(t, receiverClient.Set(ctx, "key", []byte("receiver")))
+	require.NoError(t, exporterClient.Set(ctx, "key", []byte("exporter")))
+
+	// the components don't share their keys
+	data, err := receiverClient.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("receiver"), data)
+	data, err = exporterClient.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("exporter"), data)
+
+	assert.ElementsMatch(t, []string{
+		"collector:receiver_filelog_one_key",
+		"collector:exporter_otlp__queue_key",
+	}, server.Keys())
+
+	require.NoError(t, receiverClient.Close(ctx))
+	require.NoError(t, exporterClient.Close(ctx))
+}
+
+func TestExtensionExpiration(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	cfg := testConfig(server)
+	cfg.Expiration = time.Minute
+	se := newTestExtension(t, cfg)
+
+	client, err := se.GetClient(ctx, component.KindReceiver, component.NewID("filelog"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	assert.Equal(t, time.Minute, server.TTL("receiver_filelog__key"))
+
+	server.FastForward(2 * time.Minute)
+	data, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestExtensionAuthentication(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	server.RequireUserAuth("otel", "secret")
+
+	cfg := testConfig(server)
+	cfg.Username = "otel"
+	cfg.Password = "wrong"
+	e := newRedisStorage(zap.NewNop(), cfg)
+	assert.ErrorContains(t, e.Start(ctx, componenttest.NewNopHost()), "failed to connect to redis")
+	_, err := e.GetClient(ctx, component.KindReceiver, component.NewID("filelog"), "")
+	assert.EqualError(t, err, "the redis storage extension is not started")
+
+	cfg.Password = "secret"
+	se := newTestExtension(t, cfg)
+	client, err := se.GetClient(ctx, component.KindReceiver, component.NewID("filelog"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+}
+
+func TestExtensionInvalidTLS(t *testing.T) {
+	server := miniredis.RunT(t)
+	cfg := testConfig(server)
+	cfg.TLS.Insecure = false
+	cfg.TLS.CAFile = "/does/not/exist"
+
+	e := newRedisStorage(zap.NewNop(), cfg)
+	assert.Error(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func testConfig(server *miniredis.Miniredis) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = server.Addr()
+	return cfg
+}
+
+func newTestExtension(t *testing.T, cfg *Config) storage.Extension {
+	e := newRedisStorage(zap.NewNop(), cfg)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, e.Shutdown(context.Background()))
+	})
+	return e
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package merge implements merging the values of data points of the same
+// stream. Timestamps, attributes and exemplars are left to the caller.
+package merge // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/merge"
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// Number adds the value of dp to acc. If either of the points holds a
+// double value, the result is a double.
+func Number(acc, dp pmetric.NumberDataPoint) bool {
+	if acc.ValueType() == pmetric.NumberDataPointValueTypeInt && dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		acc.SetIntValue(acc.IntValue() + dp.IntValue())
+		return true
+	}
+	acc.SetDoubleValue(numberValue(acc) + numberValue(dp))
+	return true
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+// Histogram adds the counts of dp to acc. It returns false and leaves acc
+// untouched if the bucket boundaries of the two points differ.
+func Histogram(acc, dp pmetric.HistogramDataPoint) bool {
+	if !equalBounds(acc.ExplicitBounds().AsRaw(), dp.ExplicitBounds().AsRaw()) ||
+		acc.BucketCounts().Len() != dp.BucketCounts().Len() {
+		return false
+	}
+
+	for i := 0; i < dp.BucketCounts().Len(); i++ {
+		acc.BucketCounts().SetAt(i, acc.BucketCounts().At(i)+dp.BucketCounts().At(i))
+	}
+	acc.SetCount(acc.Count() + dp.Count())
+
+	switch {
+	case acc.HasSum() && dp.HasSum():
+		acc.SetSum(acc.Sum() + dp.Sum())
+	case acc.HasSum():
+		acc.RemoveSum()
+	}
+	switch {
+	case acc.HasMin() && dp.HasMin():
+		acc.SetMin(math.Min(acc.Min(), dp.Min()))
+	case acc.HasMin():
+		acc.RemoveMin()
+	}
+	switch {
+	case acc.HasMax() && dp.HasMax():
+		acc.SetMax(math.Max(acc.Max(), dp.Max()))
+	case acc.HasMax():
+		acc.RemoveMax()
+	}
+	return true
+}
+
+func equalBounds(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ExponentialHistogram adds the counts of dp to acc. If the points use
+// different scales, both are brought to the smaller of the two scales before
+// merging.
+func ExponentialHistogram(acc, dp pmetric.ExponentialHistogramDataPoint) bool {
+	// dp is owned by the caller, so downscale a copy of it.
+	in := pmetric.NewExponentialHistogramDataPoint()
+	dp.CopyTo(in)
+	if in.Scale() < acc.Scale() {
+		downscale(acc.Positive(), acc.Scale()-in.Scale())
+		downscale(acc.Negative(), acc.Scale()-in.Scale())
+		acc.SetScale(in.Scale())
+	} else if in.Scale() > acc.Scale() {
+		downscale(in.Positive(), in.Scale()-acc.Scale())
+		downscale(in.Negative(), in.Scale()-acc.Scale())
+		in.SetScale(acc.Scale())
+	}
+
+	mergeBuckets(acc.Positive(), in.Positive())
+	mergeBuckets(acc.Negative(), in.Negative())
+	acc.SetZeroCount(acc.ZeroCount() + in.ZeroCount())
+	acc.SetCount(acc.Count() + in.Count())
+
+	switch {
+	case acc.HasSum() && in.HasSum():
+		acc.SetSum(acc.Sum() + in.Sum())
+	case acc.HasSum():
+		acc.RemoveSum()
+	}
+	switch {
+	case acc.HasMin() && in.HasMin():
+		acc.SetMin(math.Min(acc.Min(), in.Min()))
+	case acc.HasMin():
+		acc.RemoveMin()
+	}
+	switch {
+	case acc.HasMax() && in.HasMax():
+		acc.SetMax(math.Max(acc.Max(), in.Max()))
+	case acc.HasMax():
+		acc.RemoveMax()
+	}
+	return true
+}
+
+// downscale lowers the resolution of the buckets by the given number of
+// scale steps. Every step merges pairs of neighbouring buckets, so bucket
+// index i becomes i >> by.
+func downscale(bs pmetric.ExponentialHistogramDataPointBuckets, by int32) {
+	if by <= 0 {
+		return
+	}
+
+	counts := bs.BucketCounts()
+	if counts.Len() == 0 {
+		bs.SetOffset(bs.Offset() >> by)
+		return
+	}
+
+	offset := bs.Offset()
+	newOffset := offset >> by
+	last := (offset + int32(counts.Len()) - 1) >> by
+	out := make([]uint64, last-newOffset+1)
+	for i := 0; i < counts.Len(); i++ {
+		out[((offset+int32(i))>>by)-newOffset] += counts.At(i)
+	}
+
+	bs.SetOffset(newOffset)
+	counts.FromRaw(out)
+}
+
+// mergeBuckets adds the counts of in to acc. Both must use the same scale.
+func mergeBuckets(acc, in pmetric.ExponentialHistogramDataPointBuckets) {
+	if in.BucketCounts().Len() == 0 {
+		return
+	}
+	if acc.BucketCounts().Len() == 0 {
+		in.CopyTo(acc)
+		return
+	}
+
+	accLo, accHi := acc.Offset(), acc.Offset()+int32(acc.BucketCounts().Len())
+	inLo, inHi := in.Offset(), in.Offset()+int32(in.BucketCounts().Len())
+
+	lo, hi := accLo, accHi
+	if inLo < lo {
+		lo = inLo
+	}
+	if inHi > hi {
+		hi = inHi
+	}
+
+	out := make([]uint64, hi-lo)
+	for i := 0; i < acc.BucketCounts().Len(); i++ {
+		out[accLo-lo+int32(i)] += acc.BucketCounts().At(i)
+	}
+	for i := 0; i < in.BucketCounts().Len(); i++ {
+		out[inLo-lo+int32(i)] += in.BucketCounts().At(i)
+	}
+
+	acc.SetOffset(lo)
+	acc.BucketCounts().FromRaw(out)
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestNumber(t *testing.T) {
+	acc, dp := pmetric.NewNumberDataPoint(), pmetric.NewNumberDataPoint()
+	acc.SetIntValue(3)
+	dp.SetIntValue(4)
+	assert.True(t, Number(acc, dp))
+	assert.Equal(t, pmetric.NumberDataPointValueTypeInt, acc.ValueType())
+	assert.EqualValues(t, 7, acc.IntValue())
+
+	dp.SetDoubleValue(0.5)
+	assert.True(t, Number(acc, dp))
+	assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, acc.ValueType())
+	assert.Equal(t, 7.5, acc.DoubleValue())
+}
+
+func TestHistogram(t *testing.T) {
+	newPoint := func(bounds []float64, buckets []uint64, sum float64) pmetric.HistogramDataPoint {
+		dp := pmetric.NewHistogramDataPoint()
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(buckets)
+		var count uint64
+		for _, b := range buckets {
+			count += b
+		}
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		return dp
+	}
+
+	acc := newPoint([]float64{1, 10}, []uint64{1, 2, 3}, 50)
+	assert.True(t, Histogram(acc, newPoint([]float64{1, 10}, []uint64{0, 1, 0}, 5)))
+	assert.Equal(t, []uint64{1, 3, 3}, acc.BucketCounts().AsRaw())
+	assert.EqualValues(t, 7, acc.Count())
+	assert.Equal(t, 55.0, acc.Sum())
+
+	assert.False(t, Histogram(acc, newPoint([]float64{5}, []uint64{1, 1}, 5)))
+	assert.EqualValues(t, 7, acc.Count())
+}
+
+func TestExponentialHistogram(t *testing.T) {
+	acc := pmetric.NewExponentialHistogramDataPoint()
+	acc.SetScale(1)
+	acc.SetCount(3)
+	acc.Positive().SetOffset(1)
+	acc.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1})
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.SetCount(3)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(-1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 0, 1})
+
+	assert.True(t, ExponentialHistogram(acc, dp))
+	assert.EqualValues(t, 0, acc.Scale())
+	assert.EqualValues(t, 6, acc.Count())
+	assert.EqualValues(t, 1, acc.ZeroCount())
+	assert.EqualValues(t, -1, acc.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1, 3}, acc.Positive().BucketCounts().AsRaw())
+	// the input point is left untouched
+	assert.EqualValues(t, 0, dp.Scale())
+}
+
+func TestDownscale(t *testing.T) {
+	bs := pmetric.NewExponentialHistogramDataPointBuckets()
+	bs.SetOffset(-3)
+	bs.BucketCounts().FromRaw([]uint64{1, 2, 3, 4, 5})
+
+	downscale(bs, 1)
+	// indices -3..1 map to -2,-1,-1,0,0
+	assert.EqualValues(t, -2, bs.Offset())
+	assert.Equal(t, []uint64{1, 5, 9}, bs.BucketCounts().AsRaw())
+
+	downscale(bs, 2)
+	assert.EqualValues(t, -1, bs.Offset())
+	assert.Equal(t, []uint64{6, 9}, bs.BucketCounts().AsRaw())
+}
@@ -0,0 +1,381 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/pierrec/lz4/v4"
+)
+
+// The journal file format is described in https://systemd.io/JOURNAL_FILE_FORMAT/.
+// Only the parts required to read the entries sequentially are implemented:
+// the hash tables, which are used to look up entries by field, are ignored.
+
+var journalSignature = []byte("LPKSHHRH")
+
+// Incompatible flags of the header.
+const (
+	incompatibleCompressedXZ   = 1 << 0
+	incompatibleCompressedLZ4  = 1 << 1
+	incompatibleKeyedHash      = 1 << 2
+	incompatibleCompressedZSTD = 1 << 3
+	incompatibleCompact        = 1 << 4
+
+	incompatibleSupported = incompatibleCompressedXZ | incompatibleCompressedLZ4 | incompatibleKeyedHash |
+		incompatibleCompressedZSTD | incompatibleCompact
+)
+
+// Object types.
+const (
+	objectData       = 1
+	objectEntry      = 3
+	objectEntryArray = 6
+)
+
+// Object flags.
+const (
+	objectCompressedXZ   = 1 << 0
+	objectCompressedLZ4  = 1 << 1
+	objectCompressedZSTD = 1 << 2
+)
+
+const (
+	// The minimum size of the header, up to tail_entry_monotonic.
+	minHeaderSize    = 208
+	objectHeaderSize = 16
+	// Objects larger than this are considered corrupted.
+	maxObjectSize = 256 * 1024 * 1024
+)
+
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+// journalHeader contains the fields of the file header used to read the entries.
+type journalHeader struct {
+	incompatibleFlags uint32
+	fileID            [16]byte
+	seqnumID          [16]byte
+	nEntries          uint64
+	entryArrayOffset  uint64
+}
+
+func (h *journalHeader) compact() bool {
+	return h.incompatibleFlags&incompatibleCompact != 0
+}
+
+// journalFile reads the entries of a journal file.
+type journalFile struct {
+	file   io.ReaderAt
+	closer io.Closer
+	header journalHeader
+}
+
+func openJournalFile(path string) (*journalFile, error) {
+	f, err := os.Open(path) // #nosec - the journal files are configured by the user
+	if err != nil {
+		return nil, err
+	}
+	j := &journalFile{file: f, closer: f}
+	if err = j.readHeader(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("invalid journal file %s: %w", path, err)
+	}
+	return j, nil
+}
+
+func (j *journalFile) Close() error {
+	return j.closer.Close()
+}
+
+func (j *journalFile) readHeader() error {
+	b := make([]byte, minHeaderSize)
+	if _, err := j.file.ReadAt(b, 0); err != nil {
+		return err
+	}
+	if !bytes.Equal(b[:8], journalSignature) {
+		return errors.New("invalid signature")
+	}
+	h := journalHeader{
+		incompatibleFlags: binary.LittleEndian.Uint32(b[12:]),
+		nEntries:          binary.LittleEndian.Uint64(b[152:]),
+		entryArrayOffset:  binary.LittleEndian.Uint64(b[176:]),
+	}
+	if unsupported := h.incompatibleFlags &^ incompatibleSupported; unsupported != 0 {
+		return fmt.Errorf("unsupported incompatible flags %#x", unsupported)
+	}
+	copy(h.fileID[:], b[24:40])
+	copy(h.seqnumID[:], b[72:88])
+	j.header = h
+	return nil
+}
+
+// readObject reads the object at the given offset, checking its type.
+func (j *journalFile) readObject(offset uint64, objectType byte) (flags byte, object []byte, err error) {
+	header := make([]byte, objectHeaderSize)
+	if _, err = j.file.ReadAt(header, int64(offset)); err != nil {
+		return 0, nil, err
+	}
+	if header[0] != objectType {
+		return 0, nil, fmt.Errorf("unexpected object type %d at offset %d", header[0], offset)
+	}
+	size := binary.LittleEndian.Uint64(header[8:])
+	if size < objectHeaderSize || size > maxObjectSize {
+		return 0, nil, fmt.Errorf("invalid object size %d at offset %d", size, offset)
+	}
+	object = make([]byte, size)
+	if _, err = j.file.ReadAt(object, int64(offset)); err != nil {
+		return 0, nil, err
+	}
+	return header[1], object, nil
+}
+
+// entryIterator is the position of the next entry to read in the chain of entry arrays.
+type entryIterator struct {
+	// arrayOffset is the offset of the current entry array, 0 before the first one.
+	arrayOffset uint64
+	// arrayFirst is the index of the first entry of the current entry array.
+	arrayFirst uint64
+	// next is the index of the next entry to read.
+	next uint64
+}
+
+// nextEntryOffset returns the offset of the next entry and advances the
+// iterator, or returns 0 if there are no more entries.
+func (j *journalFile) nextEntryOffset(it *entryIterator) (uint64, error) {
+	if it.next >= j.header.nEntries {
+		return 0, nil
+	}
+	if it.arrayOffset == 0 {
+		if j.header.entryArrayOffset == 0 {
+			return 0, nil
+		}
+		it.arrayOffset, it.arrayFirst = j.header.entryArrayOffset, 0
+	}
+
+	itemSize := uint64(8)
+	if j.header.compact() {
+		itemSize = 4
+	}
+	for {
+		header := make([]byte, objectHeaderSize+8)
+		if _, err := j.file.ReadAt(header, int64(it.arrayOffset)); err != nil {
+			return 0, err
+		}
+		if header[0] != objectEntryArray {
+			return 0, fmt.Errorf("unexpected object type %d at offset %d", header[0], it.arrayOffset)
+		}
+		size := binary.LittleEndian.Uint64(header[8:])
+		if size < uint64(len(header)) {
+			return 0, fmt.Errorf("invalid object size %d at offset %d", size, it.arrayOffset)
+		}
+		n := (size - uint64(len(header))) / itemSize
+
+		if index := it.next - it.arrayFirst; index < n {
+			item := make([]byte, itemSize)
+			if _, err := j.file.ReadAt(item, int64(it.arrayOffset+uint64(len(header))+index*itemSize)); err != nil {
+				return 0, err
+			}
+			var offset uint64
+			if itemSize == 4 {
+				offset = uint64(binary.LittleEndian.Uint32(item))
+			} else {
+				offset = binary.LittleEndian.Uint64(item)
+			}
+			if offset != 0 {
+				it.next++
+			}
+			return offset, nil
+		}
+
+		nextArrayOffset := binary.LittleEndian.Uint64(header[objectHeaderSize:])
+		if nextArrayOffset == 0 {
+			return 0, nil
+		}
+		it.arrayOffset = nextArrayOffset
+		it.arrayFirst += n
+	}
+}
+
+// skipEntries moves the iterator after the last entry of the file.
+func (j *journalFile) skipEntries(it *entryIterator) error {
+	for {
+		offset, err := j.nextEntryOffset(it)
+		if err != nil || offset == 0 {
+			return err
+		}
+	}
+}
+
+// journalField is a field of an entry, whose value may be binary.
+type journalField struct {
+	name  string
+	value []byte
+}
+
+// journalEntry is an entry with its fields.
+type journalEntry struct {
+	seqnumID  [16]byte
+	seqnum    uint64
+	realtime  uint64
+	monotonic uint64
+	bootID    [16]byte
+	xorHash   uint64
+	fields    []journalField
+}
+
+// cursor returns the cursor of the entry, in the same format as journalctl.
+func (e *journalEntry) cursor() string {
+	return fmt.Sprintf("s=%s;i=%x;b=%s;m=%x;t=%x;x=%x",
+		hex.EncodeToString(e.seqnumID[:]), e.seqnum, hex.EncodeToString(e.bootID[:]), e.monotonic, e.realtime, e.xorHash)
+}
+
+func (j *journalFile) readEntry(offset uint64) (*journalEntry, error) {
+	_, object, err := j.readObject(offset, objectEntry)
+	if err != nil {
+		return nil, err
+	}
+	const itemsOffset = 64
+	if len(object) < itemsOffset {
+		return nil, fmt.Errorf("invalid entry object at offset %d", offset)
+	}
+
+	e := &journalEntry{
+		seqnumID:  j.header.seqnumID,
+		seqnum:    binary.LittleEndian.Uint64(object[16:]),
+		realtime:  binary.LittleEndian.Uint64(object[24:]),
+		monotonic: binary.LittleEndian.Uint64(object[32:]),
+		xorHash:   binary.LittleEndian.Uint64(object[56:]),
+	}
+	copy(e.bootID[:], object[40:56])
+
+	itemSize := 16
+	if j.header.compact() {
+		itemSize = 4
+	}
+	items := object[itemsOffset:]
+	e.fields = make([]journalField, 0, len(items)/itemSize)
+	for i := 0; i+itemSize <= len(items); i += itemSize {
+		var dataOffset uint64
+		if itemSize == 4 {
+			dataOffset = uint64(binary.LittleEndian.Uint32(items[i:]))
+		} else {
+			dataOffset = binary.LittleEndian.Uint64(items[i:])
+		}
+		if dataOffset == 0 {
+			continue
+		}
+		field, err := j.readData(dataOffset)
+		if err != nil {
+			return nil, err
+		}
+		e.fields = append(e.fields, field)
+	}
+	return e, nil
+}
+
+// readData reads the FIELD=value payload of a data object.
+func (j *journalFile) readData(offset uint64) (journalField, error) {
+	flags, object, err := j.readObject(offset, objectData)
+	if err != nil {
+		return journalField{}, err
+	}
+	payloadOffset := 64
+	if j.header.compact() {
+		payloadOffset = 72
+	}
+	if len(object) < payloadOffset {
+		return journalField{}, fmt.Errorf("invalid data object at offset %d", offset)
+	}
+
+	payload, err := decompress(flags, object[payloadOffset:])
+	if err != nil {
+		return journalField{}, fmt.Errorf("failed to decompress data object at offset %d: %w", offset, err)
+	}
+	name, value, ok := bytes.Cut(payload, []byte{'='})
+	if !ok {
+		return journalField{}, fmt.Errorf("invalid field at offset %d", offset)
+	}
+	return journalField{name: string(name), value: value}, nil
+}
+
+func decompress(flags byte, payload []byte) ([]byte, error) {
+	switch {
+	case flags&objectCompressedZSTD != 0:
+		return zstdDecoder.DecodeAll(payload, nil)
+	case flags&objectCompressedLZ4 != 0:
+		// The LZ4 block is prefixed with the size of the uncompressed data.
+		if len(payload) < 8 {
+			return nil, errors.New("invalid lz4 payload")
+		}
+		size := binary.LittleEndian.Uint64(payload)
+		if size > maxObjectSize {
+			return nil, fmt.Errorf("invalid lz4 uncompressed size %d", size)
+		}
+		dst := make([]byte, size)
+		n, err := lz4.UncompressBlock(payload[8:], dst)
+		return dst[:n], err
+	case flags&objectCompressedXZ != 0:
+		return nil, errors.New("xz compression is not supported")
+	default:
+		return payload, nil
+	}
+}
+
+// journalCursor is a parsed cursor, used to resume reading after the entry it points to.
+type journalCursor struct {
+	seqnumID string
+	seqnum   uint64
+	realtime uint64
+}
+
+func parseCursor(cursor string) (*journalCursor, error) {
+	c := &journalCursor{}
+	var hasSeqnum, hasRealtime bool
+	for _, part := range strings.Split(cursor, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid cursor %q", cursor)
+		}
+		var err error
+		switch key {
+		case "s":
+			c.seqnumID = value
+		case "i":
+			c.seqnum, err = strconv.ParseUint(value, 16, 64)
+			hasSeqnum = true
+		case "t":
+			c.realtime, err = strconv.ParseUint(value, 16, 64)
+			hasRealtime = true
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor %q: %w", cursor, err)
+		}
+	}
+	if !hasRealtime && (c.seqnumID == "" || !hasSeqnum) {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return c, nil
+}
+
+// after returns whether the entry is after the entry of the cursor. The
+// sequence numbers are compared when the entries come from the same journal,
+// and the timestamps otherwise.
+func (c *journalCursor) after(e *journalEntry) bool {
+	if c.seqnumID != "" && c.seqnumID == hex.EncodeToString(e.seqnumID[:]) {
+		return e.seqnum > c.seqnum
+	}
+	return e.realtime > c.realtime
+}
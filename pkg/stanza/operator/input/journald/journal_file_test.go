@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pierrec/lz4/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readExpected reads the JSON output of journalctl for a journal file of the
+// testdata, converted to the body of the entries.
+func readExpected(t *testing.T, name string) []map[string]interface{} {
+	f, err := os.Open(filepath.Join("testdata", name+".json"))
+	require.NoError(t, err)
+	defer f.Close()
+
+	var expected []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &body))
+		for key, value := range body {
+			values, ok := value.([]interface{})
+			if !ok {
+				continue
+			}
+			// journalctl outputs binary values as arrays of bytes, and
+			// repeated fields as arrays of values.
+			if _, ok = values[0].(float64); ok {
+				b := make([]byte, len(values))
+				for i, v := range values {
+					b[i] = byte(v.(float64))
+				}
+				body[key] = b
+			}
+		}
+		expected = append(expected, body)
+	}
+	require.NoError(t, scanner.Err())
+	return expected
+}
+
+func readAllEntries(t *testing.T, path string) []*journalEntry {
+	file, err := openJournalFile(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []*journalEntry
+	it := entryIterator{}
+	for {
+		offset, err := file.nextEntryOffset(&it)
+		require.NoError(t, err)
+		if offset == 0 {
+			return entries
+		}
+		e, err := file.readEntry(offset)
+		require.NoError(t, err)
+		entries = append(entries, e)
+	}
+}
+
+func TestReadJournalFile(t *testing.T) {
+	for _, name := range []string{"regular", "compact"} {
+		t.Run(name, func(t *testing.T) {
+			expected := readExpected(t, name)
+			entries := readAllEntries(t, filepath.Join("testdata", name+".journal"))
+			require.Len(t, entries, len(expected))
+
+			for i, e := range entries {
+				assert.Equal(t, expected[i]["__CURSOR"], e.cursor())
+
+				fields := map[string]interface{}{}
+				for _, f := range e.fields {
+					value := fieldValue(f.value)
+					if existing, ok := fields[f.name]; ok {
+						fields[f.name] = []interface{}{existing, value}
+						continue
+					}
+					fields[f.name] = value
+				}
+				for key, value := range expected[i] {
+					if strings.HasPrefix(key, "__") {
+						continue
+					}
+					assert.Equal(t, value, fields[key], "field %s of entry %d", key, i)
+				}
+			}
+
+			// The large message is compressed.
+			assert.Equal(t, "large "+strings.Repeat("x", 2000), string(entries[8].fields[messageIndex(entries[8])].value))
+		})
+	}
+}
+
+func messageIndex(e *journalEntry) int {
+	for i, f := range e.fields {
+		if f.name == "MESSAGE" {
+			return i
+		}
+	}
+	return -1
+}
+
+func TestSkipEntries(t *testing.T) {
+	file, err := openJournalFile(filepath.Join("testdata", "regular.journal"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	it := entryIterator{}
+	require.NoError(t, file.skipEntries(&it))
+	assert.Equal(t, uint64(10), it.next)
+
+	offset, err := file.nextEntryOffset(&it)
+	require.NoError(t, err)
+	assert.Zero(t, offset)
+}
+
+func TestOpenInvalidJournalFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.journal")
+	require.NoError(t, os.WriteFile(path, make([]byte, minHeaderSize), 0600))
+	_, err := openJournalFile(path)
+	assert.ErrorContains(t, err, "invalid signature")
+
+	header := make([]byte, minHeaderSize)
+	copy(header, journalSignature)
+	binary.LittleEndian.PutUint32(header[12:], 1<<10)
+	require.NoError(t, os.WriteFile(path, header, 0600))
+	_, err = openJournalFile(path)
+	assert.ErrorContains(t, err, "unsupported incompatible flags 0x400")
+}
+
+func TestDecompress(t *testing.T) {
+	data := []byte(strings.Repeat("MESSAGE=compressed ", 100))
+
+	compressed := make([]byte, lz4.CompressBlockBound(len(data)))
+	n, err := lz4.CompressBlock(data, compressed, nil)
+	require.NoError(t, err)
+	payload := binary.LittleEndian.AppendUint64(nil, uint64(len(data)))
+	payload = append(payload, compressed[:n]...)
+
+	decompressed, err := decompress(objectCompressedLZ4, payload)
+	require.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+
+	_, err = decompress(objectCompressedXZ, payload)
+	assert.EqualError(t, err, "xz compression is not supported")
+
+	decompressed, err = decompress(0, data)
+	require.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+}
+
+func TestParseCursor(t *testing.T) {
+	cursor, err := parseCursor("s=b3b262235e654e5294f5de40d12dd570;i=a;b=75a7df5d14a14aeb8af1d8223765b172;m=10a3d534c;t=65e253362f136;x=4cb2a438c89aea2f")
+	require.NoError(t, err)
+	assert.Equal(t, &journalCursor{seqnumID: "b3b262235e654e5294f5de40d12dd570", seqnum: 10, realtime: 0x65e253362f136}, cursor)
+
+	_, err = parseCursor("invalid")
+	assert.EqualError(t, err, `invalid cursor "invalid"`)
+	_, err = parseCursor("i=zz;t=1")
+	assert.ErrorContains(t, err, "invalid syntax")
+}
+
+func TestCursorAfter(t *testing.T) {
+	entries := readAllEntries(t, filepath.Join("testdata", "regular.journal"))
+	cursor, err := parseCursor(entries[4].cursor())
+	require.NoError(t, err)
+	assert.False(t, cursor.after(entries[3]))
+	assert.False(t, cursor.after(entries[4]))
+	assert.True(t, cursor.after(entries[5]))
+
+	// The entries of other journals are compared by time.
+	compact := readAllEntries(t, filepath.Join("testdata", "compact.journal"))
+	assert.True(t, cursor.after(compact[0]))
+	cursor, err = parseCursor(compact[4].cursor())
+	require.NoError(t, err)
+	assert.False(t, cursor.after(entries[9]))
+}
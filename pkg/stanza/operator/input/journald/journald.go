@@ -31,6 +31,14 @@ import (
 const operatorType = "journald_input"
 const waitDuration = 1 * time.Second
 
+// The readers of the journal.
+const (
+	readerJournalctl = "journalctl"
+	readerNative     = "native"
+)
+
+const defaultPollInterval = 200 * time.Millisecond
+
 func init() {
 	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
 }
@@ -43,9 +51,11 @@ func NewConfig() *Config {
 // NewConfigWithID creates a new input config with default values
 func NewConfigWithID(operatorID string) *Config {
 	return &Config{
-		InputConfig: helper.NewInputConfig(operatorID, operatorType),
-		StartAt:     "end",
-		Priority:    "info",
+		InputConfig:  helper.NewInputConfig(operatorID, operatorType),
+		StartAt:      "end",
+		Priority:     "info",
+		Reader:       readerJournalctl,
+		PollInterval: defaultPollInterval,
 	}
 }
 
@@ -60,6 +70,13 @@ type Config struct {
 	Priority  string        `mapstructure:"priority,omitempty"`
 	Matches   []MatchConfig `mapstructure:"matches,omitempty"`
 	Grep      string        `mapstructure:"grep,omitempty"`
+
+	// Reader is either journalctl, to run journalctl, or native, to read the
+	// journal files directly.
+	Reader string `mapstructure:"reader,omitempty"`
+	// PollInterval is the interval at which the journal files are checked for
+	// new entries by the native reader.
+	PollInterval time.Duration `mapstructure:"poll_interval,omitempty"`
 }
 
 type MatchConfig map[string]string
@@ -71,6 +88,20 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 		return nil, err
 	}
 
+	switch c.Reader {
+	case readerJournalctl, "":
+	case readerNative:
+		if c.StartAt != "end" && c.StartAt != "beginning" {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'start_at'", c.StartAt)
+		}
+		if c.PollInterval <= 0 {
+			return nil, fmt.Errorf("'poll_interval' must be positive")
+		}
+		return c.buildNative(inputOperator)
+	default:
+		return nil, fmt.Errorf("invalid value '%s' for parameter 'reader'", c.Reader)
+	}
+
 	args, err := c.buildArgs()
 	if err != nil {
 		return nil, err
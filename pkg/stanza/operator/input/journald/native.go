@@ -0,0 +1,475 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package journald // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/input/journald"
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// The directories read by default, as journalctl.
+var defaultJournalDirectories = []string{"/run/log/journal", "/var/log/journal"}
+
+var priorities = map[string]int{
+	"emerg":   0,
+	"alert":   1,
+	"crit":    2,
+	"err":     3,
+	"warning": 4,
+	"notice":  5,
+	"info":    6,
+	"debug":   7,
+}
+
+// NativeInput is an operator that reads the journal files directly, without
+// relying on journalctl.
+type NativeInput struct {
+	helper.InputOperator
+
+	directories      []string
+	files            []string
+	startAtBeginning bool
+	pollInterval     time.Duration
+	filter           *entryFilter
+
+	persister operator.Persister
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+
+	// readers are the open journal files, by file ID, so that a file which is
+	// renamed when it is rotated is read from where it was left off.
+	readers map[[16]byte]*journalReader
+	// cursor is the cursor saved by a previous run, if any.
+	cursor *journalCursor
+}
+
+// journalReader is the read position of a journal file.
+type journalReader struct {
+	path string
+	file *journalFile
+	it   entryIterator
+	// next is the next entry to emit, if it has been read already.
+	next *journalEntry
+}
+
+func (c Config) buildNative(inputOperator helper.InputOperator) (*NativeInput, error) {
+	filter, err := c.buildFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	input := &NativeInput{
+		InputOperator:    inputOperator,
+		startAtBeginning: c.StartAt == "beginning",
+		pollInterval:     c.PollInterval,
+		filter:           filter,
+	}
+	switch {
+	case c.Directory != nil:
+		input.directories = []string{*c.Directory}
+	case len(c.Files) > 0:
+		input.files = c.Files
+	default:
+		input.directories = defaultJournalDirectories
+	}
+	return input, nil
+}
+
+// Start will start reading the journal files.
+func (operator *NativeInput) Start(persister operator.Persister) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	operator.cancel = cancel
+	operator.persister = persister
+	operator.readers = map[[16]byte]*journalReader{}
+
+	cursor, err := persister.Get(ctx, lastReadCursorKey)
+	if err != nil {
+		return fmt.Errorf("failed to get journald state: %w", err)
+	}
+	if cursor != nil {
+		if operator.cursor, err = parseCursor(string(cursor)); err != nil {
+			operator.Warnw("Ignoring invalid saved cursor", zap.Error(err))
+		}
+	}
+
+	// The entries of the files present at startup are skipped when starting at
+	// the end, while the entries of the files created later are all read.
+	skipExisting := operator.cursor == nil && !operator.startAtBeginning
+	operator.updateReaders(skipExisting)
+
+	operator.wg.Add(1)
+	go func() {
+		defer operator.wg.Done()
+		ticker := time.NewTicker(operator.pollInterval)
+		defer ticker.Stop()
+		for {
+			operator.readEntries(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				operator.updateReaders(false)
+			}
+		}
+	}()
+	return nil
+}
+
+// journalPaths returns the paths of the journal files to read.
+func (operator *NativeInput) journalPaths() []string {
+	if len(operator.files) > 0 {
+		return operator.files
+	}
+	var paths []string
+	for _, dir := range operator.directories {
+		// The files are stored in a subdirectory named after the machine ID.
+		for _, pattern := range []string{"*.journal", "*/*.journal"} {
+			matches, err := filepath.Glob(filepath.Join(dir, pattern))
+			if err != nil {
+				operator.Warnw("Failed to list journal files", zap.String("directory", dir), zap.Error(err))
+				continue
+			}
+			paths = append(paths, matches...)
+		}
+	}
+	return paths
+}
+
+// updateReaders opens the new journal files, closes those which were removed,
+// and refreshes the headers of the others to find their new entries.
+func (operator *NativeInput) updateReaders(skipExisting bool) {
+	seen := map[[16]byte]bool{}
+	for _, p := range operator.journalPaths() {
+		file, err := openJournalFile(p)
+		if err != nil {
+			operator.Debugw("Failed to open journal file", zap.String("path", p), zap.Error(err))
+			continue
+		}
+		id := file.header.fileID
+		seen[id] = true
+
+		if r, ok := operator.readers[id]; ok {
+			file.Close()
+			r.path = p
+			if err = r.file.readHeader(); err != nil {
+				operator.Warnw("Failed to read journal file header", zap.String("path", p), zap.Error(err))
+			}
+			continue
+		}
+
+		r := &journalReader{path: p, file: file}
+		if skipExisting {
+			if err = file.skipEntries(&r.it); err != nil {
+				operator.Warnw("Failed to skip journal entries", zap.String("path", p), zap.Error(err))
+			}
+		}
+		operator.readers[id] = r
+	}
+
+	for id, r := range operator.readers {
+		if !seen[id] {
+			r.file.Close()
+			delete(operator.readers, id)
+		}
+	}
+}
+
+// readEntries emits the new entries of all the files, merged by time.
+func (operator *NativeInput) readEntries(ctx context.Context) {
+	for ctx.Err() == nil {
+		var oldest *journalReader
+		for _, r := range operator.readers {
+			if r.next == nil {
+				r.next = operator.nextEntry(r)
+			}
+			if r.next != nil && (oldest == nil || before(r.next, oldest.next)) {
+				oldest = r
+			}
+		}
+		if oldest == nil {
+			return
+		}
+
+		e := oldest.next
+		oldest.next = nil
+		if err := operator.persister.Set(ctx, lastReadCursorKey, []byte(e.cursor())); err != nil {
+			operator.Warnw("Failed to set offset", zap.Error(err))
+		}
+		ent, err := operator.newEntry(e)
+		if err != nil {
+			operator.Warnw("Failed to create entry", zap.Error(err))
+			continue
+		}
+		operator.Write(ctx, ent)
+	}
+}
+
+// nextEntry returns the next entry of the file to emit, or nil if there are none yet.
+func (operator *NativeInput) nextEntry(r *journalReader) *journalEntry {
+	for {
+		// The iterator is restored on errors to read the entry again on the
+		// next poll, as it may be partially written.
+		it := r.it
+		offset, err := r.file.nextEntryOffset(&r.it)
+		if err != nil {
+			operator.Debugw("Failed to read journal entry", zap.String("path", r.path), zap.Error(err))
+			r.it = it
+			return nil
+		}
+		if offset == 0 {
+			return nil
+		}
+		e, err := r.file.readEntry(offset)
+		if err != nil {
+			operator.Debugw("Failed to read journal entry", zap.String("path", r.path), zap.Error(err))
+			r.it = it
+			return nil
+		}
+		if operator.cursor != nil && !operator.cursor.after(e) {
+			continue
+		}
+		if operator.filter.match(e) {
+			return e
+		}
+	}
+}
+
+func before(a, b *journalEntry) bool {
+	if a.realtime != b.realtime {
+		return a.realtime < b.realtime
+	}
+	return a.seqnum < b.seqnum
+}
+
+// newEntry converts a journal entry to an entry, with the same body as the
+// JSON output of journalctl.
+func (operator *NativeInput) newEntry(e *journalEntry) (*entry.Entry, error) {
+	body := make(map[string]interface{}, len(e.fields)+3)
+	for _, f := range e.fields {
+		value := fieldValue(f.value)
+		switch existing := body[f.name].(type) {
+		case nil:
+			body[f.name] = value
+		case []interface{}:
+			body[f.name] = append(existing, value)
+		default:
+			body[f.name] = []interface{}{existing, value}
+		}
+	}
+	body["__CURSOR"] = e.cursor()
+	body["__MONOTONIC_TIMESTAMP"] = strconv.FormatUint(e.monotonic, 10)
+	if _, ok := body["_BOOT_ID"]; !ok {
+		body["_BOOT_ID"] = hex.EncodeToString(e.bootID[:])
+	}
+
+	ent, err := operator.NewEntry(body)
+	if err != nil {
+		return nil, err
+	}
+	ent.Timestamp = time.UnixMicro(int64(e.realtime))
+	return ent, nil
+}
+
+// fieldValue returns the value as a string, or as bytes if it is binary.
+func fieldValue(value []byte) interface{} {
+	if !utf8.Valid(value) {
+		return value
+	}
+	for _, c := range value {
+		if c < ' ' && c != '\n' && c != '\t' {
+			return value
+		}
+	}
+	return string(value)
+}
+
+// entryFilter implements the units, priority, matches and grep options, which
+// are ANDed.
+type entryFilter struct {
+	units       []string
+	minPriority int
+	maxPriority int
+	matches     []MatchConfig
+	grep        *regexp.Regexp
+}
+
+// The fields which refer to the unit of an entry.
+var unitFields = []string{"_SYSTEMD_UNIT", "UNIT", "COREDUMP_UNIT", "OBJECT_SYSTEMD_UNIT"}
+
+func (c Config) buildFilter() (*entryFilter, error) {
+	f := &entryFilter{matches: c.Matches}
+
+	var err error
+	if f.minPriority, f.maxPriority, err = parsePriorityRange(c.Priority); err != nil {
+		return nil, err
+	}
+
+	for _, unit := range c.Units {
+		// As journalctl, units without a suffix are services.
+		if !strings.Contains(unit, ".") {
+			unit += ".service"
+		}
+		f.units = append(f.units, unit)
+	}
+
+	for _, mc := range c.Matches {
+		if _, err = buildMatchConfig(mc); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.Grep != "" {
+		pattern := c.Grep
+		// As journalctl, the pattern is case insensitive if it is all lowercase.
+		if strings.ToLower(pattern) == pattern {
+			pattern = "(?i)" + pattern
+		}
+		if f.grep, err = regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("invalid value '%s' for parameter 'grep': %w", c.Grep, err)
+		}
+	}
+	return f, nil
+}
+
+// parsePriorityRange parses a priority or a range of priorities, as accepted
+// by the --priority option of journalctl.
+func parsePriorityRange(value string) (int, int, error) {
+	if from, to, ok := strings.Cut(value, ".."); ok {
+		minPriority, err := parsePriority(from)
+		if err != nil {
+			return 0, 0, err
+		}
+		maxPriority, err := parsePriority(to)
+		if err != nil {
+			return 0, 0, err
+		}
+		if minPriority > maxPriority {
+			minPriority, maxPriority = maxPriority, minPriority
+		}
+		return minPriority, maxPriority, nil
+	}
+	maxPriority, err := parsePriority(value)
+	return 0, maxPriority, err
+}
+
+func parsePriority(value string) (int, error) {
+	if p, ok := priorities[value]; ok {
+		return p, nil
+	}
+	if p, err := strconv.Atoi(value); err == nil && p >= 0 && p <= 7 {
+		return p, nil
+	}
+	return 0, fmt.Errorf("invalid value '%s' for parameter 'priority'", value)
+}
+
+func (f *entryFilter) match(e *journalEntry) bool {
+	return f.matchPriority(e) && f.matchUnits(e) && f.matchMatches(e) && f.matchGrep(e)
+}
+
+func (f *entryFilter) matchPriority(e *journalEntry) bool {
+	for _, field := range e.fields {
+		if field.name == "PRIORITY" {
+			p, err := strconv.Atoi(string(field.value))
+			return err == nil && p >= f.minPriority && p <= f.maxPriority
+		}
+	}
+	return false
+}
+
+func (f *entryFilter) matchUnits(e *journalEntry) bool {
+	if len(f.units) == 0 {
+		return true
+	}
+	for _, field := range e.fields {
+		if !isUnitField(field.name) {
+			continue
+		}
+		for _, unit := range f.units {
+			if ok, _ := path.Match(unit, string(field.value)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isUnitField(name string) bool {
+	for _, unitField := range unitFields {
+		if name == unitField {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *entryFilter) matchMatches(e *journalEntry) bool {
+	if len(f.matches) == 0 {
+		return true
+	}
+	for _, mc := range f.matches {
+		if matchAll(mc, e) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAll(mc MatchConfig, e *journalEntry) bool {
+	for key, value := range mc {
+		found := false
+		for _, field := range e.fields {
+			if field.name == key && string(field.value) == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *entryFilter) matchGrep(e *journalEntry) bool {
+	if f.grep == nil {
+		return true
+	}
+	for _, field := range e.fields {
+		if field.name == "MESSAGE" && f.grep.Match(field.value) {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop will stop reading the journal files.
+func (operator *NativeInput) Stop() error {
+	if operator.cancel != nil {
+		operator.cancel()
+	}
+	operator.wg.Wait()
+	for id, r := range operator.readers {
+		r.file.Close()
+		delete(operator.readers, id)
+	}
+	return nil
+}
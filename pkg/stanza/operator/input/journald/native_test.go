@@ -0,0 +1,253 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+// +build linux
+
+package journald
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+var testJournalFiles = []string{
+	filepath.Join("testdata", "regular.journal"),
+	filepath.Join("testdata", "compact.journal"),
+}
+
+func newNativeConfig() *Config {
+	cfg := NewConfigWithID("my_journald_input")
+	cfg.OutputIDs = []string{"fake"}
+	cfg.Reader = readerNative
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.StartAt = "beginning"
+	cfg.Files = testJournalFiles
+	cfg.Priority = "debug"
+	return cfg
+}
+
+func startNativeInput(t *testing.T, cfg *Config, persister operator.Persister) *testutil.FakeOutput {
+	op, err := cfg.Build(testutil.Logger(t))
+	require.NoError(t, err)
+	require.IsType(t, &NativeInput{}, op)
+
+	output := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{output}))
+	require.NoError(t, op.Start(persister))
+	t.Cleanup(func() { require.NoError(t, op.Stop()) })
+	return output
+}
+
+func receiveEntries(t *testing.T, output *testutil.FakeOutput, count int) []*entry.Entry {
+	entries := make([]*entry.Entry, 0, count)
+	for len(entries) < count {
+		select {
+		case e := <-output.Received:
+			entries = append(entries, e)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for entries", "received %d of %d", len(entries), count)
+		}
+	}
+	output.ExpectNoEntry(t, 50*time.Millisecond)
+	return entries
+}
+
+func messages(entries []*entry.Entry) []string {
+	var result []string
+	for _, e := range entries {
+		result = append(result, e.Body.(map[string]interface{})["MESSAGE"].(string))
+	}
+	return result
+}
+
+func TestNativeInput(t *testing.T) {
+	expected := append(readExpected(t, "regular"), readExpected(t, "compact")...)
+	output := startNativeInput(t, newNativeConfig(), testutil.NewMockPersister("test"))
+
+	entries := receiveEntries(t, output, len(expected))
+	for i, e := range entries {
+		realtime, err := strconv.ParseInt(expected[i]["__REALTIME_TIMESTAMP"].(string), 10, 64)
+		require.NoError(t, err)
+		assert.Equal(t, time.UnixMicro(realtime), e.Timestamp)
+
+		delete(expected[i], "__REALTIME_TIMESTAMP")
+		assert.Equal(t, expected[i], e.Body)
+	}
+}
+
+func TestNativeInputFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(cfg *Config)
+		expected []string
+	}{
+		{
+			name:     "units",
+			modify:   func(cfg *Config) { cfg.Units = []string{"test"} },
+			expected: []string{"Starting test service", "Something went wrong", "Starting test service", "Something went wrong"},
+		},
+		{
+			name:     "priority",
+			modify:   func(cfg *Config) { cfg.Priority = "err" },
+			expected: []string{"Something went wrong", "Something went wrong"},
+		},
+		{
+			name:     "priority range",
+			modify:   func(cfg *Config) { cfg.Priority = "5..err"; cfg.Files = testJournalFiles[:1] },
+			expected: []string{"Something went wrong", "line one\nline two"},
+		},
+		{
+			name: "matches",
+			modify: func(cfg *Config) {
+				cfg.Matches = []MatchConfig{
+					{"TEST_ID": "regular", "PRIORITY": "7"},
+					{"TEST_ID": "compact", "PRIORITY": "3"},
+				}
+			},
+			expected: []string{"debug details", "Something went wrong"},
+		},
+		{
+			name:     "grep",
+			modify:   func(cfg *Config) { cfg.Grep = "LINE|wrong"; cfg.Files = testJournalFiles[1:] },
+			expected: []string{"Something went wrong"},
+		},
+		{
+			name:     "case insensitive grep",
+			modify:   func(cfg *Config) { cfg.Grep = "line|wrong"; cfg.Files = testJournalFiles[1:] },
+			expected: []string{"Something went wrong", "line one\nline two"},
+		},
+		{
+			name: "all",
+			modify: func(cfg *Config) {
+				cfg.Units = []string{"test.service"}
+				cfg.Priority = "info"
+				cfg.Matches = []MatchConfig{{"TEST_ID": "compact"}}
+				cfg.Grep = "start"
+			},
+			expected: []string{"Starting test service"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newNativeConfig()
+			tt.modify(cfg)
+			output := startNativeInput(t, cfg, testutil.NewMockPersister("test"))
+			assert.Equal(t, tt.expected, messages(receiveEntries(t, output, len(tt.expected))))
+		})
+	}
+}
+
+func TestNativeInputResumesFromCursor(t *testing.T) {
+	expected := readExpected(t, "regular")
+	persister := testutil.NewMockPersister("test")
+	require.NoError(t, persister.Set(context.Background(), lastReadCursorKey, []byte(expected[7]["__CURSOR"].(string))))
+
+	cfg := newNativeConfig()
+	cfg.StartAt = "end"
+	cfg.Matches = []MatchConfig{{"TEST_ID": "regular"}, {"TEST_ID": "compact"}}
+	output := startNativeInput(t, cfg, persister)
+
+	// The entries of the other file are after the cursor.
+	entries := receiveEntries(t, output, 7)
+	assert.Equal(t, expected[8]["__CURSOR"], entries[0].Body.(map[string]interface{})["__CURSOR"])
+
+	cursor, err := persister.Get(context.Background(), lastReadCursorKey)
+	require.NoError(t, err)
+	assert.Equal(t, entries[6].Body.(map[string]interface{})["__CURSOR"], string(cursor))
+}
+
+func TestNativeInputDirectory(t *testing.T) {
+	dir := t.TempDir()
+	machineDir := filepath.Join(dir, "fed6b2924c424cf1b9a322f606b4de6d")
+	require.NoError(t, os.Mkdir(machineDir, 0700))
+	copyFile(t, testJournalFiles[0], filepath.Join(machineDir, "system.journal"))
+
+	cfg := newNativeConfig()
+	cfg.Files = nil
+	cfg.Directory = &dir
+	cfg.StartAt = "end"
+	output := startNativeInput(t, cfg, testutil.NewMockPersister("test"))
+
+	// The entries of the existing files are skipped.
+	output.ExpectNoEntry(t, 100*time.Millisecond)
+
+	// A file rotated by renaming it is not read again, while the entries of a new file are read.
+	require.NoError(t, os.Rename(filepath.Join(machineDir, "system.journal"), filepath.Join(machineDir, "system@1.journal")))
+	copyFile(t, testJournalFiles[1], filepath.Join(machineDir, "system.journal"))
+	entries := receiveEntries(t, output, 10)
+	assert.Equal(t, "Journal stopped", messages(entries)[9])
+	assert.Equal(t, "8aa031dc8c444d27b105684fdd1a30af", entries[0].Body.(map[string]interface{})["__CURSOR"].(string)[2:34])
+}
+
+func copyFile(t *testing.T, src, dst string) {
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	// Write to a temporary file, so that the file is not read while being written.
+	tmp := dst + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, data, 0600))
+	require.NoError(t, os.Rename(tmp, dst))
+}
+
+func TestBuildNativeConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+		err    string
+	}{
+		{
+			name:   "invalid reader",
+			modify: func(cfg *Config) { cfg.Reader = "other" },
+			err:    "invalid value 'other' for parameter 'reader'",
+		},
+		{
+			name:   "invalid start_at",
+			modify: func(cfg *Config) { cfg.StartAt = "middle" },
+			err:    "invalid value 'middle' for parameter 'start_at'",
+		},
+		{
+			name:   "invalid poll_interval",
+			modify: func(cfg *Config) { cfg.PollInterval = 0 },
+			err:    "'poll_interval' must be positive",
+		},
+		{
+			name:   "invalid priority",
+			modify: func(cfg *Config) { cfg.Priority = "8" },
+			err:    "invalid value '8' for parameter 'priority'",
+		},
+		{
+			name:   "invalid priority range",
+			modify: func(cfg *Config) { cfg.Priority = "err..verbose" },
+			err:    "invalid value 'verbose' for parameter 'priority'",
+		},
+		{
+			name:   "invalid match",
+			modify: func(cfg *Config) { cfg.Matches = []MatchConfig{{"-invalid": "value"}} },
+			err:    "'-invalid' is not a valid Systemd field name",
+		},
+		{
+			name:   "invalid grep",
+			modify: func(cfg *Config) { cfg.Grep = "(" },
+			err:    "invalid value '(' for parameter 'grep'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newNativeConfig()
+			tt.modify(cfg)
+			_, err := cfg.Build(testutil.Logger(t))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
@@ -169,11 +169,13 @@ func newOctetFrameSplitFunc(flushAtEOF bool) bufio.SplitFunc {
 		}
 
 		advance = frameMaxIndex + frameLenValue
-		// the limitation here is that we can only line split within a single buffer
-		// the context of buffer length cannot be pass onto the next scan
-		capacity := cap(data)
-		if advance > capacity {
-			return capacity, data, nil
+		if advance > len(data) {
+			// The frame may span several reads, e.g. over TLS connections, so wait
+			// for the rest of it unless no more data is expected.
+			if atEOF && flushAtEOF {
+				return len(data), data, nil
+			}
+			return 0, nil, nil
 		}
 		token = data[:advance]
 		err = nil
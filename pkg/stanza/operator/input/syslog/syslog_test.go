@@ -50,8 +50,8 @@ var (
 				"msg_id":   "ID52020",
 				"priority": 86,
 				"proc_id":  "23108",
-				"structured_data": map[string]map[string]string{
-					"SecureAuth@27389": {
+				"structured_data": map[string]interface{}{
+					"SecureAuth@27389": map[string]interface{}{
 						"PEN":             "27389",
 						"Realm":           "SecureAuth0",
 						"UserHostAddress": "192.168.2.132",
@@ -238,14 +238,25 @@ func TestOctetFramingSplitFunc(t *testing.T) {
 		},
 		{
 			Name: "over capacity",
+			Raw: func() []byte {
+				newRaw := internal.GeneratedByteSliceOfLength(5000)
+				newRaw = append([]byte(`5000 `), newRaw...)
+				return append(newRaw, []byte(`3 abc`)...)
+			}(),
+			ExpectedTokenized: []string{
+				`5000 ` + string(internal.GeneratedByteSliceOfLength(5000)),
+				`3 abc`,
+			},
+		},
+		{
+			Name: "incomplete frame",
 			Raw: func() []byte {
 				newRaw := internal.GeneratedByteSliceOfLength(4092)
 				newRaw = append([]byte(`5000 `), newRaw...)
 				return newRaw
 			}(),
 			ExpectedTokenized: []string{
-				`5000 ` + string(internal.GeneratedByteSliceOfLength(4091)),
-				`j`,
+				`5000 ` + string(internal.GeneratedByteSliceOfLength(4092)),
 			},
 		},
 	}
@@ -159,8 +159,8 @@ func CreateCases(basicConfig func() *Config) ([]Case, error) {
 					"msg_id":   "ID52020",
 					"priority": 86,
 					"proc_id":  "23108",
-					"structured_data": map[string]map[string]string{
-						"SecureAuth@27389": {
+					"structured_data": map[string]interface{}{
+						"SecureAuth@27389": map[string]interface{}{
 							"PEN":             "27389",
 							"Realm":           "SecureAuth0",
 							"UserHostAddress": "192.168.2.132",
@@ -197,8 +197,8 @@ func CreateCases(basicConfig func() *Config) ([]Case, error) {
 					"msg_id":   "ID52020",
 					"priority": 86,
 					"proc_id":  "23108",
-					"structured_data": map[string]map[string]string{
-						"SecureAuth@27389": {
+					"structured_data": map[string]interface{}{
+						"SecureAuth@27389": map[string]interface{}{
 							"PEN":             "27389",
 							"Realm":           "SecureAuth0",
 							"UserHostAddress": "192.168.2.132",
@@ -235,8 +235,8 @@ func CreateCases(basicConfig func() *Config) ([]Case, error) {
 					"msg_id":   "ID52020",
 					"priority": 86,
 					"proc_id":  "23108",
-					"structured_data": map[string]map[string]string{
-						"SecureAuth@27389": {
+					"structured_data": map[string]interface{}{
+						"SecureAuth@27389": map[string]interface{}{
 							"PEN":             "27389",
 							"Realm":           "SecureAuth0",
 							"UserHostAddress": "192.168.2.132",
@@ -250,6 +250,108 @@ func CreateCases(basicConfig func() *Config) ([]Case, error) {
 			true,
 			false,
 		},
+		{
+			"Auto RFC3164",
+			func() *Config {
+				cfg := basicConfig()
+				cfg.Protocol = Auto
+				cfg.Location = location["utc"].String()
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: fmt.Sprintf("<34>%s 1.2.3.4 apache_server: test message", ts.Format("Jan _2 15:04:05")),
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), 0, location["utc"]),
+				Severity:     entry.Error2,
+				SeverityText: "crit",
+				Attributes: map[string]interface{}{
+					"appname":  "apache_server",
+					"facility": 4,
+					"hostname": "1.2.3.4",
+					"message":  "test message",
+					"priority": 34,
+				},
+				Body: fmt.Sprintf("<34>%s 1.2.3.4 apache_server: test message", ts.Format("Jan _2 15:04:05")),
+			},
+			true,
+			true,
+		},
+		{
+			"Auto RFC5424",
+			func() *Config {
+				cfg := basicConfig()
+				cfg.Protocol = Auto
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: `<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0" UserID="Tester2" PEN="27389"] Found the user for retrieving user's profile`,
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(2015, 8, 5, 21, 58, 59, 693000000, time.UTC),
+				Severity:     entry.Info,
+				SeverityText: "info",
+				Attributes: map[string]interface{}{
+					"appname":  "SecureAuth0",
+					"facility": 10,
+					"hostname": "192.168.2.132",
+					"message":  "Found the user for retrieving user's profile",
+					"msg_id":   "ID52020",
+					"priority": 86,
+					"proc_id":  "23108",
+					"structured_data": map[string]interface{}{
+						"SecureAuth@27389": map[string]interface{}{
+							"PEN":             "27389",
+							"Realm":           "SecureAuth0",
+							"UserHostAddress": "192.168.2.132",
+							"UserID":          "Tester2",
+						},
+					},
+					"version": 1,
+				},
+				Body: `<86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0" UserID="Tester2" PEN="27389"] Found the user for retrieving user's profile`,
+			},
+			true,
+			true,
+		},
+		{
+			"Auto Octet Counting",
+			func() *Config {
+				cfg := basicConfig()
+				cfg.Protocol = Auto
+				cfg.EnableOctetCounting = true
+				return cfg
+			}(),
+			&entry.Entry{
+				Body: `215 <86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0" UserID="Tester2" PEN="27389"] Found the user for retrieving user's profile`,
+			},
+			&entry.Entry{
+				Timestamp:    time.Date(2015, 8, 5, 21, 58, 59, 693000000, time.UTC),
+				Severity:     entry.Info,
+				SeverityText: "info",
+				Attributes: map[string]interface{}{
+					"appname":  "SecureAuth0",
+					"facility": 10,
+					"hostname": "192.168.2.132",
+					"message":  "Found the user for retrieving user's profile",
+					"msg_id":   "ID52020",
+					"priority": 86,
+					"proc_id":  "23108",
+					"structured_data": map[string]interface{}{
+						"SecureAuth@27389": map[string]interface{}{
+							"PEN":             "27389",
+							"Realm":           "SecureAuth0",
+							"UserHostAddress": "192.168.2.132",
+							"UserID":          "Tester2",
+						},
+					},
+					"version": 1,
+				},
+				Body: `215 <86>1 2015-08-05T21:58:59.693Z 192.168.2.132 SecureAuth0 23108 ID52020 [SecureAuth@27389 UserHostAddress="192.168.2.132" Realm="SecureAuth0" UserID="Tester2" PEN="27389"] Found the user for retrieving user's profile`,
+			},
+			true,
+			false,
+		},
 	}
 
 	return cases, nil
@@ -8,6 +8,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
 	"time"
 
 	sl "github.com/influxdata/go-syslog/v3"
@@ -27,6 +29,8 @@ const (
 
 	RFC3164 = "rfc3164"
 	RFC5424 = "rfc5424"
+	// Auto detects whether each message is an RFC3164 or an RFC5424 message.
+	Auto = "auto"
 
 	NULTrailer = "NUL"
 	LFTrailer  = "LF"
@@ -80,11 +84,11 @@ func (c Config) Build(logger *zap.SugaredLogger) (operator.Operator, error) {
 	switch {
 	case c.Protocol == "":
 		return nil, fmt.Errorf("missing field 'protocol'")
-	case c.Protocol != RFC5424 && (c.NonTransparentFramingTrailer != nil || c.EnableOctetCounting):
-		return nil, errors.New("octet_counting and non_transparent_framing are only compatible with protocol rfc5424")
-	case c.Protocol == RFC5424 && (c.NonTransparentFramingTrailer != nil && c.EnableOctetCounting):
+	case c.Protocol != RFC5424 && c.Protocol != Auto && (c.NonTransparentFramingTrailer != nil || c.EnableOctetCounting):
+		return nil, errors.New("octet_counting and non_transparent_framing are only compatible with protocol rfc5424 or auto")
+	case c.NonTransparentFramingTrailer != nil && c.EnableOctetCounting:
 		return nil, errors.New("only one of octet_counting or non_transparent_framing can be enabled")
-	case c.Protocol == RFC5424 && c.NonTransparentFramingTrailer != nil:
+	case c.NonTransparentFramingTrailer != nil:
 		if *c.NonTransparentFramingTrailer != NULTrailer && *c.NonTransparentFramingTrailer != LFTrailer {
 			return nil, fmt.Errorf("invalid non_transparent_framing_trailer '%s'. Must be either 'LF' or 'NUL'", *c.NonTransparentFramingTrailer)
 		}
@@ -134,11 +138,48 @@ func (s *Parser) buildParseFunc() (parseFunc, error) {
 			}, nil
 		}
 
+	case Auto:
+		return s.parseAuto, nil
 	default:
 		return nil, fmt.Errorf("invalid protocol %s", s.protocol)
 	}
 }
 
+// rfc5424Header matches the beginning of RFC5424 messages, whose priority is followed by a version,
+// while it is followed by a timestamp or the message in RFC3164 messages.
+var rfc5424Header = regexp.MustCompile(`^<\d{1,3}>[1-9]\d{0,2} `)
+
+// octetCountingFrame matches the length prefixing the messages framed with octet counting.
+var octetCountingFrame = regexp.MustCompile(`^([1-9]\d*) `)
+
+// parseAuto removes the framing of a message, and parses it as an RFC5424 or an RFC3164 message.
+func (s *Parser) parseAuto(input []byte) (sl.Message, error) {
+	switch {
+	case s.enableOctetCounting:
+		frame := octetCountingFrame.FindSubmatch(input)
+		if frame == nil {
+			return nil, errors.New("message does not start with its length")
+		}
+		length, err := strconv.Atoi(string(frame[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid message length: %w", err)
+		}
+		input = input[len(frame[0]):]
+		if length < len(input) {
+			input = input[:length]
+		}
+	case s.nonTransparentFramingTrailer != nil && *s.nonTransparentFramingTrailer == LFTrailer:
+		input = bytes.TrimSuffix(input, []byte{'\n'})
+	case s.nonTransparentFramingTrailer != nil && *s.nonTransparentFramingTrailer == NULTrailer:
+		input = bytes.TrimSuffix(input, []byte{0})
+	}
+
+	if rfc5424Header.Match(input) {
+		return rfc5424.NewMachine().Parse(input)
+	}
+	return rfc3164.NewMachine(rfc3164.WithLocaleTimezone(s.location)).Parse(input)
+}
+
 // Parser is an operator that parses syslog.
 type Parser struct {
 	helper.ParserOperator
@@ -243,7 +284,7 @@ func (s *Parser) toSafeMap(message map[string]interface{}) (map[string]interface
 				delete(message, key)
 				continue
 			}
-			message[key] = *v
+			message[key] = structuredDataToMap(*v)
 		default:
 			return nil, fmt.Errorf("key %s has unknown field of type %T", key, v)
 		}
@@ -252,6 +293,20 @@ func (s *Parser) toSafeMap(message map[string]interface{}) (map[string]interface
 	return message, nil
 }
 
+// structuredDataToMap converts the structured data elements, by SD-ID, to nested maps
+// which are preserved as nested attributes.
+func structuredDataToMap(structuredData map[string]map[string]string) map[string]interface{} {
+	elements := make(map[string]interface{}, len(structuredData))
+	for id, params := range structuredData {
+		element := make(map[string]interface{}, len(params))
+		for name, value := range params {
+			element[name] = value
+		}
+		elements[id] = element
+	}
+	return elements
+}
+
 func toBytes(value interface{}) ([]byte, error) {
 	switch v := value.(type) {
 	case string:
@@ -0,0 +1,36 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// MaxStale is the total time a stream will be kept past the time it last received a data point.
+	// Set to 0 to retain streams indefinitely.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+
+	// MaxStreams is the upper limit of streams to track at the same time.
+	// Data points of new streams are dropped once the limit is reached. Set to 0 to disable the limit.
+	MaxStreams int `mapstructure:"max_streams"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (config *Config) Validate() error {
+	if config.MaxStale < 0 {
+		return errors.New("max_stale must not be negative")
+	}
+	if config.MaxStreams < 0 {
+		return errors.New("max_streams must not be negative")
+	}
+	return nil
+}
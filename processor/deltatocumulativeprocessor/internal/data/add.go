@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package data implements the accumulation of delta data points into
+// cumulative data points.
+package data // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/merge"
+)
+
+// AddNumber adds the value of the delta point dp to the cumulative point acc.
+// If either of the points holds a double value, the result is a double.
+func AddNumber(acc, dp pmetric.NumberDataPoint) {
+	merge.Number(acc, dp)
+	acc.SetTimestamp(dp.Timestamp())
+	dp.Exemplars().CopyTo(acc.Exemplars())
+}
+
+// AddHistogram adds the delta point dp to the cumulative point acc.
+// It returns false and leaves acc untouched if the bucket boundaries of the
+// two points differ.
+func AddHistogram(acc, dp pmetric.HistogramDataPoint) bool {
+	if !merge.Histogram(acc, dp) {
+		return false
+	}
+	acc.SetTimestamp(dp.Timestamp())
+	dp.Exemplars().CopyTo(acc.Exemplars())
+	return true
+}
+
+// AddExponentialHistogram adds the delta point dp to the cumulative point acc.
+// If the points use different scales, both are brought to the smaller of the
+// two scales before merging.
+func AddExponentialHistogram(acc, dp pmetric.ExponentialHistogramDataPoint) {
+	merge.ExponentialHistogram(acc, dp)
+	acc.SetTimestamp(dp.Timestamp())
+	dp.Exemplars().CopyTo(acc.Exemplars())
+}
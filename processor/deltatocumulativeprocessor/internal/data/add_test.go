@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestAddNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		acc      func(pmetric.NumberDataPoint)
+		dp       func(pmetric.NumberDataPoint)
+		wantType pmetric.NumberDataPointValueType
+		wantInt  int64
+		wantDbl  float64
+	}{
+		{
+			name:     "int",
+			acc:      func(dp pmetric.NumberDataPoint) { dp.SetIntValue(3) },
+			dp:       func(dp pmetric.NumberDataPoint) { dp.SetIntValue(4) },
+			wantType: pmetric.NumberDataPointValueTypeInt,
+			wantInt:  7,
+		},
+		{
+			name:     "double",
+			acc:      func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(1.5) },
+			dp:       func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(2.25) },
+			wantType: pmetric.NumberDataPointValueTypeDouble,
+			wantDbl:  3.75,
+		},
+		{
+			name:     "mixed",
+			acc:      func(dp pmetric.NumberDataPoint) { dp.SetIntValue(1) },
+			dp:       func(dp pmetric.NumberDataPoint) { dp.SetDoubleValue(0.5) },
+			wantType: pmetric.NumberDataPointValueTypeDouble,
+			wantDbl:  1.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc, dp := pmetric.NewNumberDataPoint(), pmetric.NewNumberDataPoint()
+			acc.SetTimestamp(10)
+			tt.acc(acc)
+			dp.SetStartTimestamp(10)
+			dp.SetTimestamp(20)
+			tt.dp(dp)
+
+			AddNumber(acc, dp)
+			assert.Equal(t, tt.wantType, acc.ValueType())
+			assert.Equal(t, tt.wantInt, acc.IntValue())
+			assert.Equal(t, tt.wantDbl, acc.DoubleValue())
+			assert.EqualValues(t, 20, acc.Timestamp())
+		})
+	}
+}
+
+func TestAddHistogram(t *testing.T) {
+	newPoint := func(count uint64, sum, min, max float64, buckets []uint64) pmetric.HistogramDataPoint {
+		dp := pmetric.NewHistogramDataPoint()
+		dp.ExplicitBounds().FromRaw([]float64{1, 10})
+		dp.BucketCounts().FromRaw(buckets)
+		dp.SetCount(count)
+		dp.SetSum(sum)
+		dp.SetMin(min)
+		dp.SetMax(max)
+		return dp
+	}
+
+	acc := newPoint(3, 12, 0.5, 9, []uint64{1, 2, 0})
+	dp := newPoint(2, 30, 2, 20, []uint64{0, 1, 1})
+	dp.SetTimestamp(20)
+
+	assert.True(t, AddHistogram(acc, dp))
+	assert.EqualValues(t, 5, acc.Count())
+	assert.Equal(t, 42.0, acc.Sum())
+	assert.Equal(t, 0.5, acc.Min())
+	assert.Equal(t, 20.0, acc.Max())
+	assert.Equal(t, []uint64{1, 3, 1}, acc.BucketCounts().AsRaw())
+	assert.EqualValues(t, 20, acc.Timestamp())
+
+	noMin := newPoint(1, 1, 0, 0, []uint64{0, 1, 0})
+	noMin.RemoveMin()
+	assert.True(t, AddHistogram(acc, noMin))
+	assert.False(t, acc.HasMin())
+	assert.True(t, acc.HasMax())
+
+	other := newPoint(1, 1, 1, 1, []uint64{0, 0, 0, 1})
+	other.ExplicitBounds().FromRaw([]float64{1, 10, 100})
+	assert.False(t, AddHistogram(acc, other))
+	assert.EqualValues(t, 6, acc.Count())
+}
+
+func TestAddExponentialHistogram(t *testing.T) {
+	acc := pmetric.NewExponentialHistogramDataPoint()
+	acc.SetScale(1)
+	acc.SetCount(4)
+	acc.SetZeroCount(1)
+	acc.SetSum(10)
+	acc.Positive().SetOffset(1)
+	acc.Positive().BucketCounts().FromRaw([]uint64{1, 1, 1})
+
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.SetCount(3)
+	dp.SetSum(5)
+	dp.SetTimestamp(20)
+	dp.Positive().SetOffset(-1)
+	dp.Positive().BucketCounts().FromRaw([]uint64{1, 0, 1})
+	dp.Negative().SetOffset(2)
+	dp.Negative().BucketCounts().FromRaw([]uint64{1})
+	acc.Exemplars().AppendEmpty().SetDoubleValue(1)
+	dp.Exemplars().AppendEmpty().SetDoubleValue(2)
+
+	AddExponentialHistogram(acc, dp)
+	assert.EqualValues(t, 0, acc.Scale())
+	assert.EqualValues(t, 7, acc.Count())
+	assert.EqualValues(t, 1, acc.ZeroCount())
+	assert.Equal(t, 15.0, acc.Sum())
+	// acc buckets 1,2,3 at scale 1 become 0,1,1 at scale 0
+	assert.EqualValues(t, -1, acc.Positive().Offset())
+	assert.Equal(t, []uint64{1, 1, 3}, acc.Positive().BucketCounts().AsRaw())
+	assert.EqualValues(t, 2, acc.Negative().Offset())
+	assert.Equal(t, []uint64{1}, acc.Negative().BucketCounts().AsRaw())
+	assert.EqualValues(t, 20, acc.Timestamp())
+	// the exemplars are replaced by the ones of the latest point
+	require.Equal(t, 1, acc.Exemplars().Len())
+	assert.Equal(t, 2.0, acc.Exemplars().At(0).DoubleValue())
+
+	// the input point is left untouched
+	assert.EqualValues(t, 0, dp.Scale())
+	assert.EqualValues(t, -1, dp.Positive().Offset())
+	assert.Equal(t, 1, dp.Exemplars().Len())
+}
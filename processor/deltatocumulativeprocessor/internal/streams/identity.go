@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streams // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/streams"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+// Identity uniquely identifies a stream of data points. Two data points
+// belong to the same stream if they share the resource, the instrumentation
+// scope, the metric and the data point attributes.
+// Identity is comparable and can be used as a map key.
+type Identity struct {
+	Resource     [16]byte
+	ScopeName    string
+	ScopeVersion string
+	Scope        [16]byte
+
+	MetricName      string
+	MetricUnit      string
+	MetricType      pmetric.MetricType
+	MetricMonotonic bool
+
+	Attributes [16]byte
+}
+
+// OfMetric returns the part of the identity that is shared by all data
+// points of the given metric.
+func OfMetric(res pcommon.Resource, scope pcommon.InstrumentationScope, m pmetric.Metric) Identity {
+	id := Identity{
+		Resource:     pdatautil.MapHash(res.Attributes()),
+		ScopeName:    scope.Name(),
+		ScopeVersion: scope.Version(),
+		Scope:        pdatautil.MapHash(scope.Attributes()),
+		MetricName:   m.Name(),
+		MetricUnit:   m.Unit(),
+		MetricType:   m.Type(),
+	}
+	if m.Type() == pmetric.MetricTypeSum {
+		id.MetricMonotonic = m.Sum().IsMonotonic()
+	}
+	return id
+}
+
+// WithAttributes returns a copy of the metric identity extended with the
+// given data point attributes.
+func (id Identity) WithAttributes(attrs pcommon.Map) Identity {
+	id.Attributes = pdatautil.MapHash(attrs)
+	return id
+}
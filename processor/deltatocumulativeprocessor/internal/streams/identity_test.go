@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package streams
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestIdentity(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "svc")
+	scope := pcommon.NewInstrumentationScope()
+	scope.SetName("scope")
+	scope.SetVersion("1.0")
+
+	m := pmetric.NewMetric()
+	m.SetName("requests")
+	m.SetUnit("1")
+	m.SetEmptySum().SetIsMonotonic(true)
+
+	attrs := pcommon.NewMap()
+	attrs.PutStr("method", "GET")
+
+	base := OfMetric(res, scope, m)
+	assert.True(t, base.MetricMonotonic)
+	assert.Equal(t, pmetric.MetricTypeSum, base.MetricType)
+
+	// the same input always yields the same identity
+	assert.Equal(t, base.WithAttributes(attrs), OfMetric(res, scope, m).WithAttributes(attrs))
+
+	other := pcommon.NewMap()
+	other.PutStr("method", "POST")
+	assert.NotEqual(t, base.WithAttributes(attrs), base.WithAttributes(other))
+
+	otherRes := pcommon.NewResource()
+	otherRes.Attributes().PutStr("service.name", "other")
+	assert.NotEqual(t, base.WithAttributes(attrs), OfMetric(otherRes, scope, m).WithAttributes(attrs))
+
+	hist := pmetric.NewMetric()
+	hist.SetName("requests")
+	hist.SetUnit("1")
+	hist.SetEmptyHistogram()
+	assert.NotEqual(t, base, OfMetric(res, scope, hist))
+}
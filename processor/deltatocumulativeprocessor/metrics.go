@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/metadata"
+)
+
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+	reasonKey = "reason"
+
+	// reasonOutOfOrder is used for points older than the last accumulated point of their stream.
+	reasonOutOfOrder = "out_of_order"
+	// reasonDuplicate is used for points that have already been accumulated.
+	reasonDuplicate = "duplicate"
+	// reasonStreamLimit is used for points of new streams once max_streams is reached.
+	reasonStreamLimit = "stream_limit"
+	// reasonNaN is used for sum points with a NaN value, which cannot be accumulated.
+	reasonNaN = "nan"
+)
+
+type telemetry struct {
+	droppedDataPoints metric.Int64Counter
+}
+
+func newTelemetry(set component.TelemetrySettings, streams func() int64) (*telemetry, error) {
+	meter := set.MeterProvider.Meter(scopeName)
+
+	dropped, err := meter.Int64Counter(
+		metadata.Type+"_datapoints_dropped",
+		metric.WithDescription("Number of data points dropped instead of being accumulated."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = meter.Int64ObservableGauge(
+		metadata.Type+"_streams_tracked",
+		metric.WithDescription("Number of streams currently tracked by the processor."),
+		metric.WithInt64Callback(func(_ context.Context, o metric.Int64Observer) error {
+			o.Observe(streams())
+			return nil
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &telemetry{droppedDataPoints: dropped}, nil
+}
+
+func (t *telemetry) dropped(ctx context.Context, reason string) {
+	t.droppedDataPoints.Add(ctx, 1, metric.WithAttributes(attribute.String(reasonKey, reason)))
+}
@@ -0,0 +1,227 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor"
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/data"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/deltatocumulativeprocessor/internal/streams"
+)
+
+// dataPoint is implemented by the data point types the processor accumulates.
+type dataPoint[P any] interface {
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+	Flags() pmetric.DataPointFlags
+	CopyTo(P)
+}
+
+// stream holds the cumulative state of a single stream.
+type stream struct {
+	// point is a pmetric.NumberDataPoint, pmetric.HistogramDataPoint or
+	// pmetric.ExponentialHistogramDataPoint, depending on the metric type
+	// of the stream identity.
+	point    any
+	lastSeen time.Time
+}
+
+type deltaToCumulativeProcessor struct {
+	logger     *zap.Logger
+	telemetry  *telemetry
+	maxStale   time.Duration
+	maxStreams int
+
+	mu      sync.Mutex
+	tracked map[streams.Identity]*stream
+
+	cancelFunc context.CancelFunc
+}
+
+func newDeltaToCumulativeProcessor(config *Config, set component.TelemetrySettings) (*deltaToCumulativeProcessor, error) {
+	p := &deltaToCumulativeProcessor{
+		logger:     set.Logger,
+		maxStale:   config.MaxStale,
+		maxStreams: config.MaxStreams,
+		tracked:    make(map[streams.Identity]*stream),
+		cancelFunc: func() {},
+	}
+	tel, err := newTelemetry(set, p.numStreams)
+	if err != nil {
+		return nil, err
+	}
+	p.telemetry = tel
+	return p, nil
+}
+
+func (p *deltaToCumulativeProcessor) start(context.Context, component.Host) error {
+	if p.maxStale > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		p.cancelFunc = cancel
+		go p.sweeper(ctx)
+	}
+	return nil
+}
+
+func (p *deltaToCumulativeProcessor) shutdown(context.Context) error {
+	p.cancelFunc()
+	return nil
+}
+
+// processMetrics implements the ProcessMetricsFunc type.
+func (p *deltaToCumulativeProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				id := streams.OfMetric(rm.Resource(), sm.Scope(), m)
+				switch m.Type() {
+				case pmetric.MetricTypeSum:
+					ms := m.Sum()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					ms.DataPoints().RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+						// NaN values cannot be accumulated, nor be forwarded as cumulative values
+						if dp.ValueType() == pmetric.NumberDataPointValueTypeDouble && math.IsNaN(dp.DoubleValue()) {
+							p.telemetry.dropped(ctx, reasonNaN)
+							return true
+						}
+						return accumulate(ctx, p, id.WithAttributes(dp.Attributes()), dp, pmetric.NewNumberDataPoint, func(acc, dp pmetric.NumberDataPoint) bool {
+							data.AddNumber(acc, dp)
+							return true
+						})
+					})
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeHistogram:
+					ms := m.Histogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					ms.DataPoints().RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+						return accumulate(ctx, p, id.WithAttributes(dp.Attributes()), dp, pmetric.NewHistogramDataPoint, data.AddHistogram)
+					})
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityDelta {
+						return false
+					}
+					ms.DataPoints().RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+						return accumulate(ctx, p, id.WithAttributes(dp.Attributes()), dp, pmetric.NewExponentialHistogramDataPoint, func(acc, dp pmetric.ExponentialHistogramDataPoint) bool {
+							data.AddExponentialHistogram(acc, dp)
+							return true
+						})
+					})
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeEmpty, pmetric.MetricTypeGauge, pmetric.MetricTypeSummary:
+					fallthrough
+				default:
+					return false
+				}
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return md, nil
+}
+
+// accumulate adds the delta point dp to the state of its stream and replaces
+// the contents of dp with the cumulative result. add merges a delta point into
+// the cumulative state and reports false if the two are incompatible, in which
+// case the stream is restarted from dp.
+// accumulate returns true if dp has to be dropped.
+func accumulate[P dataPoint[P]](ctx context.Context, p *deltaToCumulativeProcessor, id streams.Identity, dp P, newPoint func() P, add func(acc, dp P) bool) bool {
+	if dp.Flags().NoRecordedValue() {
+		// nothing to accumulate, pass the point through
+		return false
+	}
+
+	now := time.Now()
+	s, ok := p.tracked[id]
+	if !ok {
+		if p.maxStreams > 0 && len(p.tracked) >= p.maxStreams {
+			p.telemetry.dropped(ctx, reasonStreamLimit)
+			return true
+		}
+		// the first delta of a stream is also its first cumulative value
+		acc := newPoint()
+		dp.CopyTo(acc)
+		p.tracked[id] = &stream{point: acc, lastSeen: now}
+		return false
+	}
+
+	acc := s.point.(P)
+	if reason := checkOrder(acc, dp); reason != "" {
+		p.logger.Debug("dropping data point", zap.String(reasonKey, reason), zap.String("metric", id.MetricName))
+		p.telemetry.dropped(ctx, reason)
+		return true
+	}
+	if !add(acc, dp) {
+		p.logger.Debug("restarting stream with incompatible data point", zap.String("metric", id.MetricName))
+		dp.CopyTo(acc)
+	}
+	s.lastSeen = now
+	acc.CopyTo(dp)
+	return false
+}
+
+// checkOrder returns the reason for dropping dp if it must not be added to
+// the cumulative point acc, or an empty string otherwise.
+func checkOrder[P dataPoint[P]](acc, dp P) string {
+	switch {
+	case dp.Timestamp() == acc.Timestamp():
+		return reasonDuplicate
+	case dp.Timestamp() < acc.Timestamp():
+		return reasonOutOfOrder
+	case dp.StartTimestamp() != 0 && dp.StartTimestamp() < acc.Timestamp():
+		// the point overlaps with the time window that was already accumulated
+		return reasonOutOfOrder
+	}
+	return ""
+}
+
+func (p *deltaToCumulativeProcessor) numStreams() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return int64(len(p.tracked))
+}
+
+func (p *deltaToCumulativeProcessor) removeStale(staleBefore time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for id, s := range p.tracked {
+		if s.lastSeen.Before(staleBefore) {
+			p.logger.Debug("removing stale stream", zap.String("metric", id.MetricName))
+			delete(p.tracked, id)
+		}
+	}
+}
+
+func (p *deltaToCumulativeProcessor) sweeper(ctx context.Context) {
+	ticker := time.NewTicker(p.maxStale)
+	for {
+		select {
+		case currentTime := <-ticker.C:
+			p.removeStale(currentTime.Add(-p.maxStale))
+		case <-ctx.Done():
+			ticker.Stop()
+			return
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package deltatocumulativeprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type sumPoint struct {
+	attr        string
+	start, ts   int64
+	value       int64
+	wantValue   int64
+	wantStart   int64
+	wantDropped bool
+}
+
+func newSum(temporality pmetric.AggregationTemporality, points ...sumPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(temporality)
+	for _, p := range points {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("attr", p.attr)
+		dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+		dp.SetTimestamp(pcommon.Timestamp(p.ts))
+		dp.SetIntValue(p.value)
+	}
+	return md
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) *deltaToCumulativeProcessor {
+	p, err := newDeltaToCumulativeProcessor(cfg, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return p
+}
+
+func TestAccumulateSum(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxStreams int
+		batches    [][]sumPoint
+	}{
+		{
+			name: "in order",
+			batches: [][]sumPoint{
+				{{attr: "a", start: 10, ts: 20, value: 3, wantStart: 10, wantValue: 3}},
+				{{attr: "a", start: 20, ts: 30, value: 4, wantStart: 10, wantValue: 7}},
+				{{attr: "a", start: 30, ts: 40, value: 1, wantStart: 10, wantValue: 8}},
+			},
+		},
+		{
+			name: "independent streams",
+			batches: [][]sumPoint{
+				{
+					{attr: "a", start: 10, ts: 20, value: 3, wantStart: 10, wantValue: 3},
+					{attr: "b", start: 10, ts: 20, value: 5, wantStart: 10, wantValue: 5},
+				},
+				{
+					{attr: "a", start: 20, ts: 30, value: 1, wantStart: 10, wantValue: 4},
+					{attr: "b", start: 20, ts: 30, value: 1, wantStart: 10, wantValue: 6},
+				},
+			},
+		},
+		{
+			name: "duplicate",
+			batches: [][]sumPoint{
+				{{attr: "a", start: 10, ts: 20, value: 3, wantStart: 10, wantValue: 3}},
+				{{attr: "a", start: 10, ts: 20, value: 3, wantDropped: true}},
+				{{attr: "a", start: 20, ts: 30, value: 1, wantStart: 10, wantValue: 4}},
+			},
+		},
+		{
+			name: "out of order",
+			batches: [][]sumPoint{
+				{{attr: "a", start: 20, ts: 30, value: 3, wantStart: 20, wantValue: 3}},
+				{{attr: "a", start: 10, ts: 20, value: 1, wantDropped: true}},
+				{{attr: "a", start: 25, ts: 40, value: 1, wantDropped: true}},
+				{{attr: "a", start: 30, ts: 40, value: 2, wantStart: 20, wantValue: 5}},
+			},
+		},
+		{
+			name:       "stream limit",
+			maxStreams: 1,
+			batches: [][]sumPoint{
+				{
+					{attr: "a", start: 10, ts: 20, value: 3, wantStart: 10, wantValue: 3},
+					{attr: "b", start: 10, ts: 20, value: 5, wantDropped: true},
+				},
+				{
+					{attr: "a", start: 20, ts: 30, value: 1, wantStart: 10, wantValue: 4},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProcessor(t, &Config{MaxStreams: tt.maxStreams})
+			for _, batch := range tt.batches {
+				md, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, batch...))
+				require.NoError(t, err)
+
+				var want []sumPoint
+				for _, point := range batch {
+					if !point.wantDropped {
+						want = append(want, point)
+					}
+				}
+				if len(want) == 0 {
+					assert.Equal(t, 0, md.ResourceMetrics().Len())
+					continue
+				}
+
+				sum := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum()
+				assert.Equal(t, pmetric.AggregationTemporalityCumulative, sum.AggregationTemporality())
+				require.Equal(t, len(want), sum.DataPoints().Len())
+				for i, point := range want {
+					dp := sum.DataPoints().At(i)
+					attr, _ := dp.Attributes().Get("attr")
+					assert.Equal(t, point.attr, attr.Str())
+					assert.EqualValues(t, point.wantStart, dp.StartTimestamp())
+					assert.EqualValues(t, point.ts, dp.Timestamp())
+					assert.Equal(t, point.wantValue, dp.IntValue())
+				}
+			}
+		})
+	}
+}
+
+func TestDropNaNSum(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+	_, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, sumPoint{attr: "a", start: 10, ts: 20, value: 3}))
+	require.NoError(t, err)
+
+	in := newSum(pmetric.AggregationTemporalityDelta, sumPoint{attr: "a", start: 20, ts: 30})
+	in.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetDoubleValue(math.NaN())
+	md, err := p.processMetrics(context.Background(), in)
+	require.NoError(t, err)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	// the stream goes on from the last accumulated value
+	md, err = p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta, sumPoint{attr: "a", start: 30, ts: 40, value: 2}))
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+}
+
+func TestCumulativeUntouched(t *testing.T) {
+	p := newTestProcessor(t, &Config{})
+	in := newSum(pmetric.AggregationTemporalityCumulative, sumPoint{attr: "a", start: 10, ts: 20, value: 3})
+	expected := pmetric.NewMetrics()
+	in.CopyTo(expected)
+
+	md, err := p.processMetrics(context.Background(), in)
+	require.NoError(t, err)
+	assert.Equal(t, expected, md)
+	assert.EqualValues(t, 0, p.numStreams())
+}
+
+func TestAccumulateHistogram(t *testing.T) {
+	newHistogram := func(start, ts int64, bounds []float64, buckets []uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		h := m.SetEmptyHistogram()
+		h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := h.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(start))
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.ExplicitBounds().FromRaw(bounds)
+		dp.BucketCounts().FromRaw(buckets)
+		var count uint64
+		for _, b := range buckets {
+			count += b
+		}
+		dp.SetCount(count)
+		return md
+	}
+	point := func(md pmetric.Metrics) pmetric.HistogramDataPoint {
+		h := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram()
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, h.AggregationTemporality())
+		return h.DataPoints().At(0)
+	}
+
+	p := newTestProcessor(t, &Config{})
+
+	md, err := p.processMetrics(context.Background(), newHistogram(10, 20, []float64{1, 10}, []uint64{1, 2, 3}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, point(md).BucketCounts().AsRaw())
+
+	md, err = p.processMetrics(context.Background(), newHistogram(20, 30, []float64{1, 10}, []uint64{1, 0, 1}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2, 2, 4}, point(md).BucketCounts().AsRaw())
+	assert.EqualValues(t, 8, point(md).Count())
+	assert.EqualValues(t, 10, point(md).StartTimestamp())
+
+	// changed bucket boundaries restart the stream
+	md, err = p.processMetrics(context.Background(), newHistogram(30, 40, []float64{5}, []uint64{1, 1}))
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 1}, point(md).BucketCounts().AsRaw())
+	assert.EqualValues(t, 30, point(md).StartTimestamp())
+}
+
+func TestAccumulateExponentialHistogram(t *testing.T) {
+	newExpHistogram := func(start, ts int64, scale int32, offset int32, buckets []uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("latency")
+		h := m.SetEmptyExponentialHistogram()
+		h.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		dp := h.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(start))
+		dp.SetTimestamp(pcommon.Timestamp(ts))
+		dp.SetScale(scale)
+		dp.Positive().SetOffset(offset)
+		dp.Positive().BucketCounts().FromRaw(buckets)
+		return md
+	}
+
+	p := newTestProcessor(t, &Config{})
+
+	_, err := p.processMetrics(context.Background(), newExpHistogram(10, 20, 2, 0, []uint64{1, 1, 1, 1}))
+	require.NoError(t, err)
+	md, err := p.processMetrics(context.Background(), newExpHistogram(20, 30, 1, 0, []uint64{1, 1}))
+	require.NoError(t, err)
+
+	h := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, h.AggregationTemporality())
+	dp := h.DataPoints().At(0)
+	assert.EqualValues(t, 1, dp.Scale())
+	assert.EqualValues(t, 0, dp.Positive().Offset())
+	assert.Equal(t, []uint64{3, 3}, dp.Positive().BucketCounts().AsRaw())
+	assert.EqualValues(t, 10, dp.StartTimestamp())
+}
+
+func TestRemoveStale(t *testing.T) {
+	p := newTestProcessor(t, &Config{MaxStale: time.Minute})
+
+	_, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta,
+		sumPoint{attr: "a", start: 10, ts: 20, value: 3},
+		sumPoint{attr: "b", start: 10, ts: 20, value: 3},
+	))
+	require.NoError(t, err)
+	require.EqualValues(t, 2, p.numStreams())
+
+	for _, s := range p.tracked {
+		s.lastSeen = s.lastSeen.Add(-time.Hour)
+		break
+	}
+	p.removeStale(time.Now().Add(-time.Minute))
+	assert.EqualValues(t, 1, p.numStreams())
+
+	p.removeStale(time.Now().Add(time.Minute))
+	assert.EqualValues(t, 0, p.numStreams())
+
+	// a removed stream starts over
+	md, err := p.processMetrics(context.Background(), newSum(pmetric.AggregationTemporalityDelta,
+		sumPoint{attr: "a", start: 20, ts: 30, value: 1},
+	))
+	require.NoError(t, err)
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	assert.EqualValues(t, 20, dp.StartTimestamp())
+	assert.EqualValues(t, 1, dp.IntValue())
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// dataPoint is implemented by the data point types the processor aggregates.
+type dataPoint[P any] interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	SetStartTimestamp(pcommon.Timestamp)
+	Timestamp() pcommon.Timestamp
+	SetTimestamp(pcommon.Timestamp)
+	CopyTo(P)
+}
+
+// cumulativePoint is the last point seen for a cumulative input stream.
+type cumulativePoint[P any] struct {
+	// key identifies the aggregated stream the input stream is reported as.
+	key   streamKey
+	point P
+	// lastSeen is when a point of the input stream was last received.
+	lastSeen time.Time
+}
+
+// aggregator aggregates the data points of a single data point type.
+type aggregator[P dataPoint[P]] struct {
+	newPoint    func() P
+	mergeValues func(acc, dp P) bool
+	appendEmpty func(pmetric.Metric) P
+
+	points map[streamKey]P
+	// cumulatives holds the last point of every cumulative input stream, across
+	// intervals. Input streams only differing in dropped attributes are only summed
+	// up when the interval is flushed, as summing every point would count the same
+	// cumulative value multiple times. An input stream missing an interval is still
+	// added with its last point, otherwise the sum would decrease, which looks like
+	// a reset to the consumers of the cumulative stream.
+	cumulatives map[streamKey]*cumulativePoint[P]
+	// order keeps the cumulative input streams in the order they were first seen.
+	order []streamKey
+	// updated holds the aggregated cumulative streams with an input stream updated
+	// in the current interval, the other ones aren't reported again.
+	updated map[streamKey]struct{}
+}
+
+func newAggregator[P dataPoint[P]](newPoint func() P, mergeValues func(acc, dp P) bool, appendEmpty func(pmetric.Metric) P) *aggregator[P] {
+	a := &aggregator[P]{
+		newPoint:    newPoint,
+		mergeValues: mergeValues,
+		appendEmpty: appendEmpty,
+		cumulatives: make(map[streamKey]*cumulativePoint[P]),
+	}
+	a.reset()
+	return a
+}
+
+// reset starts a new interval, the state of the cumulative input streams is kept.
+func (a *aggregator[P]) reset() {
+	a.points = make(map[streamKey]P)
+	a.updated = make(map[streamKey]struct{})
+}
+
+// last keeps the most recent point of the stream.
+func (a *aggregator[P]) last(key streamKey, m pmetric.Metric, dp P, attrs pcommon.Map) {
+	acc, ok := a.points[key]
+	if !ok {
+		a.add(key, m, dp, attrs)
+		return
+	}
+	if dp.Timestamp() >= acc.Timestamp() {
+		dp.CopyTo(acc)
+		attrs.CopyTo(acc.Attributes())
+	}
+}
+
+// merge adds the value of dp to the value of the stream.
+func (a *aggregator[P]) merge(key streamKey, m pmetric.Metric, dp P, attrs pcommon.Map) {
+	acc, ok := a.points[key]
+	if !ok {
+		a.add(key, m, dp, attrs)
+		return
+	}
+	if !a.mergeValues(acc, dp) {
+		// the layout of the points changed, keep the most recent one
+		if dp.Timestamp() >= acc.Timestamp() {
+			dp.CopyTo(acc)
+			attrs.CopyTo(acc.Attributes())
+		}
+		return
+	}
+	widenTimestamps(acc, dp)
+}
+
+// cumulative records dp as the last point of the input stream identified by
+// inKey, which is reported as part of the aggregated stream identified by key.
+func (a *aggregator[P]) cumulative(inKey, key streamKey, dp P, attrs pcommon.Map, now time.Time) {
+	c, ok := a.cumulatives[inKey]
+	if ok && dp.Timestamp() < c.point.Timestamp() {
+		return
+	}
+	if !ok {
+		c = &cumulativePoint[P]{key: key, point: a.newPoint()}
+		a.cumulatives[inKey] = c
+		a.order = append(a.order, inKey)
+	}
+	dp.CopyTo(c.point)
+	attrs.CopyTo(c.point.Attributes())
+	c.lastSeen = now
+	a.updated[key] = struct{}{}
+}
+
+// flush adds the last points of the cumulative input streams to their aggregated
+// streams updated in the interval, with metricFor returning the metric of an
+// aggregated stream. The input streams not seen for longer than maxStale are removed.
+func (a *aggregator[P]) flush(now time.Time, maxStale time.Duration, metricFor func(metricKey) pmetric.Metric) {
+	kept := a.order[:0]
+	for _, inKey := range a.order {
+		c := a.cumulatives[inKey]
+		if now.Sub(c.lastSeen) > maxStale {
+			delete(a.cumulatives, inKey)
+			continue
+		}
+		kept = append(kept, inKey)
+		if _, ok := a.updated[c.key]; ok {
+			a.merge(c.key, metricFor(c.key.metric), c.point, c.point.Attributes())
+		}
+	}
+	a.order = kept
+}
+
+func (a *aggregator[P]) add(key streamKey, m pmetric.Metric, dp P, attrs pcommon.Map) {
+	acc := a.appendEmpty(m)
+	dp.CopyTo(acc)
+	attrs.CopyTo(acc.Attributes())
+	a.points[key] = acc
+}
+
+// widenTimestamps extends the time window of acc to include the one of dp.
+func widenTimestamps[P dataPoint[P]](acc, dp P) {
+	if dp.StartTimestamp() != 0 && (acc.StartTimestamp() == 0 || dp.StartTimestamp() < acc.StartTimestamp()) {
+		acc.SetStartTimestamp(dp.StartTimestamp())
+	}
+	if dp.Timestamp() > acc.Timestamp() {
+		acc.SetTimestamp(dp.Timestamp())
+	}
+}
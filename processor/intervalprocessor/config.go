@@ -0,0 +1,40 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// Interval is the time interval at which the processor emits the aggregated metrics.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// DropAttributes is a list of data point attribute keys that are removed before aggregation.
+	// Streams that only differ in these attributes are aggregated into a single stream.
+	DropAttributes []string `mapstructure:"drop_attributes"`
+
+	// MaxStale is the duration after which the last point of a cumulative input stream is
+	// forgotten when no new point is received. Until then, it's still added to the aggregated
+	// stream it's part of when its attributes are dropped.
+	MaxStale time.Duration `mapstructure:"max_stale"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks whether the input configuration has all of the required fields for the processor.
+// An error is returned if there are any invalid inputs.
+func (config *Config) Validate() error {
+	if config.Interval <= 0 {
+		return errors.New("interval must be greater than 0")
+	}
+	if config.MaxStale < config.Interval {
+		return errors.New("max_stale must be greater than or equal to interval")
+	}
+	return nil
+}
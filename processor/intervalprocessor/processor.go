@@ -0,0 +1,389 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/intervalprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/exp/metrics/merge"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatautil"
+)
+
+var _ processor.Metrics = (*intervalProcessor)(nil)
+
+type scopeKey struct {
+	resource   [16]byte
+	name       string
+	version    string
+	attributes [16]byte
+}
+
+type metricKey struct {
+	scope       scopeKey
+	name        string
+	unit        string
+	typ         pmetric.MetricType
+	temporality pmetric.AggregationTemporality
+	monotonic   bool
+}
+
+type streamKey struct {
+	metric     metricKey
+	attributes [16]byte
+}
+
+// cumulativeMetric holds what's needed to report a cumulative metric again in the
+// next intervals: its resource, scope and metric, without data points.
+type cumulativeMetric struct {
+	resource pcommon.Resource
+	scope    pmetric.ScopeMetrics
+	metric   pmetric.Metric
+	lastSeen time.Time
+}
+
+type intervalProcessor struct {
+	logger         *zap.Logger
+	nextConsumer   consumer.Metrics
+	interval       time.Duration
+	dropAttributes []string
+	maxStale       time.Duration
+	now            func() time.Time
+
+	mu        sync.Mutex
+	md        pmetric.Metrics
+	resources map[[16]byte]pmetric.ResourceMetrics
+	scopes    map[scopeKey]pmetric.ScopeMetrics
+	metrics   map[metricKey]pmetric.Metric
+	// cumulativeMetrics holds the cumulative metrics, across intervals.
+	cumulativeMetrics map[metricKey]*cumulativeMetric
+
+	numbers       *aggregator[pmetric.NumberDataPoint]
+	histograms    *aggregator[pmetric.HistogramDataPoint]
+	expHistograms *aggregator[pmetric.ExponentialHistogramDataPoint]
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newIntervalProcessor(config *Config, logger *zap.Logger, nextConsumer consumer.Metrics) *intervalProcessor {
+	p := &intervalProcessor{
+		logger:         logger,
+		nextConsumer:   nextConsumer,
+		interval:       config.Interval,
+		dropAttributes: config.DropAttributes,
+		maxStale:       config.MaxStale,
+		now:            time.Now,
+		cancel:         func() {},
+
+		cumulativeMetrics: make(map[metricKey]*cumulativeMetric),
+		numbers: newAggregator(pmetric.NewNumberDataPoint, merge.Number, func(m pmetric.Metric) pmetric.NumberDataPoint {
+			if m.Type() == pmetric.MetricTypeGauge {
+				return m.Gauge().DataPoints().AppendEmpty()
+			}
+			return m.Sum().DataPoints().AppendEmpty()
+		}),
+		histograms: newAggregator(pmetric.NewHistogramDataPoint, merge.Histogram, func(m pmetric.Metric) pmetric.HistogramDataPoint {
+			return m.Histogram().DataPoints().AppendEmpty()
+		}),
+		expHistograms: newAggregator(pmetric.NewExponentialHistogramDataPoint, merge.ExponentialHistogram, func(m pmetric.Metric) pmetric.ExponentialHistogramDataPoint {
+			return m.ExponentialHistogram().DataPoints().AppendEmpty()
+		}),
+	}
+	p.reset()
+	return p
+}
+
+func (p *intervalProcessor) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	go func() {
+		defer close(p.done)
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.export(ctx); err != nil {
+					p.logger.Error("failed to export aggregated metrics", zap.Error(err))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the export loop and emits what has been aggregated so far.
+func (p *intervalProcessor) Shutdown(ctx context.Context) error {
+	p.cancel()
+	if p.done != nil {
+		<-p.done
+	}
+	return p.export(ctx)
+}
+
+func (p *intervalProcessor) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeMetrics aggregates the incoming metrics until the next export.
+// Summaries can't be aggregated and are passed through right away.
+func (p *intervalProcessor) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	passThrough := pmetric.NewMetrics()
+
+	p.mu.Lock()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		var rmPassThrough pmetric.ResourceMetrics
+		hasResource := false
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			var smPassThrough pmetric.ScopeMetrics
+			hasScope := false
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				switch m.Type() {
+				case pmetric.MetricTypeGauge, pmetric.MetricTypeSum, pmetric.MetricTypeHistogram, pmetric.MetricTypeExponentialHistogram:
+					p.aggregateMetric(rm.Resource(), sm, m)
+				case pmetric.MetricTypeSummary, pmetric.MetricTypeEmpty:
+					if !hasScope {
+						if !hasResource {
+							rmPassThrough = passThrough.ResourceMetrics().AppendEmpty()
+							rm.Resource().CopyTo(rmPassThrough.Resource())
+							rmPassThrough.SetSchemaUrl(rm.SchemaUrl())
+							hasResource = true
+						}
+						smPassThrough = rmPassThrough.ScopeMetrics().AppendEmpty()
+						sm.Scope().CopyTo(smPassThrough.Scope())
+						smPassThrough.SetSchemaUrl(sm.SchemaUrl())
+						hasScope = true
+					}
+					m.CopyTo(smPassThrough.Metrics().AppendEmpty())
+				}
+			}
+		}
+	}
+	p.mu.Unlock()
+
+	if passThrough.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, passThrough)
+}
+
+func (p *intervalProcessor) aggregateMetric(res pcommon.Resource, sm pmetric.ScopeMetrics, m pmetric.Metric) {
+	out, mKey := p.metricFor(res, sm, m)
+	now := p.now()
+	if mKey.temporality == pmetric.AggregationTemporalityCumulative {
+		p.rememberCumulative(mKey, res, sm, m, now)
+	}
+
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			attrs, key := p.streamAttributes(mKey, dp.Attributes())
+			p.numbers.last(key, out, dp, attrs)
+		}
+	case pmetric.MetricTypeSum:
+		cumulative := m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			attrs, key := p.streamAttributes(mKey, dp.Attributes())
+			if cumulative {
+				p.numbers.cumulative(streamKey{metric: mKey, attributes: pdatautil.MapHash(dp.Attributes())}, key, dp, attrs, now)
+			} else {
+				p.numbers.merge(key, out, dp, attrs)
+			}
+		}
+	case pmetric.MetricTypeHistogram:
+		cumulative := m.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			attrs, key := p.streamAttributes(mKey, dp.Attributes())
+			if cumulative {
+				p.histograms.cumulative(streamKey{metric: mKey, attributes: pdatautil.MapHash(dp.Attributes())}, key, dp, attrs, now)
+			} else {
+				p.histograms.merge(key, out, dp, attrs)
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		cumulative := m.ExponentialHistogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			attrs, key := p.streamAttributes(mKey, dp.Attributes())
+			if cumulative {
+				p.expHistograms.cumulative(streamKey{metric: mKey, attributes: pdatautil.MapHash(dp.Attributes())}, key, dp, attrs, now)
+			} else {
+				p.expHistograms.merge(key, out, dp, attrs)
+			}
+		}
+	case pmetric.MetricTypeSummary, pmetric.MetricTypeEmpty:
+	}
+}
+
+// rememberCumulative records the resource, scope and metric of a cumulative metric,
+// so that it can be reported again in the next intervals.
+func (p *intervalProcessor) rememberCumulative(mKey metricKey, res pcommon.Resource, sm pmetric.ScopeMetrics, m pmetric.Metric, now time.Time) {
+	cm, ok := p.cumulativeMetrics[mKey]
+	if !ok {
+		cm = &cumulativeMetric{
+			resource: pcommon.NewResource(),
+			scope:    pmetric.NewScopeMetrics(),
+			metric:   pmetric.NewMetric(),
+		}
+		res.CopyTo(cm.resource)
+		sm.Scope().CopyTo(cm.scope.Scope())
+		cm.scope.SetSchemaUrl(sm.SchemaUrl())
+		cm.metric.SetName(m.Name())
+		cm.metric.SetDescription(m.Description())
+		cm.metric.SetUnit(m.Unit())
+		switch m.Type() {
+		case pmetric.MetricTypeSum:
+			cm.metric.SetEmptySum().SetAggregationTemporality(mKey.temporality)
+			cm.metric.Sum().SetIsMonotonic(mKey.monotonic)
+		case pmetric.MetricTypeHistogram:
+			cm.metric.SetEmptyHistogram().SetAggregationTemporality(mKey.temporality)
+		case pmetric.MetricTypeExponentialHistogram:
+			cm.metric.SetEmptyExponentialHistogram().SetAggregationTemporality(mKey.temporality)
+		case pmetric.MetricTypeGauge, pmetric.MetricTypeSummary, pmetric.MetricTypeEmpty:
+		}
+		p.cumulativeMetrics[mKey] = cm
+	}
+	cm.lastSeen = now
+}
+
+// cumulativeMetricFor returns the aggregated metric of a cumulative metric of a previous interval.
+func (p *intervalProcessor) cumulativeMetricFor(mKey metricKey) pmetric.Metric {
+	cm := p.cumulativeMetrics[mKey]
+	out, _ := p.metricFor(cm.resource, cm.scope, cm.metric)
+	return out
+}
+
+// metricFor returns the aggregated metric the data points of m are added to,
+// creating it and its resource and scope if needed.
+func (p *intervalProcessor) metricFor(res pcommon.Resource, sm pmetric.ScopeMetrics, m pmetric.Metric) (pmetric.Metric, metricKey) {
+	resKey := pdatautil.MapHash(res.Attributes())
+	rm, ok := p.resources[resKey]
+	if !ok {
+		rm = p.md.ResourceMetrics().AppendEmpty()
+		res.CopyTo(rm.Resource())
+		p.resources[resKey] = rm
+	}
+
+	sKey := scopeKey{
+		resource:   resKey,
+		name:       sm.Scope().Name(),
+		version:    sm.Scope().Version(),
+		attributes: pdatautil.MapHash(sm.Scope().Attributes()),
+	}
+	outScope, ok := p.scopes[sKey]
+	if !ok {
+		outScope = rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().CopyTo(outScope.Scope())
+		outScope.SetSchemaUrl(sm.SchemaUrl())
+		p.scopes[sKey] = outScope
+	}
+
+	mKey := metricKey{
+		scope: sKey,
+		name:  m.Name(),
+		unit:  m.Unit(),
+		typ:   m.Type(),
+	}
+	switch m.Type() {
+	case pmetric.MetricTypeSum:
+		mKey.temporality = m.Sum().AggregationTemporality()
+		mKey.monotonic = m.Sum().IsMonotonic()
+	case pmetric.MetricTypeHistogram:
+		mKey.temporality = m.Histogram().AggregationTemporality()
+	case pmetric.MetricTypeExponentialHistogram:
+		mKey.temporality = m.ExponentialHistogram().AggregationTemporality()
+	case pmetric.MetricTypeGauge, pmetric.MetricTypeSummary, pmetric.MetricTypeEmpty:
+	}
+	out, ok := p.metrics[mKey]
+	if !ok {
+		out = outScope.Metrics().AppendEmpty()
+		out.SetName(m.Name())
+		out.SetDescription(m.Description())
+		out.SetUnit(m.Unit())
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			out.SetEmptyGauge()
+		case pmetric.MetricTypeSum:
+			out.SetEmptySum().SetAggregationTemporality(mKey.temporality)
+			out.Sum().SetIsMonotonic(mKey.monotonic)
+		case pmetric.MetricTypeHistogram:
+			out.SetEmptyHistogram().SetAggregationTemporality(mKey.temporality)
+		case pmetric.MetricTypeExponentialHistogram:
+			out.SetEmptyExponentialHistogram().SetAggregationTemporality(mKey.temporality)
+		case pmetric.MetricTypeSummary, pmetric.MetricTypeEmpty:
+		}
+		p.metrics[mKey] = out
+	}
+	return out, mKey
+}
+
+// streamAttributes returns the attributes of the aggregated stream a data
+// point with the given attributes belongs to, and the key of that stream.
+func (p *intervalProcessor) streamAttributes(mKey metricKey, attrs pcommon.Map) (pcommon.Map, streamKey) {
+	if len(p.dropAttributes) == 0 {
+		return attrs, streamKey{metric: mKey, attributes: pdatautil.MapHash(attrs)}
+	}
+	reduced := pcommon.NewMap()
+	attrs.CopyTo(reduced)
+	for _, k := range p.dropAttributes {
+		reduced.Remove(k)
+	}
+	return reduced, streamKey{metric: mKey, attributes: pdatautil.MapHash(reduced)}
+}
+
+// export sends everything aggregated since the previous export to the next
+// consumer and starts a new interval.
+func (p *intervalProcessor) export(ctx context.Context) error {
+	p.mu.Lock()
+	now := p.now()
+	p.numbers.flush(now, p.maxStale, p.cumulativeMetricFor)
+	p.histograms.flush(now, p.maxStale, p.cumulativeMetricFor)
+	p.expHistograms.flush(now, p.maxStale, p.cumulativeMetricFor)
+	for mKey, cm := range p.cumulativeMetrics {
+		if now.Sub(cm.lastSeen) > p.maxStale {
+			delete(p.cumulativeMetrics, mKey)
+		}
+	}
+	md := p.md
+	p.reset()
+	p.mu.Unlock()
+
+	if md.ResourceMetrics().Len() == 0 {
+		return nil
+	}
+	return p.nextConsumer.ConsumeMetrics(ctx, md)
+}
+
+func (p *intervalProcessor) reset() {
+	p.md = pmetric.NewMetrics()
+	p.resources = make(map[[16]byte]pmetric.ResourceMetrics)
+	p.scopes = make(map[scopeKey]pmetric.ScopeMetrics)
+	p.metrics = make(map[metricKey]pmetric.Metric)
+	p.numbers.reset()
+	p.histograms.reset()
+	p.expHistograms.reset()
+}
@@ -0,0 +1,291 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package intervalprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type point struct {
+	attrs     map[string]any
+	start, ts int64
+	value     int64
+}
+
+func newMetrics(name string, typ pmetric.MetricType, temporality pmetric.AggregationTemporality, points ...point) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "svc")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+
+	var dps pmetric.NumberDataPointSlice
+	switch typ {
+	case pmetric.MetricTypeGauge:
+		dps = m.SetEmptyGauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(temporality)
+		dps = sum.DataPoints()
+	case pmetric.MetricTypeHistogram:
+		h := m.SetEmptyHistogram()
+		h.SetAggregationTemporality(temporality)
+		for _, p := range points {
+			dp := h.DataPoints().AppendEmpty()
+			_ = dp.Attributes().FromRaw(p.attrs)
+			dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+			dp.SetTimestamp(pcommon.Timestamp(p.ts))
+			dp.SetCount(uint64(p.value))
+			dp.SetSum(float64(p.value))
+			dp.ExplicitBounds().FromRaw([]float64{10})
+			dp.BucketCounts().FromRaw([]uint64{uint64(p.value), 0})
+		}
+		return md
+	case pmetric.MetricTypeSummary:
+		m.SetEmptySummary().DataPoints().AppendEmpty().SetCount(1)
+		return md
+	}
+	for _, p := range points {
+		dp := dps.AppendEmpty()
+		_ = dp.Attributes().FromRaw(p.attrs)
+		dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+		dp.SetTimestamp(pcommon.Timestamp(p.ts))
+		dp.SetIntValue(p.value)
+	}
+	return md
+}
+
+func numberPoints(t *testing.T, md pmetric.Metrics) pmetric.NumberDataPointSlice {
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	require.Equal(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().Len())
+	require.Equal(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().Len())
+	m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	if m.Type() == pmetric.MetricTypeGauge {
+		return m.Gauge().DataPoints()
+	}
+	return m.Sum().DataPoints()
+}
+
+func TestAggregateNumbers(t *testing.T) {
+	a := map[string]any{"host": "a"}
+	b := map[string]any{"host": "b"}
+
+	tests := []struct {
+		name           string
+		typ            pmetric.MetricType
+		temporality    pmetric.AggregationTemporality
+		dropAttributes []string
+		batches        [][]point
+		want           []point
+	}{
+		{
+			name: "gauge keeps last value",
+			typ:  pmetric.MetricTypeGauge,
+			batches: [][]point{
+				{{attrs: a, ts: 10, value: 1}, {attrs: b, ts: 10, value: 5}},
+				{{attrs: a, ts: 30, value: 3}, {attrs: a, ts: 20, value: 2}},
+			},
+			want: []point{{attrs: a, ts: 30, value: 3}, {attrs: b, ts: 10, value: 5}},
+		},
+		{
+			name:        "cumulative sum keeps last value",
+			typ:         pmetric.MetricTypeSum,
+			temporality: pmetric.AggregationTemporalityCumulative,
+			batches: [][]point{
+				{{attrs: a, start: 1, ts: 10, value: 1}},
+				{{attrs: a, start: 1, ts: 20, value: 4}},
+			},
+			want: []point{{attrs: a, start: 1, ts: 20, value: 4}},
+		},
+		{
+			name:        "delta sum merges values",
+			typ:         pmetric.MetricTypeSum,
+			temporality: pmetric.AggregationTemporalityDelta,
+			batches: [][]point{
+				{{attrs: a, start: 0, ts: 10, value: 1}},
+				{{attrs: a, start: 10, ts: 20, value: 4}, {attrs: b, start: 10, ts: 20, value: 2}},
+			},
+			want: []point{{attrs: a, start: 10, ts: 20, value: 5}, {attrs: b, start: 10, ts: 20, value: 2}},
+		},
+		{
+			name:           "delta sum with dropped attributes",
+			typ:            pmetric.MetricTypeSum,
+			temporality:    pmetric.AggregationTemporalityDelta,
+			dropAttributes: []string{"host"},
+			batches: [][]point{
+				{{attrs: a, start: 5, ts: 10, value: 1}, {attrs: b, start: 5, ts: 10, value: 2}},
+				{{attrs: a, start: 10, ts: 20, value: 4}},
+			},
+			want: []point{{attrs: map[string]any{}, start: 5, ts: 20, value: 7}},
+		},
+		{
+			name:           "cumulative sum with dropped attributes",
+			typ:            pmetric.MetricTypeSum,
+			temporality:    pmetric.AggregationTemporalityCumulative,
+			dropAttributes: []string{"host"},
+			batches: [][]point{
+				{{attrs: a, start: 1, ts: 10, value: 1}, {attrs: b, start: 2, ts: 10, value: 2}},
+				{{attrs: a, start: 1, ts: 20, value: 4}, {attrs: b, start: 2, ts: 20, value: 3}},
+			},
+			want: []point{{attrs: map[string]any{}, start: 1, ts: 20, value: 7}},
+		},
+		{
+			name:           "gauge with dropped attributes",
+			typ:            pmetric.MetricTypeGauge,
+			dropAttributes: []string{"host"},
+			batches: [][]point{
+				{{attrs: a, ts: 10, value: 1}, {attrs: b, ts: 15, value: 2}},
+			},
+			want: []point{{attrs: map[string]any{}, ts: 15, value: 2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := new(consumertest.MetricsSink)
+			p := newIntervalProcessor(&Config{Interval: time.Minute, MaxStale: time.Hour, DropAttributes: tt.dropAttributes}, zap.NewNop(), sink)
+			for _, batch := range tt.batches {
+				require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("m", tt.typ, tt.temporality, batch...)))
+			}
+			assert.Empty(t, sink.AllMetrics())
+
+			require.NoError(t, p.export(context.Background()))
+			require.Len(t, sink.AllMetrics(), 1)
+			dps := numberPoints(t, sink.AllMetrics()[0])
+			require.Equal(t, len(tt.want), dps.Len())
+			for i, want := range tt.want {
+				dp := dps.At(i)
+				assert.Equal(t, want.attrs, dp.Attributes().AsRaw())
+				assert.EqualValues(t, want.start, dp.StartTimestamp())
+				assert.EqualValues(t, want.ts, dp.Timestamp())
+				assert.Equal(t, want.value, dp.IntValue())
+			}
+
+			// the next interval starts empty
+			sink.Reset()
+			require.NoError(t, p.export(context.Background()))
+			assert.Empty(t, sink.AllMetrics())
+		})
+	}
+}
+
+func TestCumulativeInputStreamSkippingInterval(t *testing.T) {
+	a := map[string]any{"host": "a"}
+	b := map[string]any{"host": "b"}
+
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Minute, MaxStale: 5 * time.Minute, DropAttributes: []string{"host"}}, zap.NewNop(), sink)
+	now := time.Unix(1000, 0)
+	p.now = func() time.Time { return now }
+
+	exportValue := func() int64 {
+		sink.Reset()
+		require.NoError(t, p.export(context.Background()))
+		require.Len(t, sink.AllMetrics(), 1)
+		dps := numberPoints(t, sink.AllMetrics()[0])
+		require.Equal(t, 1, dps.Len())
+		return dps.At(0).IntValue()
+	}
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("m", pmetric.MetricTypeSum, pmetric.AggregationTemporalityCumulative,
+		point{attrs: a, start: 1, ts: 10, value: 5}, point{attrs: b, start: 1, ts: 10, value: 3})))
+	assert.EqualValues(t, 8, exportValue())
+
+	// b skips the interval, its last value is still part of the sum
+	now = now.Add(time.Minute)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("m", pmetric.MetricTypeSum, pmetric.AggregationTemporalityCumulative,
+		point{attrs: a, start: 1, ts: 20, value: 6})))
+	assert.EqualValues(t, 9, exportValue())
+
+	now = now.Add(time.Minute)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("m", pmetric.MetricTypeSum, pmetric.AggregationTemporalityCumulative,
+		point{attrs: a, start: 1, ts: 30, value: 7}, point{attrs: b, start: 1, ts: 30, value: 4})))
+	assert.EqualValues(t, 11, exportValue())
+
+	// nothing is reported when no input stream is updated
+	now = now.Add(time.Minute)
+	sink.Reset()
+	require.NoError(t, p.export(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+
+	// b is forgotten once stale
+	now = now.Add(5 * time.Minute)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("m", pmetric.MetricTypeSum, pmetric.AggregationTemporalityCumulative,
+		point{attrs: a, start: 1, ts: 40, value: 8})))
+	assert.EqualValues(t, 8, exportValue())
+	assert.Len(t, p.numbers.cumulatives, 1)
+}
+
+func TestAggregateHistograms(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Minute, MaxStale: time.Hour}, zap.NewNop(), sink)
+
+	attrs := map[string]any{"host": "a"}
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("h", pmetric.MetricTypeHistogram, pmetric.AggregationTemporalityDelta,
+		point{attrs: attrs, start: 0, ts: 10, value: 2})))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("h", pmetric.MetricTypeHistogram, pmetric.AggregationTemporalityDelta,
+		point{attrs: attrs, start: 10, ts: 20, value: 3})))
+	require.NoError(t, p.export(context.Background()))
+
+	require.Len(t, sink.AllMetrics(), 1)
+	m := sink.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, m.Histogram().AggregationTemporality())
+	require.Equal(t, 1, m.Histogram().DataPoints().Len())
+	dp := m.Histogram().DataPoints().At(0)
+	assert.EqualValues(t, 5, dp.Count())
+	assert.Equal(t, 5.0, dp.Sum())
+	assert.Equal(t, []uint64{5, 0}, dp.BucketCounts().AsRaw())
+	assert.EqualValues(t, 10, dp.StartTimestamp())
+	assert.EqualValues(t, 20, dp.Timestamp())
+}
+
+func TestSummaryPassThrough(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Minute, MaxStale: time.Hour}, zap.NewNop(), sink)
+
+	md := newMetrics("s", pmetric.MetricTypeSummary, pmetric.AggregationTemporalityUnspecified)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), md))
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, md, sink.AllMetrics()[0])
+
+	sink.Reset()
+	require.NoError(t, p.export(context.Background()))
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestShutdownExports(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: time.Hour, MaxStale: time.Hour}, zap.NewNop(), sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("m", pmetric.MetricTypeGauge, pmetric.AggregationTemporalityUnspecified,
+		point{ts: 10, value: 1})))
+	assert.Empty(t, sink.AllMetrics())
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Equal(t, 1, sink.DataPointCount())
+}
+
+func TestExportOnInterval(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	p := newIntervalProcessor(&Config{Interval: 10 * time.Millisecond, MaxStale: time.Hour}, zap.NewNop(), sink)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, p.Shutdown(context.Background())) }()
+
+	require.NoError(t, p.ConsumeMetrics(context.Background(), newMetrics("m", pmetric.MetricTypeGauge, pmetric.AggregationTemporalityUnspecified,
+		point{ts: 10, value: 1})))
+	assert.Eventually(t, func() bool {
+		return sink.DataPointCount() == 1
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,17 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
+
+import (
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config defines the configuration for the processor.
+type Config struct {
+	// Extension is the ID of the jaegerremotesampling extension with an adaptive source
+	// to which the observed throughput is reported.
+	Extension component.ID `mapstructure:"extension"`
+}
+
+var _ component.Config = (*Config)(nil)
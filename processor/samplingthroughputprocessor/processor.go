@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/samplingthroughputprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+)
+
+type serviceOperation struct {
+	service   string
+	operation string
+}
+
+type throughputProcessor struct {
+	cfg      *Config
+	logger   *zap.Logger
+	recorder jaegerremotesampling.ThroughputRecorder
+}
+
+func newThroughputProcessor(cfg *Config, logger *zap.Logger) *throughputProcessor {
+	return &throughputProcessor{
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (p *throughputProcessor) start(_ context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[p.cfg.Extension]
+	if !ok {
+		return fmt.Errorf("extension '%s' not found", p.cfg.Extension)
+	}
+
+	recorder, ok := ext.(jaegerremotesampling.ThroughputRecorder)
+	if !ok {
+		return fmt.Errorf("extension '%s' is not a jaegerremotesampling extension", p.cfg.Extension)
+	}
+	if !recorder.AdaptiveConfigured() {
+		return fmt.Errorf("extension '%s' has no adaptive source configured", p.cfg.Extension)
+	}
+	p.recorder = recorder
+	return nil
+}
+
+// processTraces counts the root spans by service and operation, and records them as the
+// throughput of the sampled traces. The traces are passed on unchanged.
+func (p *throughputProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	counts := map[serviceOperation]int64{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service := ""
+		if v, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName); ok {
+			service = v.AsString()
+		}
+
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if !span.ParentSpanID().IsEmpty() {
+					continue
+				}
+				counts[serviceOperation{service: service, operation: span.Name()}]++
+			}
+		}
+	}
+
+	if len(counts) == 0 {
+		return td, nil
+	}
+
+	throughput := make([]jaegerremotesampling.Throughput, 0, len(counts))
+	for key, count := range counts {
+		throughput = append(throughput, jaegerremotesampling.Throughput{
+			Service:   key.service,
+			Operation: key.operation,
+			Count:     count,
+		})
+	}
+	if err := p.recorder.RecordThroughput(throughput); err != nil {
+		p.logger.Debug("Failed to record the sampling throughput", zap.Error(err))
+	}
+	return td, nil
+}
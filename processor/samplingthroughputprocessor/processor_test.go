@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package samplingthroughputprocessor
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+)
+
+type recorderExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	withoutAdaptive bool
+	throughput      []jaegerremotesampling.Throughput
+}
+
+func (r *recorderExtension) AdaptiveConfigured() bool {
+	return !r.withoutAdaptive
+}
+
+func (r *recorderExtension) RecordThroughput(throughput []jaegerremotesampling.Throughput) error {
+	r.throughput = append(r.throughput, throughput...)
+	return nil
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type testHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *testHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+func TestStart(t *testing.T) {
+	extID := component.NewID("jaegerremotesampling")
+
+	testCases := []struct {
+		desc        string
+		extensions  map[component.ID]component.Component
+		expectedErr string
+	}{
+		{
+			desc:       "recorder extension",
+			extensions: map[component.ID]component.Component{extID: &recorderExtension{}},
+		},
+		{
+			desc:        "missing extension",
+			extensions:  map[component.ID]component.Component{},
+			expectedErr: "extension 'jaegerremotesampling' not found",
+		},
+		{
+			desc:        "recorder extension without adaptive source",
+			extensions:  map[component.ID]component.Component{extID: &recorderExtension{withoutAdaptive: true}},
+			expectedErr: "extension 'jaegerremotesampling' has no adaptive source configured",
+		},
+		{
+			desc:        "other extension",
+			extensions:  map[component.ID]component.Component{extID: &nopExtension{}},
+			expectedErr: "extension 'jaegerremotesampling' is not a jaegerremotesampling extension",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			p := newThroughputProcessor(&Config{Extension: extID}, nil)
+			err := p.start(context.Background(), &testHost{Host: componenttest.NewNopHost(), extensions: tC.extensions})
+			if tC.expectedErr != "" {
+				assert.EqualError(t, err, tC.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProcessTraces(t *testing.T) {
+	extID := component.NewID("jaegerremotesampling")
+	recorder := &recorderExtension{}
+	sink := new(consumertest.TracesSink)
+
+	factory := NewFactory()
+	tp, err := factory.CreateTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), factory.CreateDefaultConfig(), sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), &testHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{extID: recorder},
+	}))
+
+	td := ptrace.NewTraces()
+	addSpans(td, "foo", map[string]bool{"op1": true, "op2": true, "child": false})
+	addSpans(td, "bar", map[string]bool{"op1": true})
+	addSpans(td, "foo", map[string]bool{"op1": true})
+
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+	require.NoError(t, tp.Shutdown(context.Background()))
+
+	sort.Slice(recorder.throughput, func(i, j int) bool {
+		a, b := recorder.throughput[i], recorder.throughput[j]
+		if a.Service != b.Service {
+			return a.Service < b.Service
+		}
+		return a.Operation < b.Operation
+	})
+	assert.Equal(t, []jaegerremotesampling.Throughput{
+		{Service: "bar", Operation: "op1", Count: 1},
+		{Service: "foo", Operation: "op1", Count: 2},
+		{Service: "foo", Operation: "op2", Count: 1},
+	}, recorder.throughput)
+
+	// the traces are passed on unchanged
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, 5, sink.AllTraces()[0].SpanCount())
+}
+
+// addSpans adds spans of the service to the traces, by name and whether they are root spans.
+func addSpans(td ptrace.Traces, service string, spans map[string]bool) {
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(conventions.AttributeServiceName, service)
+	ss := rs.ScopeSpans().AppendEmpty()
+	for name, root := range spans {
+		span := ss.Spans().AppendEmpty()
+		span.SetName(name)
+		span.SetTraceID([16]byte{1})
+		span.SetSpanID([8]byte{2})
+		if !root {
+			span.SetParentSpanID([8]byte{1})
+		}
+	}
+}
@@ -5,6 +5,8 @@ package servicegraphprocessor // import "github.com/open-telemetry/opentelemetry
 
 import (
 	"time"
+
+	"go.opentelemetry.io/collector/component"
 )
 
 // Config defines the configuration options for servicegraphprocessor.
@@ -41,4 +43,10 @@ type StoreConfig struct {
 	MaxItems int `mapstructure:"max_items"`
 	// TTL is the time to live for items in the store.
 	TTL time.Duration `mapstructure:"ttl"`
+	// Storage is the ID of a storage extension edges are written to when they expire before finding
+	// their pair. Persisted edges are still paired with spans received later on, possibly by other
+	// collectors sharing the same storage. Edges are expired immediately if not set.
+	Storage *component.ID `mapstructure:"storage"`
+	// StorageTTL is the time to live for edges written to the storage extension.
+	StorageTTL time.Duration `mapstructure:"storage_ttl"`
 }
@@ -25,6 +25,8 @@ func TestLoadConfig(t *testing.T) {
 	factories.Processors[metadata.Type] = NewFactory()
 	factories.Connectors[metadata.Type] = newConnectorFactory()
 
+	storageID := component.NewIDWithName("file_storage", "servicegraph")
+
 	// Test
 	cfg, err := otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "service-graph-config.yaml"), factories)
 
@@ -37,8 +39,10 @@ func TestLoadConfig(t *testing.T) {
 			LatencyHistogramBuckets: []time.Duration{1, 2, 3, 4, 5},
 			Dimensions:              []string{"dimension-1", "dimension-2"},
 			Store: StoreConfig{
-				TTL:      time.Second,
-				MaxItems: 10,
+				TTL:        time.Second,
+				MaxItems:   10,
+				Storage:    &storageID,
+				StorageTTL: time.Minute,
 			},
 			CacheLoop:                 2 * time.Minute,
 			StoreExpirationLoop:       10 * time.Second,
@@ -57,8 +61,9 @@ func TestLoadConfig(t *testing.T) {
 			LatencyHistogramBuckets: []time.Duration{1, 2, 3, 4, 5},
 			Dimensions:              []string{"dimension-1", "dimension-2"},
 			Store: StoreConfig{
-				TTL:      time.Second,
-				MaxItems: 10,
+				TTL:        time.Second,
+				MaxItems:   10,
+				StorageTTL: 30 * time.Second,
 			},
 			CacheLoop:           time.Minute,
 			StoreExpirationLoop: 2 * time.Second,
@@ -70,8 +70,9 @@ func NewConnectorFactoryFunc(cfgType component.Type, tracesToMetricsStability co
 func createDefaultConfig() component.Config {
 	return &Config{
 		Store: StoreConfig{
-			TTL:      2 * time.Second,
-			MaxItems: 1000,
+			TTL:        2 * time.Second,
+			MaxItems:   1000,
+			StorageTTL: 30 * time.Second,
 		},
 		CacheLoop:           time.Minute,
 		StoreExpirationLoop: 2 * time.Second,
@@ -80,12 +81,14 @@ func createDefaultConfig() component.Config {
 
 func createTracesProcessor(_ context.Context, params processor.CreateSettings, cfg component.Config, nextConsumer consumer.Traces) (processor.Traces, error) {
 	p := newProcessor(params.Logger, cfg)
+	p.id = params.ID
 	p.tracesConsumer = nextConsumer
 	return p, nil
 }
 
 func createTracesToMetricsConnector(_ context.Context, params connector.CreateSettings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
 	c := newProcessor(params.Logger, cfg)
+	c.id = params.ID
 	c.metricsConsumer = nextConsumer
 	return c, nil
 }
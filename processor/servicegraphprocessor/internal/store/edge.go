@@ -20,13 +20,17 @@ const (
 
 // Edge is an Edge between two nodes in the graph
 type Edge struct {
-	Key Key
+	Key Key `json:"-"`
 
 	TraceID                            pcommon.TraceID
 	ConnectionType                     ConnectionType
 	ServerService, ClientService       string
 	ServerLatencySec, ClientLatencySec float64
 
+	// ClientEndTimestamp and ServerStartTimestamp are used to compute the time
+	// a message spent in a messaging system between its producer and consumer.
+	ClientEndTimestamp, ServerStartTimestamp pcommon.Timestamp
+
 	// If either the client or the server spans have status code error,
 	// the Edge will be considered as failed.
 	Failed bool
@@ -55,6 +59,35 @@ func (e *Edge) isComplete() bool {
 	return len(e.ClientService) != 0 && len(e.ServerService) != 0
 }
 
+// merge copies the half of the other Edge that is still missing from e.
+func (e *Edge) merge(other *Edge) {
+	if e.TraceID.IsEmpty() {
+		e.TraceID = other.TraceID
+	}
+	if e.ConnectionType == Unknown {
+		e.ConnectionType = other.ConnectionType
+	}
+	if len(e.ClientService) == 0 && len(other.ClientService) != 0 {
+		e.ClientService = other.ClientService
+		e.ClientLatencySec = other.ClientLatencySec
+		e.ClientEndTimestamp = other.ClientEndTimestamp
+		for k, v := range other.Peer {
+			e.Peer[k] = v
+		}
+	}
+	if len(e.ServerService) == 0 && len(other.ServerService) != 0 {
+		e.ServerService = other.ServerService
+		e.ServerLatencySec = other.ServerLatencySec
+		e.ServerStartTimestamp = other.ServerStartTimestamp
+	}
+	e.Failed = e.Failed || other.Failed
+	for k, v := range other.Dimensions {
+		if _, ok := e.Dimensions[k]; !ok {
+			e.Dimensions[k] = v
+		}
+	}
+}
+
 func (e *Edge) isExpired() bool {
 	return time.Now().After(e.expiration)
 }
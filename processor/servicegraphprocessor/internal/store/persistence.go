@@ -0,0 +1,229 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
+
+import (
+	"container/list"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	storageKeyPrefix = "edge_"
+
+	clientHalf = "client"
+	serverHalf = "server"
+)
+
+// persistence keeps edges that expired before finding their pair in a storage.Client.
+// When the storage is shared by several collectors, the halves of an Edge can be paired
+// even if they are received by different collectors. Each half is persisted under its own
+// key, so that collectors persisting the two halves at the same time don't overwrite each other.
+//
+// The storage is never accessed while holding the lock of the store.
+type persistence struct {
+	client storage.Client
+	ttl    time.Duration
+	logger *zap.Logger
+
+	mtx sync.Mutex
+	// pending contains the edges persisted by this store, oldest first.
+	pending *list.List
+}
+
+type pendingEdge struct {
+	key        Key
+	half       string
+	expiration time.Time
+}
+
+// EnablePersistence makes the store write edges that expire without finding their pair to
+// the given client instead of expiring them immediately. Persisted edges are paired with
+// edges upserted later on, and are only expired once ttl has elapsed.
+func (s *Store) EnablePersistence(client storage.Client, ttl time.Duration, logger *zap.Logger) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	s.persistence = &persistence{
+		client:  client,
+		ttl:     ttl,
+		logger:  logger,
+		pending: list.New(),
+	}
+}
+
+// storageKey returns the key the given half of the Edge is persisted with.
+func (k Key) storageKey(half string) string {
+	return storageKeyPrefix + hex.EncodeToString(k.tid[:]) + hex.EncodeToString(k.sid[:]) + "_" + half
+}
+
+// halves returns the half of the incomplete Edge, and the half it is missing.
+func (e *Edge) halves() (half, missing string) {
+	if len(e.ClientService) != 0 {
+		return clientHalf, serverHalf
+	}
+	return serverHalf, clientHalf
+}
+
+func (s *Store) getPersistence() *persistence {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.persistence
+}
+
+// pairPersisted completes e with its persisted half, if any, and deletes it from storage.
+// Returns true if e is complete.
+//
+// Must be called without holding lock.
+func (s *Store) pairPersisted(ctx context.Context, e *Edge) bool {
+	p := s.getPersistence()
+	if p == nil {
+		return false
+	}
+
+	_, missing := e.halves()
+	key := e.Key.storageKey(missing)
+	value, err := p.client.Get(ctx, key)
+	if err != nil {
+		p.logger.Warn("failed to read persisted edge", zap.Error(err))
+		return false
+	}
+	stored, ok := p.unmarshal(e.Key, value)
+	if !ok {
+		return false
+	}
+
+	e.merge(stored)
+	if !e.isComplete() {
+		return false
+	}
+
+	if err = p.client.Delete(ctx, key); err != nil {
+		p.logger.Warn("failed to delete persisted edge", zap.Error(err))
+	}
+	return true
+}
+
+// persist writes the expired Edge to storage, or completes it if its other half was already
+// persisted. Returns false if the Edge couldn't be persisted and should be expired right away.
+//
+// Must be called without holding lock.
+func (s *Store) persist(ctx context.Context, e *Edge) bool {
+	p := s.getPersistence()
+	if p == nil {
+		return false
+	}
+
+	if s.pairPersisted(ctx, e) {
+		s.onComplete(e)
+		return true
+	}
+
+	value, err := json.Marshal(e)
+	if err != nil {
+		p.logger.Debug("failed to marshal edge", zap.Error(err))
+		return false
+	}
+	half, _ := e.halves()
+	if err = p.client.Set(ctx, e.Key.storageKey(half), value); err != nil {
+		p.logger.Warn("failed to persist edge", zap.Error(err))
+		return false
+	}
+
+	p.mtx.Lock()
+	p.pending.PushBack(&pendingEdge{
+		key:        e.Key,
+		half:       half,
+		expiration: time.Now().Add(p.ttl),
+	})
+	p.mtx.Unlock()
+	return true
+}
+
+// expirePersisted expires the persisted edges whose ttl elapsed without being paired, or
+// completes them if their other half was persisted in the meantime, possibly by another
+// collector. Edges paired in the meantime are no longer in storage and are dropped silently.
+//
+// Must be called without holding lock.
+func (s *Store) expirePersisted(ctx context.Context) {
+	p := s.getPersistence()
+	if p == nil {
+		return
+	}
+
+	for _, pending := range p.popExpired(time.Now()) {
+		missing := clientHalf
+		if pending.half == clientHalf {
+			missing = serverHalf
+		}
+		get := storage.GetOperation(pending.key.storageKey(pending.half))
+		getMissing := storage.GetOperation(pending.key.storageKey(missing))
+		if err := p.client.Batch(ctx, get, getMissing); err != nil {
+			p.logger.Warn("failed to read persisted edge", zap.Error(err))
+			continue
+		}
+
+		stored, ok := p.unmarshal(pending.key, get.Value)
+		if !ok {
+			continue
+		}
+		deletes := []storage.Operation{storage.DeleteOperation(get.Key)}
+		if other, ok := p.unmarshal(pending.key, getMissing.Value); ok {
+			stored.merge(other)
+			deletes = append(deletes, storage.DeleteOperation(getMissing.Key))
+		}
+		if err := p.client.Batch(ctx, deletes...); err != nil {
+			p.logger.Warn("failed to delete persisted edge", zap.Error(err))
+		}
+
+		if stored.isComplete() {
+			s.onComplete(stored)
+		} else {
+			s.onExpire(stored)
+		}
+	}
+}
+
+// popExpired removes and returns the pending edges whose ttl elapsed.
+func (p *persistence) popExpired(now time.Time) []*pendingEdge {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	var expired []*pendingEdge
+	for head := p.pending.Front(); head != nil; head = p.pending.Front() {
+		pending := head.Value.(*pendingEdge)
+		if now.Before(pending.expiration) {
+			break
+		}
+		p.pending.Remove(head)
+		expired = append(expired, pending)
+	}
+	return expired
+}
+
+// unmarshal returns the persisted Edge with the given key, or false if there is none.
+func (p *persistence) unmarshal(key Key, value []byte) (*Edge, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	e := newEdge(key, 0)
+	if err := json.Unmarshal(value, e); err != nil {
+		p.logger.Debug("failed to unmarshal persisted edge", zap.Error(err))
+		return nil, false
+	}
+	if e.Dimensions == nil {
+		e.Dimensions = make(map[string]string)
+	}
+	if e.Peer == nil {
+		e.Peer = make(map[string]string)
+	}
+	return e, true
+}
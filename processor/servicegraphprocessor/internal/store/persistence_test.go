@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func TestStorePersistence_pairAcrossStores(t *testing.T) {
+	ctx := context.Background()
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID("servicegraph"), "")
+
+	// Two stores sharing the same storage, like two collectors behind a load balancer.
+	var completed []*Edge
+	var onExpireCount int
+	onComplete := func(e *Edge) { completed = append(completed, e) }
+	clientStore := NewStore(-time.Second, 10, onComplete, countingCallback(&onExpireCount))
+	clientStore.EnablePersistence(client, time.Hour, zaptest.NewLogger(t))
+	serverStore := NewStore(time.Hour, 10, onComplete, countingCallback(&onExpireCount))
+	serverStore.EnablePersistence(client, time.Hour, zaptest.NewLogger(t))
+
+	_, err := clientStore.UpsertEdge(ctx, key, func(e *Edge) {
+		e.ClientService = clientService
+		e.ClientLatencySec = 2
+		e.Dimensions["client_dim"] = "a"
+	})
+	require.NoError(t, err)
+
+	// The client half expires and is written to storage instead of being expired.
+	clientStore.Expire(ctx)
+	assert.Equal(t, 0, clientStore.len())
+	assert.Equal(t, 1, clientStore.persistence.pending.Len())
+	assert.Equal(t, 0, onExpireCount)
+	value, err := client.Get(ctx, key.storageKey(clientHalf))
+	require.NoError(t, err)
+	assert.NotNil(t, value)
+
+	// The server half is paired with the persisted client half.
+	isNew, err := serverStore.UpsertEdge(ctx, key, func(e *Edge) {
+		e.ServerService = "server"
+		e.ServerLatencySec = 1
+		e.Dimensions["server_dim"] = "b"
+	})
+	require.NoError(t, err)
+	assert.False(t, isNew)
+	assert.Equal(t, 0, serverStore.len())
+
+	require.Len(t, completed, 1)
+	assert.Equal(t, clientService, completed[0].ClientService)
+	assert.Equal(t, "server", completed[0].ServerService)
+	assert.Equal(t, float64(2), completed[0].ClientLatencySec)
+	assert.Equal(t, float64(1), completed[0].ServerLatencySec)
+	assert.Equal(t, map[string]string{"client_dim": "a", "server_dim": "b"}, completed[0].Dimensions)
+	assert.Equal(t, key, completed[0].Key)
+
+	value, err = client.Get(ctx, key.storageKey(clientHalf))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestStorePersistence_pairOnExpire(t *testing.T) {
+	ctx := context.Background()
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID("servicegraph"), "")
+
+	var onCompletedCount, onExpireCount int
+	clientStore := NewStore(-time.Second, 10, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	clientStore.EnablePersistence(client, time.Hour, zaptest.NewLogger(t))
+	serverStore := NewStore(-time.Second, 10, countingCallback(&onCompletedCount), countingCallback(&onExpireCount))
+	serverStore.EnablePersistence(client, time.Hour, zaptest.NewLogger(t))
+
+	// Both halves are received at the same time by different stores.
+	_, err := clientStore.UpsertEdge(ctx, key, func(e *Edge) { e.ClientService = clientService })
+	require.NoError(t, err)
+	_, err = serverStore.UpsertEdge(ctx, key, func(e *Edge) { e.ServerService = "server" })
+	require.NoError(t, err)
+
+	clientStore.Expire(ctx)
+	assert.Equal(t, 0, onCompletedCount)
+
+	// The second half to expire finds the first one in storage.
+	serverStore.Expire(ctx)
+	assert.Equal(t, 1, onCompletedCount)
+	assert.Equal(t, 0, onExpireCount)
+	assert.Equal(t, 0, serverStore.persistence.pending.Len())
+}
+
+func TestStorePersistence_expire(t *testing.T) {
+	ctx := context.Background()
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID("servicegraph"), "")
+
+	var onCompletedCount int
+	var expired []*Edge
+	s := NewStore(-time.Second, 10, countingCallback(&onCompletedCount), func(e *Edge) { expired = append(expired, e) })
+	s.EnablePersistence(client, -time.Second, zaptest.NewLogger(t))
+
+	_, err := s.UpsertEdge(ctx, key, func(e *Edge) { e.ClientService = clientService })
+	require.NoError(t, err)
+
+	// The edge is persisted and expired immediately as its persistence ttl already elapsed.
+	s.Expire(ctx)
+	assert.Equal(t, 0, onCompletedCount)
+	require.Len(t, expired, 1)
+	assert.Equal(t, clientService, expired[0].ClientService)
+	assert.Equal(t, 0, s.persistence.pending.Len())
+
+	value, err := client.Get(ctx, key.storageKey(clientHalf))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestStorePersistence_halvesPersistedConcurrently(t *testing.T) {
+	ctx := context.Background()
+	key := NewKey(pcommon.TraceID([16]byte{1, 2, 3}), pcommon.SpanID([8]byte{1, 2, 3}))
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.NewID("servicegraph"), "")
+
+	var completed []*Edge
+	var onExpireCount int
+	s := NewStore(-time.Second, 10, func(e *Edge) { completed = append(completed, e) }, countingCallback(&onExpireCount))
+	s.EnablePersistence(client, time.Hour, zaptest.NewLogger(t))
+
+	_, err := s.UpsertEdge(ctx, key, func(e *Edge) { e.ClientService = clientService })
+	require.NoError(t, err)
+	s.Expire(ctx)
+	require.Equal(t, 1, s.persistence.pending.Len())
+
+	// Another collector persists the server half at the same time, without seeing the client half.
+	value, err := json.Marshal(&Edge{ServerService: "server", ServerLatencySec: 1})
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, key.storageKey(serverHalf), value))
+
+	// Both halves are kept, and paired when the client half expires.
+	value, err = client.Get(ctx, key.storageKey(clientHalf))
+	require.NoError(t, err)
+	assert.NotNil(t, value)
+	s.persistence.pending.Front().Value.(*pendingEdge).expiration = time.Now().Add(-time.Second)
+	s.Expire(ctx)
+
+	assert.Equal(t, 0, onExpireCount)
+	require.Len(t, completed, 1)
+	assert.Equal(t, clientService, completed[0].ClientService)
+	assert.Equal(t, "server", completed[0].ServerService)
+	for _, half := range []string{clientHalf, serverHalf} {
+		value, err = client.Get(ctx, key.storageKey(half))
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	}
+}
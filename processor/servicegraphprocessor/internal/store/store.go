@@ -5,6 +5,7 @@ package store // import "github.com/open-telemetry/opentelemetry-collector-contr
 
 import (
 	"container/list"
+	"context"
 	"errors"
 	"sync"
 	"time"
@@ -41,6 +42,10 @@ type Store struct {
 
 	ttl      time.Duration
 	maxItems int
+
+	// persistence is only set when edges that expire unpaired are written
+	// to a storage extension, see EnablePersistence.
+	persistence *persistence
 }
 
 // NewStore creates a Store to build service graphs. The store caches edges, each representing a
@@ -69,20 +74,8 @@ func (s *Store) len() int {
 // UpsertEdge fetches an Edge from the store and updates it using the given callback. If the Edge
 // doesn't exist yet, it creates a new one with the default TTL.
 // If the Edge is complete after applying the callback, it's completed and removed.
-func (s *Store) UpsertEdge(key Key, update Callback) (isNew bool, err error) {
-	s.mtx.Lock()
-	defer s.mtx.Unlock()
-
-	if storedEdge, ok := s.m[key]; ok {
-		edge := storedEdge.Value.(*Edge)
-		update(edge)
-
-		if edge.isComplete() {
-			s.onComplete(edge)
-			delete(s.m, key)
-			s.l.Remove(storedEdge)
-		}
-
+func (s *Store) UpsertEdge(ctx context.Context, key Key, update Callback) (isNew bool, err error) {
+	if s.updateStored(key, update) {
 		return false, nil
 	}
 
@@ -94,6 +87,23 @@ func (s *Store) UpsertEdge(key Key, update Callback) (isNew bool, err error) {
 		return true, nil
 	}
 
+	// The other half of the Edge might have been persisted when it expired,
+	// possibly by another collector sharing the same storage. The storage is
+	// read without holding the lock.
+	if s.pairPersisted(ctx, edge) {
+		s.onComplete(edge)
+		return false, nil
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	// The Edge might have been added while the storage was read
+	if storedEdge, ok := s.m[key]; ok {
+		s.updateElement(storedEdge, update)
+		return false, nil
+	}
+
 	// Check we can add new edges
 	if s.l.Len() >= s.maxItems {
 		// TODO: try to evict expired items
@@ -106,34 +116,77 @@ func (s *Store) UpsertEdge(key Key, update Callback) (isNew bool, err error) {
 	return true, nil
 }
 
-// Expire evicts all expired items in the store.
-func (s *Store) Expire() {
+// updateStored updates the Edge with the given key using the given callback, if it is in the
+// store. Returns false if it isn't.
+func (s *Store) updateStored(key Key, update Callback) bool {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 
+	storedEdge, ok := s.m[key]
+	if !ok {
+		return false
+	}
+	s.updateElement(storedEdge, update)
+	return true
+}
+
+// updateElement updates the Edge of the element using the given callback, and completes and
+// removes it if it is complete.
+//
+// Must be called holding lock.
+func (s *Store) updateElement(ele *list.Element, update Callback) {
+	edge := ele.Value.(*Edge)
+	update(edge)
+
+	if edge.isComplete() {
+		s.onComplete(edge)
+		delete(s.m, edge.Key)
+		s.l.Remove(ele)
+	}
+}
+
+// Expire evicts all expired items in the store. The evicted edges are persisted, if enabled,
+// once the lock is released.
+func (s *Store) Expire(ctx context.Context) {
+	var evicted []*Edge
+
+	s.mtx.Lock()
 	// Iterates until no more items can be evicted
-	for s.tryEvictHead() { // nolint
+	for {
+		edge, ok := s.tryEvictHead()
+		if !ok {
+			break
+		}
+		evicted = append(evicted, edge)
+	}
+	s.mtx.Unlock()
+
+	for _, edge := range evicted {
+		if !s.persist(ctx, edge) {
+			s.onExpire(edge)
+		}
 	}
+
+	s.expirePersisted(ctx)
 }
 
 // tryEvictHead checks if the oldest item (head of list) can be evicted and will delete it if so.
-// Returns true if the head was evicted.
+// Returns the evicted Edge, and true if the head was evicted.
 //
 // Must be called holding lock.
-func (s *Store) tryEvictHead() bool {
+func (s *Store) tryEvictHead() (*Edge, bool) {
 	head := s.l.Front()
 	if head == nil {
-		return false // list is empty
+		return nil, false // list is empty
 	}
 
 	headEdge := head.Value.(*Edge)
 	if !headEdge.isExpired() {
-		return false
+		return nil, false
 	}
 
-	s.onExpire(headEdge)
 	delete(s.m, headEdge.Key)
 	s.l.Remove(head)
 
-	return true
+	return headEdge, true
 }
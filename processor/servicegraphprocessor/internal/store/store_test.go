@@ -4,6 +4,7 @@
 package store // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/servicegraphprocessor/internal/store"
 
 import (
+	"context"
 	"encoding/hex"
 	"math/rand"
 	"testing"
@@ -26,7 +27,7 @@ func TestStoreUpsertEdge(t *testing.T) {
 	assert.Equal(t, 0, s.len())
 
 	// Insert first half of an edge
-	isNew, err := s.UpsertEdge(key, func(e *Edge) {
+	isNew, err := s.UpsertEdge(context.Background(), key, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.NoError(t, err)
@@ -34,12 +35,13 @@ func TestStoreUpsertEdge(t *testing.T) {
 	assert.Equal(t, 1, s.len())
 
 	// Nothing should be evicted as TTL is set to 1h
-	assert.False(t, s.tryEvictHead())
+	_, evicted := s.tryEvictHead()
+	assert.False(t, evicted)
 	assert.Equal(t, 0, onCompletedCount)
 	assert.Equal(t, 0, onExpireCount)
 
 	// Insert the second half of an edge
-	isNew, err = s.UpsertEdge(key, func(e *Edge) {
+	isNew, err = s.UpsertEdge(context.Background(), key, func(e *Edge) {
 		assert.Equal(t, clientService, e.ClientService)
 		e.ServerService = "server"
 	})
@@ -52,7 +54,7 @@ func TestStoreUpsertEdge(t *testing.T) {
 	assert.Equal(t, 0, onExpireCount)
 
 	// Insert an edge that will immediately expire
-	isNew, err = s.UpsertEdge(key, func(e *Edge) {
+	isNew, err = s.UpsertEdge(context.Background(), key, func(e *Edge) {
 		e.ClientService = clientService
 		e.expiration = time.UnixMicro(0)
 	})
@@ -62,7 +64,7 @@ func TestStoreUpsertEdge(t *testing.T) {
 	assert.Equal(t, 1, onCompletedCount)
 	assert.Equal(t, 0, onExpireCount)
 
-	assert.True(t, s.tryEvictHead())
+	s.Expire(context.Background())
 	assert.Equal(t, 0, s.len())
 	assert.Equal(t, 1, onCompletedCount)
 	assert.Equal(t, 1, onExpireCount)
@@ -76,20 +78,20 @@ func TestStoreUpsertEdge_errTooManyItems(t *testing.T) {
 	s := NewStore(time.Hour, 1, countingCallback(&onCallbackCounter), countingCallback(&onCallbackCounter))
 	assert.Equal(t, 0, s.len())
 
-	isNew, err := s.UpsertEdge(key1, func(e *Edge) {
+	isNew, err := s.UpsertEdge(context.Background(), key1, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.NoError(t, err)
 	require.Equal(t, true, isNew)
 	assert.Equal(t, 1, s.len())
 
-	_, err = s.UpsertEdge(key2, func(e *Edge) {
+	_, err = s.UpsertEdge(context.Background(), key2, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.ErrorIs(t, err, ErrTooManyItems)
 	assert.Equal(t, 1, s.len())
 
-	isNew, err = s.UpsertEdge(key1, func(e *Edge) {
+	isNew, err = s.UpsertEdge(context.Background(), key1, func(e *Edge) {
 		e.ClientService = clientService
 	})
 	require.NoError(t, err)
@@ -118,12 +120,12 @@ func TestStoreExpire(t *testing.T) {
 	s := NewStore(-time.Second, testSize, onComplete, countingCallback(&onExpireCount))
 
 	for key := range keys {
-		isNew, err := s.UpsertEdge(key, noopCallback)
+		isNew, err := s.UpsertEdge(context.Background(), key, noopCallback)
 		require.NoError(t, err)
 		require.Equal(t, true, isNew)
 	}
 
-	s.Expire()
+	s.Expire(context.Background())
 	assert.Equal(t, 0, s.len())
 	assert.Equal(t, 0, onCompletedCount)
 	assert.Equal(t, testSize, onExpireCount)
@@ -148,14 +150,14 @@ func TestStoreConcurrency(t *testing.T) {
 	go accessor(func() {
 		key := NewKey(pcommon.TraceID([16]byte{byte(rand.Intn(32))}), pcommon.SpanID([8]byte{1, 2, 3}))
 
-		_, err := s.UpsertEdge(key, func(e *Edge) {
+		_, err := s.UpsertEdge(context.Background(), key, func(e *Edge) {
 			e.ClientService = hex.EncodeToString(key.tid[:])
 		})
 		assert.NoError(t, err)
 	})
 
 	go accessor(func() {
-		s.Expire()
+		s.Expire(context.Background())
 	})
 
 	time.Sleep(100 * time.Millisecond)
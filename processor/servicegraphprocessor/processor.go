@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -49,12 +50,14 @@ type metricSeries struct {
 var _ processor.Traces = (*serviceGraphProcessor)(nil)
 
 type serviceGraphProcessor struct {
+	id              component.ID
 	config          *Config
 	logger          *zap.Logger
 	metricsConsumer consumer.Metrics
 	tracesConsumer  consumer.Traces
 
-	store *store.Store
+	store         *store.Store
+	storageClient storage.Client
 
 	startTime time.Time
 
@@ -67,7 +70,12 @@ type serviceGraphProcessor struct {
 	reqServerDurationSecondsCount        map[string]uint64
 	reqServerDurationSecondsSum          map[string]float64
 	reqServerDurationSecondsBucketCounts map[string][]uint64
-	reqDurationBounds                    []float64
+	// reqMessagingSystemDurationSeconds track the time messages spent in a messaging system,
+	// from the end of the producer span to the start of the consumer span.
+	reqMessagingSystemDurationSecondsCount        map[string]uint64
+	reqMessagingSystemDurationSecondsSum          map[string]float64
+	reqMessagingSystemDurationSecondsBucketCounts map[string][]uint64
+	reqDurationBounds                             []float64
 
 	metricMutex sync.RWMutex
 	keyToMetric map[string]metricSeries
@@ -96,26 +104,38 @@ func newProcessor(logger *zap.Logger, config component.Config) *serviceGraphProc
 	}
 
 	return &serviceGraphProcessor{
-		config:                               pConfig,
-		logger:                               logger,
-		startTime:                            time.Now(),
-		reqTotal:                             make(map[string]int64),
-		reqFailedTotal:                       make(map[string]int64),
-		reqClientDurationSecondsCount:        make(map[string]uint64),
-		reqClientDurationSecondsSum:          make(map[string]float64),
-		reqClientDurationSecondsBucketCounts: make(map[string][]uint64),
-		reqServerDurationSecondsCount:        make(map[string]uint64),
-		reqServerDurationSecondsSum:          make(map[string]float64),
-		reqServerDurationSecondsBucketCounts: make(map[string][]uint64),
-		reqDurationBounds:                    bounds,
-		keyToMetric:                          make(map[string]metricSeries),
-		shutdownCh:                           make(chan interface{}),
-	}
-}
-
-func (p *serviceGraphProcessor) Start(_ context.Context, host component.Host) error {
+		config:                                        pConfig,
+		logger:                                        logger,
+		startTime:                                     time.Now(),
+		reqTotal:                                      make(map[string]int64),
+		reqFailedTotal:                                make(map[string]int64),
+		reqClientDurationSecondsCount:                 make(map[string]uint64),
+		reqClientDurationSecondsSum:                   make(map[string]float64),
+		reqClientDurationSecondsBucketCounts:          make(map[string][]uint64),
+		reqServerDurationSecondsCount:                 make(map[string]uint64),
+		reqServerDurationSecondsSum:                   make(map[string]float64),
+		reqServerDurationSecondsBucketCounts:          make(map[string][]uint64),
+		reqMessagingSystemDurationSecondsCount:        make(map[string]uint64),
+		reqMessagingSystemDurationSecondsSum:          make(map[string]float64),
+		reqMessagingSystemDurationSecondsBucketCounts: make(map[string][]uint64),
+		reqDurationBounds:                             bounds,
+		keyToMetric:                                   make(map[string]metricSeries),
+		shutdownCh:                                    make(chan interface{}),
+	}
+}
+
+func (p *serviceGraphProcessor) Start(ctx context.Context, host component.Host) error {
 	p.store = store.NewStore(p.config.Store.TTL, p.config.Store.MaxItems, p.onComplete, p.onExpire)
 
+	if p.config.Store.Storage != nil {
+		client, err := p.getStorageClient(ctx, host)
+		if err != nil {
+			return err
+		}
+		p.storageClient = client
+		p.store.EnablePersistence(client, p.config.Store.StorageTTL, p.logger)
+	}
+
 	if p.metricsConsumer == nil {
 		exporters := host.GetExporters() //nolint:staticcheck
 
@@ -146,13 +166,35 @@ func (p *serviceGraphProcessor) Start(_ context.Context, host component.Host) er
 	return nil
 }
 
-func (p *serviceGraphProcessor) Shutdown(_ context.Context) error {
+func (p *serviceGraphProcessor) getStorageClient(ctx context.Context, host component.Host) (storage.Client, error) {
+	ext, ok := host.GetExtensions()[*p.config.Store.Storage]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", p.config.Store.Storage)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", p.config.Store.Storage)
+	}
+
+	kind := component.KindProcessor
+	if p.tracesConsumer == nil {
+		kind = component.KindConnector
+	}
+	return storageExt.GetClient(ctx, kind, p.id, "")
+}
+
+func (p *serviceGraphProcessor) Shutdown(ctx context.Context) error {
 	if p.tracesConsumer == nil {
 		p.logger.Info("Shutting down servicegraphconnector")
 	} else {
 		p.logger.Info("Shutting down servicegraphprocessor")
 	}
 	close(p.shutdownCh)
+
+	if p.storageClient != nil {
+		return p.storageClient.Close(ctx)
+	}
 	return nil
 }
 
@@ -225,11 +267,12 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindClient:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.SpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					isNew, err = p.store.UpsertEdge(ctx, key, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ClientService = serviceName
 						e.ClientLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
+						e.ClientEndTimestamp = span.EndTimestamp()
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(clientKind, e.Dimensions, rAttributes, span.Attributes())
 
@@ -239,7 +282,7 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 
 						// A database request will only have one span, we don't wait for the server
 						// span but just copy details from the client span
-						if dbName, ok := findAttributeValue(semconv.AttributeDBName, rAttributes, span.Attributes()); ok {
+						if dbName, ok := findDatabaseName(rAttributes, span.Attributes()); ok {
 							e.ConnectionType = store.Database
 							e.ServerService = dbName
 							e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
@@ -252,11 +295,12 @@ func (p *serviceGraphProcessor) aggregateMetrics(ctx context.Context, td ptrace.
 				case ptrace.SpanKindServer:
 					traceID := span.TraceID()
 					key := store.NewKey(traceID, span.ParentSpanID())
-					isNew, err = p.store.UpsertEdge(key, func(e *store.Edge) {
+					isNew, err = p.store.UpsertEdge(ctx, key, func(e *store.Edge) {
 						e.TraceID = traceID
 						e.ConnectionType = connectionType
 						e.ServerService = serviceName
 						e.ServerLatencySec = float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
+						e.ServerStartTimestamp = span.StartTimestamp()
 						e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
 						p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
 					})
@@ -350,6 +394,9 @@ func (p *serviceGraphProcessor) aggregateMetricsForEdge(e *store.Edge) {
 		p.updateErrorMetrics(metricKey)
 	}
 	p.updateDurationMetrics(metricKey, e.ServerLatencySec, e.ClientLatencySec)
+	if e.ConnectionType == store.MessagingSystem {
+		p.updateMessagingSystemDurationMetrics(metricKey, e)
+	}
 }
 
 func (p *serviceGraphProcessor) updateSeries(key string, dimensions pcommon.Map) {
@@ -401,6 +448,23 @@ func (p *serviceGraphProcessor) updateClientDurationMetrics(key string, duration
 	p.reqClientDurationSecondsBucketCounts[key][index]++
 }
 
+func (p *serviceGraphProcessor) updateMessagingSystemDurationMetrics(key string, e *store.Edge) {
+	// Clock skew between the producer and the consumer can make the start of the consumer
+	// span precede the end of the producer span.
+	if e.ServerStartTimestamp < e.ClientEndTimestamp {
+		return
+	}
+	duration := float64(e.ServerStartTimestamp-e.ClientEndTimestamp) / float64(time.Millisecond.Nanoseconds())
+
+	index := sort.SearchFloat64s(p.reqDurationBounds, duration) // Search bucket index
+	if _, ok := p.reqMessagingSystemDurationSecondsBucketCounts[key]; !ok {
+		p.reqMessagingSystemDurationSecondsBucketCounts[key] = make([]uint64, len(p.reqDurationBounds)+1)
+	}
+	p.reqMessagingSystemDurationSecondsSum[key] += duration
+	p.reqMessagingSystemDurationSecondsCount[key]++
+	p.reqMessagingSystemDurationSecondsBucketCounts[key][index]++
+}
+
 func buildDimensions(e *store.Edge) pcommon.Map {
 	dims := pcommon.NewMap()
 	dims.PutStr("client", e.ClientService)
@@ -487,7 +551,38 @@ func (p *serviceGraphProcessor) collectLatencyMetrics(ilm pmetric.ScopeMetrics)
 		return err
 	}
 
-	return p.collectClientLatencyMetrics(ilm)
+	if err := p.collectClientLatencyMetrics(ilm); err != nil {
+		return err
+	}
+
+	return p.collectMessagingSystemLatencyMetrics(ilm)
+}
+
+func (p *serviceGraphProcessor) collectMessagingSystemLatencyMetrics(ilm pmetric.ScopeMetrics) error {
+	for key := range p.reqMessagingSystemDurationSecondsCount {
+		mDuration := ilm.Metrics().AppendEmpty()
+		mDuration.SetName("traces_service_graph_request_messaging_system_seconds")
+		// TODO: Support other aggregation temporalities
+		mDuration.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+
+		timestamp := pcommon.NewTimestampFromTime(time.Now())
+
+		dpDuration := mDuration.Histogram().DataPoints().AppendEmpty()
+		dpDuration.SetStartTimestamp(pcommon.NewTimestampFromTime(p.startTime))
+		dpDuration.SetTimestamp(timestamp)
+		dpDuration.ExplicitBounds().FromRaw(p.reqDurationBounds)
+		dpDuration.BucketCounts().FromRaw(p.reqMessagingSystemDurationSecondsBucketCounts[key])
+		dpDuration.SetCount(p.reqMessagingSystemDurationSecondsCount[key])
+		dpDuration.SetSum(p.reqMessagingSystemDurationSecondsSum[key])
+
+		dimensions, ok := p.dimensionsForSeries(key)
+		if !ok {
+			return fmt.Errorf("failed to find dimensions for key %s", key)
+		}
+
+		dimensions.CopyTo(dpDuration.Attributes())
+	}
+	return nil
 }
 
 func (p *serviceGraphProcessor) collectClientLatencyMetrics(ilm pmetric.ScopeMetrics) error {
@@ -567,7 +662,7 @@ func (p *serviceGraphProcessor) storeExpirationLoop(d time.Duration) {
 	for {
 		select {
 		case <-t.C:
-			p.store.Expire()
+			p.store.Expire(context.Background())
 		case <-p.shutdownCh:
 			return
 		}
@@ -626,6 +721,9 @@ func (p *serviceGraphProcessor) cleanCache() {
 		delete(p.reqServerDurationSecondsCount, key)
 		delete(p.reqServerDurationSecondsSum, key)
 		delete(p.reqServerDurationSecondsBucketCounts, key)
+		delete(p.reqMessagingSystemDurationSecondsCount, key)
+		delete(p.reqMessagingSystemDurationSecondsSum, key)
+		delete(p.reqMessagingSystemDurationSecondsBucketCounts, key)
 	}
 	p.seriesMutex.Unlock()
 }
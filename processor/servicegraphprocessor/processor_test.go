@@ -27,6 +27,8 @@ import (
 	"go.opentelemetry.io/collector/processor/processortest"
 	semconv "go.opentelemetry.io/collector/semconv/v1.13.0"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 )
 
 func TestProcessorStart(t *testing.T) {
@@ -215,7 +217,7 @@ func TestProcessorConsume(t *testing.T) {
 			time.Sleep(time.Second * 2)
 
 			// Force collection
-			p.store.Expire()
+			p.store.Expire(context.Background())
 			md, err := p.buildMetrics()
 			assert.NoError(t, err)
 			tc.verifyMetrics(t, md)
@@ -247,7 +249,7 @@ func TestConnectorConsume(t *testing.T) {
 	assert.NoError(t, conn.ConsumeTraces(context.Background(), td))
 
 	// Force collection
-	conn.store.Expire()
+	conn.store.Expire(context.Background())
 	md, err := conn.buildMetrics()
 	assert.NoError(t, err)
 	verifyHappyCaseMetrics(t, md)
@@ -256,6 +258,127 @@ func TestConnectorConsume(t *testing.T) {
 	assert.NoError(t, conn.Shutdown(context.Background()))
 }
 
+func TestConnectorStartWithStorage(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		storageID    component.ID
+		host         component.Host
+		wantErrorMsg string
+	}{
+		{"storage extension", storagetest.NewStorageID("test"), storagetest.NewStorageHost().WithInMemoryStorageExtension("test"), ""},
+		{"missing extension", storagetest.NewStorageID("test"), storagetest.NewStorageHost(), "storage extension 'test_storage/test' not found"},
+		{"non-storage extension", storagetest.NewNonStorageID("test"), storagetest.NewStorageHost().WithNonStorageExtension("test"), "non-storage extension 'non_storage/test' found"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			factory := newConnectorFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.Store.Storage = &tc.storageID
+
+			conn, err := factory.CreateTracesToMetrics(context.Background(), connectortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+
+			err = conn.Start(context.Background(), tc.host)
+			if tc.wantErrorMsg != "" {
+				assert.EqualError(t, err, tc.wantErrorMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, conn.(*serviceGraphProcessor).storageClient)
+			assert.NoError(t, conn.Shutdown(context.Background()))
+		})
+	}
+}
+
+func TestConnectorConsumeMessagingSystem(t *testing.T) {
+	cfg := &Config{
+		Store: StoreConfig{MaxItems: 10},
+	}
+
+	conn := newProcessor(zaptest.NewLogger(t), cfg)
+	conn.metricsConsumer = newMockMetricsExporter()
+	assert.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	tStart := time.Date(2022, 1, 2, 3, 4, 5, 6, time.UTC)
+	traces := ptrace.NewTraces()
+	traceID := pcommon.TraceID([16]byte{1, 2, 3, 4})
+	producerSpanID := pcommon.SpanID([8]byte{1, 2, 3, 4})
+
+	producer := traces.ResourceSpans().AppendEmpty()
+	producer.Resource().Attributes().PutStr(semconv.AttributeServiceName, "producer-service")
+	producerSpan := producer.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	producerSpan.SetSpanID(producerSpanID)
+	producerSpan.SetTraceID(traceID)
+	producerSpan.SetKind(ptrace.SpanKindProducer)
+	producerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart))
+	producerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tStart.Add(time.Second)))
+
+	// The message waits 2 seconds in the queue before being consumed.
+	consumer := traces.ResourceSpans().AppendEmpty()
+	consumer.Resource().Attributes().PutStr(semconv.AttributeServiceName, "consumer-service")
+	consumerSpan := consumer.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	consumerSpan.SetSpanID(pcommon.SpanID([8]byte{5, 6, 7, 8}))
+	consumerSpan.SetParentSpanID(producerSpanID)
+	consumerSpan.SetTraceID(traceID)
+	consumerSpan.SetKind(ptrace.SpanKindConsumer)
+	consumerSpan.SetStartTimestamp(pcommon.NewTimestampFromTime(tStart.Add(3 * time.Second)))
+	consumerSpan.SetEndTimestamp(pcommon.NewTimestampFromTime(tStart.Add(4 * time.Second)))
+
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), traces))
+
+	md, err := conn.buildMetrics()
+	require.NoError(t, err)
+
+	var found bool
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		if m.Name() != "traces_service_graph_request_messaging_system_seconds" {
+			continue
+		}
+		found = true
+		dp := m.Histogram().DataPoints().At(0)
+		assert.Equal(t, float64(2000), dp.Sum())
+		assert.Equal(t, uint64(1), dp.Count())
+		verifyAttr(t, dp.Attributes(), "client", "producer-service")
+		verifyAttr(t, dp.Attributes(), "server", "consumer-service")
+		verifyAttr(t, dp.Attributes(), "connection_type", "messaging_system")
+	}
+	assert.True(t, found)
+
+	assert.NoError(t, conn.Shutdown(context.Background()))
+}
+
+func TestConnectorConsumeDatabase(t *testing.T) {
+	cfg := &Config{
+		Store: StoreConfig{MaxItems: 10},
+	}
+
+	conn := newProcessor(zaptest.NewLogger(t), cfg)
+	conn.metricsConsumer = newMockMetricsExporter()
+	assert.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	traces := ptrace.NewTraces()
+	rs := traces.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(semconv.AttributeServiceName, "some-service")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetSpanID(pcommon.SpanID([8]byte{1, 2, 3, 4}))
+	span.SetTraceID(pcommon.TraceID([16]byte{1, 2, 3, 4}))
+	span.SetKind(ptrace.SpanKindClient)
+	// Without db.name, the database system is used as server.
+	span.Attributes().PutStr(semconv.AttributeDBSystem, "postgresql")
+
+	assert.NoError(t, conn.ConsumeTraces(context.Background(), traces))
+
+	md, err := conn.buildMetrics()
+	require.NoError(t, err)
+	dp := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	verifyAttr(t, dp.Attributes(), "client", "some-service")
+	verifyAttr(t, dp.Attributes(), "server", "postgresql")
+	verifyAttr(t, dp.Attributes(), "connection_type", "database")
+
+	assert.NoError(t, conn.Shutdown(context.Background()))
+}
+
 func verifyHappyCaseMetrics(t *testing.T, md pmetric.Metrics) {
 	assert.Equal(t, 3, md.MetricCount())
 
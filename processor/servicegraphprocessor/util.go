@@ -17,6 +17,15 @@ func findAttributeValue(key string, attributes ...pcommon.Map) (string, bool) {
 	return "", false
 }
 
+// findDatabaseName returns the name of the database called by a client span, falling back
+// to the database system when the span doesn't record the database name.
+func findDatabaseName(attributes ...pcommon.Map) (string, bool) {
+	if dbName, ok := findAttributeValue(semconv.AttributeDBName, attributes...); ok {
+		return dbName, true
+	}
+	return findAttributeValue(semconv.AttributeDBSystem, attributes...)
+}
+
 func findServiceName(attributes pcommon.Map) (string, bool) {
 	return findAttributeValue(semconv.AttributeServiceName, attributes)
 }
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// maxBodySize is the maximum size of the response body read to check the assertions.
+const maxBodySize = 1 << 20
+
+// failure is a reason why a check failed, with the details of the failure.
+type failure struct {
+	reason metadata.AttributeFailureReason
+	detail string
+}
+
+// statusCodeRange is an inclusive range of accepted status codes.
+type statusCodeRange struct {
+	min, max int
+}
+
+type compiledHeaderAssertion struct {
+	name       string
+	valueRegex *regexp.Regexp
+}
+
+// assertions checks the responses against the assertions of a target.
+type assertions struct {
+	statusCodes []statusCodeRange
+	bodyRegex   *regexp.Regexp
+	jsonPaths   []jsonPathAssertion
+	headers     []compiledHeaderAssertion
+}
+
+func newAssertions(cfg assertionsConfig) (*assertions, error) {
+	var errs error
+	a := &assertions{
+		jsonPaths: cfg.JSONPaths,
+	}
+
+	for _, codes := range cfg.StatusCodes {
+		r, err := parseStatusCodes(codes)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		a.statusCodes = append(a.statusCodes, r)
+	}
+	if len(cfg.StatusCodes) == 0 {
+		a.statusCodes = []statusCodeRange{{min: 100, max: 399}}
+	}
+
+	if cfg.BodyRegex != "" {
+		var err error
+		if a.bodyRegex, err = regexp.Compile(cfg.BodyRegex); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("invalid body_regex: %w", err))
+		}
+	}
+
+	for _, jsonPath := range cfg.JSONPaths {
+		if jsonPath.Path == "" {
+			errs = multierr.Append(errs, errors.New("json_paths: path must be specified"))
+		}
+	}
+
+	for _, header := range cfg.Headers {
+		if header.Name == "" {
+			errs = multierr.Append(errs, errors.New("headers: name must be specified"))
+			continue
+		}
+		h := compiledHeaderAssertion{name: header.Name}
+		if header.ValueRegex != "" {
+			var err error
+			if h.valueRegex, err = regexp.Compile(header.ValueRegex); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("invalid value_regex for header %q: %w", header.Name, err))
+				continue
+			}
+		}
+		a.headers = append(a.headers, h)
+	}
+
+	return a, errs
+}
+
+// parseStatusCodes parses a status code ("200"), a status class ("2xx") or a range of status codes ("200-204").
+func parseStatusCodes(codes string) (statusCodeRange, error) {
+	invalid := fmt.Errorf("invalid status code %q, must be a status code, a status class or a range of status codes", codes)
+
+	if len(codes) == 3 && strings.HasSuffix(strings.ToLower(codes), "xx") {
+		class := int(codes[0] - '0')
+		if class < 1 || class > 5 {
+			return statusCodeRange{}, invalid
+		}
+		return statusCodeRange{min: class * 100, max: class*100 + 99}, nil
+	}
+
+	minStr, maxStr, isRange := strings.Cut(codes, "-")
+	if !isRange {
+		maxStr = minStr
+	}
+	min, err := strconv.Atoi(minStr)
+	if err != nil {
+		return statusCodeRange{}, invalid
+	}
+	max, err := strconv.Atoi(maxStr)
+	if err != nil {
+		return statusCodeRange{}, invalid
+	}
+	if min < 100 || max > 599 || min > max {
+		return statusCodeRange{}, invalid
+	}
+	return statusCodeRange{min: min, max: max}, nil
+}
+
+// needsBody returns whether the response body has to be read to check the assertions.
+func (a *assertions) needsBody() bool {
+	return a.bodyRegex != nil || len(a.jsonPaths) > 0
+}
+
+// check returns the failures of the assertions the response does not satisfy,
+// or nil if it satisfies all of them.
+func (a *assertions) check(resp *http.Response, body []byte) []failure {
+	var failures []failure
+
+	if !a.statusCodeAccepted(resp.StatusCode) {
+		failures = append(failures, failure{metadata.AttributeFailureReasonStatusCode, fmt.Sprintf("status code %d not accepted", resp.StatusCode)})
+	}
+
+	if a.bodyRegex != nil && !a.bodyRegex.Match(body) {
+		failures = append(failures, failure{metadata.AttributeFailureReasonBody, fmt.Sprintf("body does not match %q", a.bodyRegex)})
+	}
+
+	if len(a.jsonPaths) > 0 {
+		if !gjson.ValidBytes(body) {
+			failures = append(failures, failure{metadata.AttributeFailureReasonJSONPath, "body is not valid JSON"})
+		} else {
+			for _, jsonPath := range a.jsonPaths {
+				result := gjson.GetBytes(body, jsonPath.Path)
+				switch {
+				case !result.Exists():
+					failures = append(failures, failure{metadata.AttributeFailureReasonJSONPath, fmt.Sprintf("JSON path %q not found", jsonPath.Path)})
+				case jsonPath.Value != "" && result.String() != jsonPath.Value:
+					failures = append(failures, failure{metadata.AttributeFailureReasonJSONPath, fmt.Sprintf("JSON path %q is %q, expected %q", jsonPath.Path, result.String(), jsonPath.Value)})
+				}
+			}
+		}
+	}
+
+	for _, header := range a.headers {
+		values := resp.Header.Values(header.name)
+		if len(values) == 0 {
+			failures = append(failures, failure{metadata.AttributeFailureReasonHeader, fmt.Sprintf("header %q missing", header.name)})
+			continue
+		}
+		if header.valueRegex != nil && !anyMatch(header.valueRegex, values) {
+			failures = append(failures, failure{metadata.AttributeFailureReasonHeader, fmt.Sprintf("header %q does not match %q", header.name, header.valueRegex)})
+		}
+	}
+
+	return failures
+}
+
+func (a *assertions) statusCodeAccepted(statusCode int) bool {
+	for _, r := range a.statusCodes {
+		if statusCode >= r.min && statusCode <= r.max {
+			return true
+		}
+	}
+	return false
+}
+
+func anyMatch(re *regexp.Regexp, values []string) bool {
+	for _, v := range values {
+		if re.MatchString(v) {
+			return true
+		}
+	}
+	return false
+}
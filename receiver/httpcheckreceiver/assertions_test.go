@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+func TestParseStatusCodes(t *testing.T) {
+	testCases := []struct {
+		codes       string
+		expected    statusCodeRange
+		expectedErr bool
+	}{
+		{codes: "200", expected: statusCodeRange{min: 200, max: 200}},
+		{codes: "2xx", expected: statusCodeRange{min: 200, max: 299}},
+		{codes: "4XX", expected: statusCodeRange{min: 400, max: 499}},
+		{codes: "301-302", expected: statusCodeRange{min: 301, max: 302}},
+		{codes: "6xx", expectedErr: true},
+		{codes: "99", expectedErr: true},
+		{codes: "302-301", expectedErr: true},
+		{codes: "ok", expectedErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.codes, func(t *testing.T) {
+			actual, err := parseStatusCodes(tc.codes)
+			if tc.expectedErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestAssertionsCheck(t *testing.T) {
+	body := []byte(`{"status":"UP","checks":[{"name":"db","status":"DOWN"}]}`)
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+
+	testCases := []struct {
+		desc       string
+		cfg        assertionsConfig
+		statusCode int
+		body       []byte
+		expected   []failure
+	}{
+		{
+			desc:       "default status codes",
+			statusCode: 302,
+		},
+		{
+			desc:       "default status codes failing",
+			statusCode: 500,
+			expected:   []failure{{metadata.AttributeFailureReasonStatusCode, "status code 500 not accepted"}},
+		},
+		{
+			desc:       "status codes",
+			cfg:        assertionsConfig{StatusCodes: []string{"200", "5xx"}},
+			statusCode: 503,
+		},
+		{
+			desc:       "all satisfied",
+			cfg:        assertionsConfig{BodyRegex: `"status":"UP"`, JSONPaths: []jsonPathAssertion{{Path: "status", Value: "UP"}, {Path: "checks.0.name"}}, Headers: []headerAssertion{{Name: "content-type", ValueRegex: "json"}}},
+			statusCode: 200,
+			body:       body,
+		},
+		{
+			desc: "all failing",
+			cfg: assertionsConfig{
+				StatusCodes: []string{"2xx"},
+				BodyRegex:   "healthy",
+				JSONPaths:   []jsonPathAssertion{{Path: "checks.0.status", Value: "UP"}, {Path: "version"}},
+				Headers:     []headerAssertion{{Name: "Content-Type", ValueRegex: "^text/"}, {Name: "X-Request-Id"}},
+			},
+			statusCode: 404,
+			body:       body,
+			expected: []failure{
+				{metadata.AttributeFailureReasonStatusCode, "status code 404 not accepted"},
+				{metadata.AttributeFailureReasonBody, `body does not match "healthy"`},
+				{metadata.AttributeFailureReasonJSONPath, `JSON path "checks.0.status" is "DOWN", expected "UP"`},
+				{metadata.AttributeFailureReasonJSONPath, `JSON path "version" not found`},
+				{metadata.AttributeFailureReasonHeader, `header "Content-Type" does not match "^text/"`},
+				{metadata.AttributeFailureReasonHeader, `header "X-Request-Id" missing`},
+			},
+		},
+		{
+			desc:       "invalid JSON",
+			cfg:        assertionsConfig{JSONPaths: []jsonPathAssertion{{Path: "status"}}},
+			statusCode: 200,
+			body:       []byte("OK"),
+			expected:   []failure{{metadata.AttributeFailureReasonJSONPath, "body is not valid JSON"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			a, err := newAssertions(tc.cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, a.check(&http.Response{StatusCode: tc.statusCode, Header: header}, tc.body))
+		})
+	}
+}
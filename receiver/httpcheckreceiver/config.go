@@ -31,6 +31,38 @@ type Config struct {
 type targetConfig struct {
 	confighttp.HTTPClientSettings `mapstructure:",squash"`
 	Method                        string `mapstructure:"method"`
+	// Body is sent as the body of the request.
+	Body string `mapstructure:"body"`
+	// Assertions are the conditions the response must satisfy for the check to succeed.
+	Assertions assertionsConfig `mapstructure:"assertions"`
+}
+
+type assertionsConfig struct {
+	// StatusCodes lists the accepted status codes, each one either a status code ("200"),
+	// a status class ("2xx") or a range of status codes ("200-204").
+	// Status codes below 400 are accepted if not set.
+	StatusCodes []string `mapstructure:"status_codes"`
+	// BodyRegex is a regular expression the response body must match.
+	BodyRegex string `mapstructure:"body_regex"`
+	// JSONPaths lists values the response body, parsed as JSON, must contain.
+	JSONPaths []jsonPathAssertion `mapstructure:"json_paths"`
+	// Headers lists the headers the response must contain.
+	Headers []headerAssertion `mapstructure:"headers"`
+}
+
+type jsonPathAssertion struct {
+	// Path to the value, using the GJSON path syntax, e.g. "checks.0.status".
+	Path string `mapstructure:"path"`
+	// Value is the expected value. The path only has to exist if not set.
+	Value string `mapstructure:"value"`
+}
+
+type headerAssertion struct {
+	// Name of the header.
+	Name string `mapstructure:"name"`
+	// ValueRegex is a regular expression the value of the header must match.
+	// The header only has to be present if not set.
+	ValueRegex string `mapstructure:"value_regex"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
@@ -46,6 +78,10 @@ func (cfg *targetConfig) Validate() error {
 		}
 	}
 
+	if _, assertionsErr := newAssertions(cfg.Assertions); assertionsErr != nil {
+		err = multierr.Append(err, assertionsErr)
+	}
+
 	return err
 }
 
@@ -4,6 +4,7 @@
 package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
@@ -81,6 +82,31 @@ func TestValidate(t *testing.T) {
 				fmt.Errorf("%w: %s", errInvalidEndpoint, `parse "www.opentelemetry.io/docs": invalid URI for request`),
 			),
 		},
+		{
+			desc: "invalid assertions",
+			cfg: &Config{
+				Targets: []*targetConfig{
+					{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "https://opentelemetry.io",
+						},
+						Assertions: assertionsConfig{
+							StatusCodes: []string{"2xx", "600", "204-200"},
+							BodyRegex:   "(",
+							JSONPaths:   []jsonPathAssertion{{Value: "UP"}},
+							Headers:     []headerAssertion{{ValueRegex: "json"}},
+						},
+					},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errors.New(`invalid status code "600", must be a status code, a status class or a range of status codes`),
+				errors.New(`invalid status code "204-200", must be a status code, a status class or a range of status codes`),
+				errors.New("invalid body_regex: error parsing regexp: missing closing ): `(`"),
+				errors.New("json_paths: path must be specified"),
+				errors.New("headers: name must be specified"),
+			),
+		},
 		{
 			desc: "valid config",
 			cfg: &Config{
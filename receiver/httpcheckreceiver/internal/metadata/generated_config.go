@@ -25,9 +25,13 @@ func (ms *MetricConfig) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsConfig provides config for httpcheck metrics.
 type MetricsConfig struct {
-	HttpcheckDuration MetricConfig `mapstructure:"httpcheck.duration"`
-	HttpcheckError    MetricConfig `mapstructure:"httpcheck.error"`
-	HttpcheckStatus   MetricConfig `mapstructure:"httpcheck.status"`
+	HttpcheckDuration      MetricConfig `mapstructure:"httpcheck.duration"`
+	HttpcheckError         MetricConfig `mapstructure:"httpcheck.error"`
+	HttpcheckFailure       MetricConfig `mapstructure:"httpcheck.failure"`
+	HttpcheckPhaseDuration MetricConfig `mapstructure:"httpcheck.phase.duration"`
+	HttpcheckStatus        MetricConfig `mapstructure:"httpcheck.status"`
+	HttpcheckSuccess       MetricConfig `mapstructure:"httpcheck.success"`
+	HttpcheckTLSCertExpiry MetricConfig `mapstructure:"httpcheck.tls.cert_expiry"`
 }
 
 func DefaultMetricsConfig() MetricsConfig {
@@ -38,9 +42,21 @@ func DefaultMetricsConfig() MetricsConfig {
 		HttpcheckError: MetricConfig{
 			Enabled: true,
 		},
+		HttpcheckFailure: MetricConfig{
+			Enabled: true,
+		},
+		HttpcheckPhaseDuration: MetricConfig{
+			Enabled: false,
+		},
 		HttpcheckStatus: MetricConfig{
 			Enabled: true,
 		},
+		HttpcheckSuccess: MetricConfig{
+			Enabled: true,
+		},
+		HttpcheckTLSCertExpiry: MetricConfig{
+			Enabled: true,
+		},
 	}
 }
 
@@ -26,9 +26,13 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "all_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					HttpcheckDuration: MetricConfig{Enabled: true},
-					HttpcheckError:    MetricConfig{Enabled: true},
-					HttpcheckStatus:   MetricConfig{Enabled: true},
+					HttpcheckDuration:      MetricConfig{Enabled: true},
+					HttpcheckError:         MetricConfig{Enabled: true},
+					HttpcheckFailure:       MetricConfig{Enabled: true},
+					HttpcheckPhaseDuration: MetricConfig{Enabled: true},
+					HttpcheckStatus:        MetricConfig{Enabled: true},
+					HttpcheckSuccess:       MetricConfig{Enabled: true},
+					HttpcheckTLSCertExpiry: MetricConfig{Enabled: true},
 				},
 			},
 		},
@@ -36,9 +40,13 @@ func TestMetricsBuilderConfig(t *testing.T) {
 			name: "none_set",
 			want: MetricsBuilderConfig{
 				Metrics: MetricsConfig{
-					HttpcheckDuration: MetricConfig{Enabled: false},
-					HttpcheckError:    MetricConfig{Enabled: false},
-					HttpcheckStatus:   MetricConfig{Enabled: false},
+					HttpcheckDuration:      MetricConfig{Enabled: false},
+					HttpcheckError:         MetricConfig{Enabled: false},
+					HttpcheckFailure:       MetricConfig{Enabled: false},
+					HttpcheckPhaseDuration: MetricConfig{Enabled: false},
+					HttpcheckStatus:        MetricConfig{Enabled: false},
+					HttpcheckSuccess:       MetricConfig{Enabled: false},
+					HttpcheckTLSCertExpiry: MetricConfig{Enabled: false},
 				},
 			},
 		},
@@ -11,6 +11,82 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 )
 
+// AttributeFailureReason specifies the a value failure.reason attribute.
+type AttributeFailureReason int
+
+const (
+	_ AttributeFailureReason = iota
+	AttributeFailureReasonRequest
+	AttributeFailureReasonTimeout
+	AttributeFailureReasonStatusCode
+	AttributeFailureReasonBody
+	AttributeFailureReasonJSONPath
+	AttributeFailureReasonHeader
+)
+
+// String returns the string representation of the AttributeFailureReason.
+func (av AttributeFailureReason) String() string {
+	switch av {
+	case AttributeFailureReasonRequest:
+		return "request"
+	case AttributeFailureReasonTimeout:
+		return "timeout"
+	case AttributeFailureReasonStatusCode:
+		return "status_code"
+	case AttributeFailureReasonBody:
+		return "body"
+	case AttributeFailureReasonJSONPath:
+		return "json_path"
+	case AttributeFailureReasonHeader:
+		return "header"
+	}
+	return ""
+}
+
+// MapAttributeFailureReason is a helper map of string to AttributeFailureReason attribute value.
+var MapAttributeFailureReason = map[string]AttributeFailureReason{
+	"request":     AttributeFailureReasonRequest,
+	"timeout":     AttributeFailureReasonTimeout,
+	"status_code": AttributeFailureReasonStatusCode,
+	"body":        AttributeFailureReasonBody,
+	"json_path":   AttributeFailureReasonJSONPath,
+	"header":      AttributeFailureReasonHeader,
+}
+
+// AttributeHTTPPhase specifies the a value http.phase attribute.
+type AttributeHTTPPhase int
+
+const (
+	_ AttributeHTTPPhase = iota
+	AttributeHTTPPhaseDNSLookup
+	AttributeHTTPPhaseTCPConnect
+	AttributeHTTPPhaseTLSHandshake
+	AttributeHTTPPhaseTimeToFirstByte
+)
+
+// String returns the string representation of the AttributeHTTPPhase.
+func (av AttributeHTTPPhase) String() string {
+	switch av {
+	case AttributeHTTPPhaseDNSLookup:
+		return "dns_lookup"
+	case AttributeHTTPPhaseTCPConnect:
+		return "tcp_connect"
+	case AttributeHTTPPhaseTLSHandshake:
+		return "tls_handshake"
+	case AttributeHTTPPhaseTimeToFirstByte:
+		return "time_to_first_byte"
+	}
+	return ""
+}
+
+// MapAttributeHTTPPhase is a helper map of string to AttributeHTTPPhase attribute value.
+var MapAttributeHTTPPhase = map[string]AttributeHTTPPhase{
+	"dns_lookup":         AttributeHTTPPhaseDNSLookup,
+	"tcp_connect":        AttributeHTTPPhaseTCPConnect,
+	"tls_handshake":      AttributeHTTPPhaseTLSHandshake,
+	"time_to_first_byte": AttributeHTTPPhaseTimeToFirstByte,
+}
+
 type metricHttpcheckDuration struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -116,6 +192,110 @@ func newMetricHttpcheckError(cfg MetricConfig) metricHttpcheckError {
 	return m
 }
 
+type metricHttpcheckFailure struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.failure metric with initial data.
+func (m *metricHttpcheckFailure) init() {
+	m.data.SetName("httpcheck.failure")
+	m.data.SetDescription("1 if the check failed for the failure.reason, otherwise 0. The details of the failures are logged.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckFailure) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, failureReasonAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("failure.reason", failureReasonAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckFailure) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckFailure) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckFailure(cfg MetricConfig) metricHttpcheckFailure {
+	m := metricHttpcheckFailure{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckPhaseDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.phase.duration metric with initial data.
+func (m *metricHttpcheckPhaseDuration) init() {
+	m.data.SetName("httpcheck.phase.duration")
+	m.data.SetDescription("Measures the duration of each phase of the HTTP request. Phases are only reported when they occur, DNS lookup and TCP connect are skipped when a connection is reused.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckPhaseDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.phase", httpPhaseAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckPhaseDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckPhaseDuration) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckPhaseDuration(cfg MetricConfig) metricHttpcheckPhaseDuration {
+	m := metricHttpcheckPhaseDuration{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckStatus struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	config   MetricConfig   // metric config provided by user.
@@ -172,17 +352,123 @@ func newMetricHttpcheckStatus(cfg MetricConfig) metricHttpcheckStatus {
 	return m
 }
 
+type metricHttpcheckSuccess struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.success metric with initial data.
+func (m *metricHttpcheckSuccess) init() {
+	m.data.SetName("httpcheck.success")
+	m.data.SetDescription("1 if the request succeeded and the response satisfied all the assertions, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckSuccess) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckSuccess) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckSuccess) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckSuccess(cfg MetricConfig) metricHttpcheckSuccess {
+	m := metricHttpcheckSuccess{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckTLSCertExpiry struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	config   MetricConfig   // metric config provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.tls.cert_expiry metric with initial data.
+func (m *metricHttpcheckTLSCertExpiry) init() {
+	m.data.SetName("httpcheck.tls.cert_expiry")
+	m.data.SetDescription("Number of days until the TLS certificate presented by the endpoint expires.")
+	m.data.SetUnit("d")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckTLSCertExpiry) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.config.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTLSCertExpiry) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTLSCertExpiry) emit(metrics pmetric.MetricSlice) {
+	if m.config.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTLSCertExpiry(cfg MetricConfig) metricHttpcheckTLSCertExpiry {
+	m := metricHttpcheckTLSCertExpiry{config: cfg}
+	if cfg.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user config.
 type MetricsBuilder struct {
-	startTime               pcommon.Timestamp   // start time that will be applied to all recorded data points.
-	metricsCapacity         int                 // maximum observed number of metrics per resource.
-	resourceCapacity        int                 // maximum observed number of resource attributes.
-	metricsBuffer           pmetric.Metrics     // accumulates metrics data before emitting.
-	buildInfo               component.BuildInfo // contains version information
-	metricHttpcheckDuration metricHttpcheckDuration
-	metricHttpcheckError    metricHttpcheckError
-	metricHttpcheckStatus   metricHttpcheckStatus
+	startTime                    pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity              int                 // maximum observed number of metrics per resource.
+	resourceCapacity             int                 // maximum observed number of resource attributes.
+	metricsBuffer                pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                    component.BuildInfo // contains version information
+	metricHttpcheckDuration      metricHttpcheckDuration
+	metricHttpcheckError         metricHttpcheckError
+	metricHttpcheckFailure       metricHttpcheckFailure
+	metricHttpcheckPhaseDuration metricHttpcheckPhaseDuration
+	metricHttpcheckStatus        metricHttpcheckStatus
+	metricHttpcheckSuccess       metricHttpcheckSuccess
+	metricHttpcheckTLSCertExpiry metricHttpcheckTLSCertExpiry
 }
 
 // metricBuilderOption applies changes to default metrics builder.
@@ -197,12 +483,16 @@ func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
 
 func NewMetricsBuilder(mbc MetricsBuilderConfig, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		startTime:               pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:           pmetric.NewMetrics(),
-		buildInfo:               settings.BuildInfo,
-		metricHttpcheckDuration: newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
-		metricHttpcheckError:    newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
-		metricHttpcheckStatus:   newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
+		startTime:                    pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                pmetric.NewMetrics(),
+		buildInfo:                    settings.BuildInfo,
+		metricHttpcheckDuration:      newMetricHttpcheckDuration(mbc.Metrics.HttpcheckDuration),
+		metricHttpcheckError:         newMetricHttpcheckError(mbc.Metrics.HttpcheckError),
+		metricHttpcheckFailure:       newMetricHttpcheckFailure(mbc.Metrics.HttpcheckFailure),
+		metricHttpcheckPhaseDuration: newMetricHttpcheckPhaseDuration(mbc.Metrics.HttpcheckPhaseDuration),
+		metricHttpcheckStatus:        newMetricHttpcheckStatus(mbc.Metrics.HttpcheckStatus),
+		metricHttpcheckSuccess:       newMetricHttpcheckSuccess(mbc.Metrics.HttpcheckSuccess),
+		metricHttpcheckTLSCertExpiry: newMetricHttpcheckTLSCertExpiry(mbc.Metrics.HttpcheckTLSCertExpiry),
 	}
 	for _, op := range options {
 		op(mb)
@@ -257,7 +547,11 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
 	mb.metricHttpcheckDuration.emit(ils.Metrics())
 	mb.metricHttpcheckError.emit(ils.Metrics())
+	mb.metricHttpcheckFailure.emit(ils.Metrics())
+	mb.metricHttpcheckPhaseDuration.emit(ils.Metrics())
 	mb.metricHttpcheckStatus.emit(ils.Metrics())
+	mb.metricHttpcheckSuccess.emit(ils.Metrics())
+	mb.metricHttpcheckTLSCertExpiry.emit(ils.Metrics())
 
 	for _, op := range rmo {
 		op(rm)
@@ -288,11 +582,31 @@ func (mb *MetricsBuilder) RecordHttpcheckErrorDataPoint(ts pcommon.Timestamp, va
 	mb.metricHttpcheckError.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, errorMessageAttributeValue)
 }
 
+// RecordHttpcheckFailureDataPoint adds a data point to httpcheck.failure metric.
+func (mb *MetricsBuilder) RecordHttpcheckFailureDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, failureReasonAttributeValue AttributeFailureReason) {
+	mb.metricHttpcheckFailure.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, failureReasonAttributeValue.String())
+}
+
+// RecordHttpcheckPhaseDurationDataPoint adds a data point to httpcheck.phase.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckPhaseDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue AttributeHTTPPhase) {
+	mb.metricHttpcheckPhaseDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpPhaseAttributeValue.String())
+}
+
 // RecordHttpcheckStatusDataPoint adds a data point to httpcheck.status metric.
 func (mb *MetricsBuilder) RecordHttpcheckStatusDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpStatusCodeAttributeValue int64, httpMethodAttributeValue string, httpStatusClassAttributeValue string) {
 	mb.metricHttpcheckStatus.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpStatusCodeAttributeValue, httpMethodAttributeValue, httpStatusClassAttributeValue)
 }
 
+// RecordHttpcheckSuccessDataPoint adds a data point to httpcheck.success metric.
+func (mb *MetricsBuilder) RecordHttpcheckSuccessDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckSuccess.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
+// RecordHttpcheckTLSCertExpiryDataPoint adds a data point to httpcheck.tls.cert_expiry metric.
+func (mb *MetricsBuilder) RecordHttpcheckTLSCertExpiryDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckTLSCertExpiry.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
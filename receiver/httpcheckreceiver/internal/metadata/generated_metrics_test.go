@@ -62,10 +62,25 @@ func TestMetricsBuilder(t *testing.T) {
 			allMetricsCount++
 			mb.RecordHttpcheckErrorDataPoint(ts, 1, "http.url-val", "error.message-val")
 
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckFailureDataPoint(ts, 1, "http.url-val", AttributeFailureReasonRequest)
+
+			allMetricsCount++
+			mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "http.url-val", AttributeHTTPPhaseDNSLookup)
+
 			defaultMetricsCount++
 			allMetricsCount++
 			mb.RecordHttpcheckStatusDataPoint(ts, 1, "http.url-val", 16, "http.method-val", "http.status_class-val")
 
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckSuccessDataPoint(ts, 1, "http.url-val")
+
+			defaultMetricsCount++
+			allMetricsCount++
+			mb.RecordHttpcheckTLSCertExpiryDataPoint(ts, 1, "http.url-val")
+
 			metrics := mb.Emit()
 
 			if test.configSet == testSetNone {
@@ -126,6 +141,42 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("error.message")
 					assert.True(t, ok)
 					assert.EqualValues(t, "error.message-val", attrVal.Str())
+				case "httpcheck.failure":
+					assert.False(t, validatedMetrics["httpcheck.failure"], "Found a duplicate in the metrics slice: httpcheck.failure")
+					validatedMetrics["httpcheck.failure"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "1 if the check failed for the failure.reason, otherwise 0. The details of the failures are logged.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.EqualValues(t, "http.url-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("failure.reason")
+					assert.True(t, ok)
+					assert.EqualValues(t, "request", attrVal.Str())
+				case "httpcheck.phase.duration":
+					assert.False(t, validatedMetrics["httpcheck.phase.duration"], "Found a duplicate in the metrics slice: httpcheck.phase.duration")
+					validatedMetrics["httpcheck.phase.duration"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Measures the duration of each phase of the HTTP request. Phases are only reported when they occur, DNS lookup and TCP connect are skipped when a connection is reused.", ms.At(i).Description())
+					assert.Equal(t, "ms", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.EqualValues(t, "http.url-val", attrVal.Str())
+					attrVal, ok = dp.Attributes().Get("http.phase")
+					assert.True(t, ok)
+					assert.EqualValues(t, "dns_lookup", attrVal.Str())
 				case "httpcheck.status":
 					assert.False(t, validatedMetrics["httpcheck.status"], "Found a duplicate in the metrics slice: httpcheck.status")
 					validatedMetrics["httpcheck.status"] = true
@@ -152,6 +203,36 @@ func TestMetricsBuilder(t *testing.T) {
 					attrVal, ok = dp.Attributes().Get("http.status_class")
 					assert.True(t, ok)
 					assert.EqualValues(t, "http.status_class-val", attrVal.Str())
+				case "httpcheck.success":
+					assert.False(t, validatedMetrics["httpcheck.success"], "Found a duplicate in the metrics slice: httpcheck.success")
+					validatedMetrics["httpcheck.success"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "1 if the request succeeded and the response satisfied all the assertions, otherwise 0.", ms.At(i).Description())
+					assert.Equal(t, "1", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.EqualValues(t, "http.url-val", attrVal.Str())
+				case "httpcheck.tls.cert_expiry":
+					assert.False(t, validatedMetrics["httpcheck.tls.cert_expiry"], "Found a duplicate in the metrics slice: httpcheck.tls.cert_expiry")
+					validatedMetrics["httpcheck.tls.cert_expiry"] = true
+					assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+					assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+					assert.Equal(t, "Number of days until the TLS certificate presented by the endpoint expires.", ms.At(i).Description())
+					assert.Equal(t, "d", ms.At(i).Unit())
+					dp := ms.At(i).Gauge().DataPoints().At(0)
+					assert.Equal(t, start, dp.StartTimestamp())
+					assert.Equal(t, ts, dp.Timestamp())
+					assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+					assert.Equal(t, int64(1), dp.IntValue())
+					attrVal, ok := dp.Attributes().Get("http.url")
+					assert.True(t, ok)
+					assert.EqualValues(t, "http.url-val", attrVal.Str())
 				}
 			}
 		})
@@ -6,7 +6,12 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptrace"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,10 +31,11 @@ var (
 )
 
 type httpcheckScraper struct {
-	clients  []*http.Client
-	cfg      *Config
-	settings component.TelemetrySettings
-	mb       *metadata.MetricsBuilder
+	clients    []*http.Client
+	assertions []*assertions
+	cfg        *Config
+	settings   component.TelemetrySettings
+	mb         *metadata.MetricsBuilder
 }
 
 // start starts the scraper by creating a new HTTP Client on the scraper
@@ -40,6 +46,12 @@ func (h *httpcheckScraper) start(_ context.Context, host component.Host) (err er
 			err = multierr.Append(err, clentErr)
 		}
 		h.clients = append(h.clients, client)
+
+		targetAssertions, assertionsErr := newAssertions(target.Assertions)
+		if assertionsErr != nil {
+			err = multierr.Append(err, assertionsErr)
+		}
+		h.assertions = append(h.assertions, targetAssertions)
 	}
 	return
 }
@@ -60,16 +72,31 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 
 			now := pcommon.NewTimestampFromTime(time.Now())
 
-			req, err := http.NewRequestWithContext(ctx, h.cfg.Targets[targetIndex].Method, h.cfg.Targets[targetIndex].Endpoint, http.NoBody)
+			var body io.Reader = http.NoBody
+			if h.cfg.Targets[targetIndex].Body != "" {
+				body = strings.NewReader(h.cfg.Targets[targetIndex].Body)
+			}
+			req, err := http.NewRequestWithContext(ctx, h.cfg.Targets[targetIndex].Method, h.cfg.Targets[targetIndex].Endpoint, body)
 			if err != nil {
 				h.settings.Logger.Error("failed to create request", zap.Error(err))
 				return
 			}
+			timer := newPhaseTimer()
+			req = req.WithContext(httptrace.WithClientTrace(req.Context(), timer.clientTrace()))
 
 			start := time.Now()
 			resp, err := targetClient.Do(req)
+			duration := time.Since(start)
+
+			var failures []failure
+			if err != nil {
+				failures = []failure{requestFailure(err)}
+			} else {
+				failures = checkResponse(resp, h.assertions[targetIndex])
+			}
+
 			mux.Lock()
-			h.mb.RecordHttpcheckDurationDataPoint(now, time.Since(start).Milliseconds(), h.cfg.Targets[targetIndex].Endpoint)
+			h.mb.RecordHttpcheckDurationDataPoint(now, duration.Milliseconds(), h.cfg.Targets[targetIndex].Endpoint)
 
 			statusCode := 0
 			if err != nil {
@@ -78,6 +105,33 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 				statusCode = resp.StatusCode
 			}
 
+			for phase, phaseDuration := range timer.phases() {
+				h.mb.RecordHttpcheckPhaseDurationDataPoint(now, phaseDuration.Milliseconds(), h.cfg.Targets[targetIndex].Endpoint, phase)
+			}
+
+			if resp != nil && resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+				h.mb.RecordHttpcheckTLSCertExpiryDataPoint(now, daysUntil(resp.TLS.PeerCertificates[0].NotAfter), h.cfg.Targets[targetIndex].Endpoint)
+			}
+
+			failed := make(map[metadata.AttributeFailureReason]bool, len(failures))
+			for _, f := range failures {
+				failed[f.reason] = true
+				h.settings.Logger.Info("check failed", zap.String("endpoint", h.cfg.Targets[targetIndex].Endpoint),
+					zap.String("reason", f.reason.String()), zap.String("detail", f.detail))
+			}
+			if len(failures) == 0 {
+				h.mb.RecordHttpcheckSuccessDataPoint(now, int64(1), h.cfg.Targets[targetIndex].Endpoint)
+			} else {
+				h.mb.RecordHttpcheckSuccessDataPoint(now, int64(0), h.cfg.Targets[targetIndex].Endpoint)
+			}
+			for _, reason := range metadata.MapAttributeFailureReason {
+				if failed[reason] {
+					h.mb.RecordHttpcheckFailureDataPoint(now, int64(1), h.cfg.Targets[targetIndex].Endpoint, reason)
+				} else {
+					h.mb.RecordHttpcheckFailureDataPoint(now, int64(0), h.cfg.Targets[targetIndex].Endpoint, reason)
+				}
+			}
+
 			for class, intVal := range httpResponseClasses {
 				if statusCode/100 == intVal {
 					h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), h.cfg.Targets[targetIndex].Endpoint, int64(statusCode), req.Method, class)
@@ -94,6 +148,39 @@ func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error)
 	return h.mb.Emit(), nil
 }
 
+// requestFailure returns the failure of a request which did not get a response.
+func requestFailure(err error) failure {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return failure{metadata.AttributeFailureReasonTimeout, err.Error()}
+	}
+	return failure{metadata.AttributeFailureReasonRequest, err.Error()}
+}
+
+// checkResponse checks the response against the assertions, and returns the failures
+// of the assertions it does not satisfy. The response body is consumed and closed.
+func checkResponse(resp *http.Response, a *assertions) []failure {
+	defer func() {
+		// Draining the body allows the connection to be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodySize))
+		_ = resp.Body.Close()
+	}()
+
+	var body []byte
+	if a.needsBody() {
+		var err error
+		if body, err = io.ReadAll(io.LimitReader(resp.Body, maxBodySize)); err != nil {
+			return []failure{{metadata.AttributeFailureReasonBody, fmt.Sprintf("failed to read body: %v", err)}}
+		}
+	}
+	return a.check(resp, body)
+}
+
+// daysUntil returns the number of whole days until t, negative if t is in the past.
+func daysUntil(t time.Time) int64 {
+	return int64(time.Until(t).Hours() / 24)
+}
+
 func newScraper(conf *Config, settings receiver.CreateSettings) *httpcheckScraper {
 	return &httpcheckScraper{
 		cfg:      conf,
@@ -5,6 +5,7 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/config/configtls"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
@@ -233,3 +235,120 @@ func TestScraperMultipleTargets(t *testing.T) {
 		pmetrictest.IgnoreTimestamp(),
 	))
 }
+
+func TestScraperAssertions(t *testing.T) {
+	ms := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		if req.Method != http.MethodPost || req.Header.Get("Authorization") != "Bearer token" || string(body) != `{"query":"ping"}` {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		_, err = rw.Write([]byte(`{"status":"DOWN"}`))
+		require.NoError(t, err)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = []*targetConfig{{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ms.URL,
+			Headers:  map[string]configopaque.String{"Authorization": "Bearer token"},
+		},
+		Method: http.MethodPost,
+		Body:   `{"query":"ping"}`,
+		Assertions: assertionsConfig{
+			StatusCodes: []string{"200"},
+			JSONPaths:   []jsonPathAssertion{{Path: "status", Value: "UP"}},
+			Headers:     []headerAssertion{{Name: "Content-Type", ValueRegex: "json"}},
+		},
+	}}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	dp, ok := findDataPoint(actualMetrics, "httpcheck.success")
+	require.True(t, ok)
+	require.Equal(t, int64(0), dp.IntValue())
+
+	failed := map[string]int64{}
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() != "httpcheck.failure" {
+			continue
+		}
+		dps := metrics.At(i).Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			reason, _ := dps.At(j).Attributes().Get("failure.reason")
+			failed[reason.Str()] = dps.At(j).IntValue()
+		}
+	}
+	require.Equal(t, map[string]int64{
+		"request":     0,
+		"timeout":     0,
+		"status_code": 0,
+		"body":        0,
+		"json_path":   1,
+		"header":      0,
+	}, failed)
+}
+
+func TestScraperTLS(t *testing.T) {
+	ms := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MetricsBuilderConfig.Metrics.HttpcheckPhaseDuration.Enabled = true
+	cfg.Targets = []*targetConfig{{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: ms.URL,
+			TLSSetting: configtls.TLSClientSetting{
+				InsecureSkipVerify: true,
+			},
+		},
+	}}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	dp, ok := findDataPoint(actualMetrics, "httpcheck.tls.cert_expiry")
+	require.True(t, ok)
+	expected := daysUntil(ms.Certificate().NotAfter)
+	require.InDelta(t, expected, dp.IntValue(), 1)
+
+	dp, ok = findDataPoint(actualMetrics, "httpcheck.success")
+	require.True(t, ok)
+	require.Equal(t, int64(1), dp.IntValue())
+
+	phases := map[string]bool{}
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() != "httpcheck.phase.duration" {
+			continue
+		}
+		dps := metrics.At(i).Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			phase, _ := dps.At(j).Attributes().Get("http.phase")
+			phases[phase.Str()] = true
+		}
+	}
+	// The endpoint is an IP address, so there is no DNS lookup.
+	require.Equal(t, map[string]bool{"tcp_connect": true, "tls_handshake": true, "time_to_first_byte": true}, phases)
+}
+
+func findDataPoint(md pmetric.Metrics, name string) (pmetric.NumberDataPoint, bool) {
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i).Gauge().DataPoints().At(0), true
+		}
+	}
+	return pmetric.NumberDataPoint{}, false
+}
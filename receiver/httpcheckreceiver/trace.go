@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver"
+
+import (
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
+)
+
+// phaseTimer measures the duration of the phases of an HTTP request.
+type phaseTimer struct {
+	mu        sync.Mutex
+	starts    map[metadata.AttributeHTTPPhase]time.Time
+	durations map[metadata.AttributeHTTPPhase]time.Duration
+}
+
+// newPhaseTimer creates a phaseTimer for a request starting now.
+func newPhaseTimer() *phaseTimer {
+	return &phaseTimer{
+		// The time to first byte is measured from the start of the request.
+		starts:    map[metadata.AttributeHTTPPhase]time.Time{metadata.AttributeHTTPPhaseTimeToFirstByte: time.Now()},
+		durations: make(map[metadata.AttributeHTTPPhase]time.Duration),
+	}
+}
+
+func (p *phaseTimer) start(phase metadata.AttributeHTTPPhase) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.starts[phase] = time.Now()
+}
+
+func (p *phaseTimer) done(phase metadata.AttributeHTTPPhase) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if start, ok := p.starts[phase]; ok {
+		p.durations[phase] = time.Since(start)
+	}
+}
+
+// phases returns the duration of the phases that occurred.
+func (p *phaseTimer) phases() map[metadata.AttributeHTTPPhase]time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	durations := make(map[metadata.AttributeHTTPPhase]time.Duration, len(p.durations))
+	for phase, d := range p.durations {
+		durations[phase] = d
+	}
+	return durations
+}
+
+func (p *phaseTimer) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			p.start(metadata.AttributeHTTPPhaseDNSLookup)
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			p.done(metadata.AttributeHTTPPhaseDNSLookup)
+		},
+		ConnectStart: func(string, string) {
+			p.start(metadata.AttributeHTTPPhaseTCPConnect)
+		},
+		ConnectDone: func(string, string, error) {
+			p.done(metadata.AttributeHTTPPhaseTCPConnect)
+		},
+		TLSHandshakeStart: func() {
+			p.start(metadata.AttributeHTTPPhaseTLSHandshake)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			p.done(metadata.AttributeHTTPPhaseTLSHandshake)
+		},
+		GotFirstResponseByte: func() {
+			p.done(metadata.AttributeHTTPPhaseTimeToFirstByte)
+		},
+	}
+}
@@ -24,6 +24,7 @@ const (
 	defaultPullInterval    time.Duration = time.Hour
 	defaultMode            mode          = PullMode
 	defaultResourceVersion               = "1"
+	defaultDiffMaxObjects                = 1000
 )
 
 var modeMap = map[mode]bool{
@@ -40,7 +41,28 @@ type K8sObjectsConfig struct {
 	FieldSelector   string        `mapstructure:"field_selector"`
 	Interval        time.Duration `mapstructure:"interval"`
 	ResourceVersion string        `mapstructure:"resource_version"`
-	gvr             *schema.GroupVersionResource
+	// ExcludeFields are the paths of the fields removed from the objects, e.g. metadata.managedFields.
+	ExcludeFields []string `mapstructure:"exclude_fields"`
+	// Attributes are fields of the objects added as attributes of the logs.
+	Attributes []FieldAttributeConfig `mapstructure:"attributes"`
+	// Diff configures the emission of the changes of the objects instead of the objects. Only available for watch mode.
+	Diff         DiffConfig `mapstructure:"diff"`
+	gvr          *schema.GroupVersionResource
+	excludePaths []fieldPath
+}
+
+type FieldAttributeConfig struct {
+	// Key is the key of the attribute.
+	Key string `mapstructure:"key"`
+	// Field is the path of the field, e.g. spec.replicas or metadata.labels["app.kubernetes.io/name"].
+	Field string `mapstructure:"field"`
+	path  fieldPath
+}
+
+type DiffConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxObjects is the maximum number of objects whose last version is kept to compute their changes.
+	MaxObjects int `mapstructure:"max_objects"`
 }
 
 type Config struct {
@@ -91,11 +113,51 @@ func (c *Config) Validate() error {
 			object.ResourceVersion = defaultResourceVersion
 		}
 
+		if err := object.validateFields(); err != nil {
+			return err
+		}
+
 		object.gvr = gvr
 	}
 	return nil
 }
 
+func (object *K8sObjectsConfig) validateFields() error {
+	object.excludePaths = nil
+	for _, field := range object.ExcludeFields {
+		path, err := parseFieldPath(field)
+		if err != nil {
+			return fmt.Errorf("invalid exclude_fields of %v: %w", object.Name, err)
+		}
+		object.excludePaths = append(object.excludePaths, path)
+	}
+
+	for i := range object.Attributes {
+		attribute := &object.Attributes[i]
+		if attribute.Key == "" {
+			return fmt.Errorf("invalid attributes of %v: key cannot be empty", object.Name)
+		}
+		path, err := parseFieldPath(attribute.Field)
+		if err != nil {
+			return fmt.Errorf("invalid attributes of %v: %w", object.Name, err)
+		}
+		attribute.path = path
+	}
+
+	if object.Diff.Enabled {
+		if object.Mode != WatchMode {
+			return fmt.Errorf("diff of %v is only available for watch mode", object.Name)
+		}
+		if object.Diff.MaxObjects < 0 {
+			return fmt.Errorf("diff max_objects of %v cannot be negative", object.Name)
+		}
+		if object.Diff.MaxObjects == 0 {
+			object.Diff.MaxObjects = defaultDiffMaxObjects
+		}
+	}
+	return nil
+}
+
 func (c *Config) getDiscoveryClient() (discovery.ServerResourcesInterface, error) {
 	if c.makeDiscoveryClient != nil {
 		return c.makeDiscoveryClient()
@@ -193,3 +193,91 @@ func TestWatchResourceVersion(t *testing.T) {
 	assert.EqualValues(t, expected, cfg.Objects)
 
 }
+
+func TestFieldsConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_fields.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+
+	sub, err := cm.Sub("k8sobjects")
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+	cfg.makeDiscoveryClient = getMockDiscoveryClient
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	expected := []*K8sObjectsConfig{
+		{
+			Name:            "pods",
+			Mode:            WatchMode,
+			ResourceVersion: "1",
+			ExcludeFields:   []string{"metadata.managedFields", `metadata.annotations["kubectl.kubernetes.io/last-applied-configuration"]`},
+			Attributes: []FieldAttributeConfig{
+				{Key: "k8s.pod.phase", Field: "status.phase", path: fieldPath{"status", "phase"}},
+				{Key: "app", Field: `metadata.labels["app.kubernetes.io/name"]`, path: fieldPath{"metadata", "labels", "app.kubernetes.io/name"}},
+			},
+			Diff: DiffConfig{
+				Enabled:    true,
+				MaxObjects: 1000,
+			},
+			gvr: &schema.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "pods",
+			},
+			excludePaths: []fieldPath{
+				{"metadata", "managedFields"},
+				{"metadata", "annotations", "kubectl.kubernetes.io/last-applied-configuration"},
+			},
+		},
+	}
+	assert.EqualValues(t, expected, cfg.Objects)
+}
+
+func TestValidateFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		object *K8sObjectsConfig
+		err    string
+	}{
+		{
+			name:   "invalid exclude_fields",
+			object: &K8sObjectsConfig{Name: "pods", Mode: WatchMode, ExcludeFields: []string{"metadata..managedFields"}},
+			err:    `invalid exclude_fields of pods: invalid field path "metadata..managedFields": empty key`,
+		},
+		{
+			name:   "attribute without key",
+			object: &K8sObjectsConfig{Name: "pods", Mode: WatchMode, Attributes: []FieldAttributeConfig{{Field: "status.phase"}}},
+			err:    "invalid attributes of pods: key cannot be empty",
+		},
+		{
+			name:   "attribute without field",
+			object: &K8sObjectsConfig{Name: "pods", Mode: WatchMode, Attributes: []FieldAttributeConfig{{Key: "phase"}}},
+			err:    "invalid attributes of pods: field path cannot be empty",
+		},
+		{
+			name:   "diff in pull mode",
+			object: &K8sObjectsConfig{Name: "pods", Mode: PullMode, Diff: DiffConfig{Enabled: true}},
+			err:    "diff of pods is only available for watch mode",
+		},
+		{
+			name:   "negative diff max_objects",
+			object: &K8sObjectsConfig{Name: "pods", Mode: WatchMode, Diff: DiffConfig{Enabled: true, MaxObjects: -1}},
+			err:    "diff max_objects of pods cannot be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.makeDiscoveryClient = getMockDiscoveryClient
+			cfg.Objects = []*K8sObjectsConfig{tt.object}
+			assert.EqualError(t, cfg.Validate(), tt.err)
+		})
+	}
+}
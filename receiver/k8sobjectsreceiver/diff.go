@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/lru"
+)
+
+// ignoredDiffPaths are the paths of the fields changing on every update, which are not part of the diffs.
+var ignoredDiffPaths = map[string]bool{
+	"/metadata/resourceVersion": true,
+}
+
+// diffObject returns the body of the log of a watch event in diff mode, or false if the object
+// did not change since its last version. previous holds the last version of the objects by UID.
+func diffObject(eventType watch.EventType, object *unstructured.Unstructured, previous *lru.Cache) (map[string]interface{}, bool) {
+	body := map[string]interface{}{
+		"type": string(eventType),
+	}
+
+	uid := object.GetUID()
+	switch eventType {
+	case watch.Added, watch.Modified:
+		last, ok := previous.Get(uid)
+		previous.Add(uid, object.Object)
+		if !ok {
+			body["object"] = object.Object
+			return body, true
+		}
+		patch := jsonPatch("", last, object.Object, nil)
+		if len(patch) == 0 {
+			return nil, false
+		}
+		body["object"] = objectReference(object)
+		body["patch"] = patch
+	case watch.Deleted:
+		previous.Remove(uid)
+		body["object"] = objectReference(object)
+	default:
+		body["object"] = object.Object
+	}
+	return body, true
+}
+
+// objectReference returns the fields identifying the version of an object.
+func objectReference(object *unstructured.Unstructured) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"name":            object.GetName(),
+		"uid":             string(object.GetUID()),
+		"resourceVersion": object.GetResourceVersion(),
+	}
+	if namespace := object.GetNamespace(); namespace != "" {
+		metadata["namespace"] = namespace
+	}
+	return map[string]interface{}{
+		"apiVersion": object.GetAPIVersion(),
+		"kind":       object.GetKind(),
+		"metadata":   metadata,
+	}
+}
+
+// jsonPatch appends to patch the JSON patch (RFC 6902) operations transforming from into to.
+// Objects are compared field by field, while other values, including lists, are replaced as a whole.
+func jsonPatch(path string, from, to interface{}, patch []interface{}) []interface{} {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if !fromIsMap || !toIsMap {
+		if !reflect.DeepEqual(from, to) {
+			patch = append(patch, patchOperation("replace", path, to))
+		}
+		return patch
+	}
+
+	keys := make([]string, 0, len(fromMap)+len(toMap))
+	for key := range fromMap {
+		keys = append(keys, key)
+	}
+	for key := range toMap {
+		if _, ok := fromMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		keyPath := path + "/" + escapePointerToken(key)
+		if ignoredDiffPaths[keyPath] {
+			continue
+		}
+		fromValue, inFrom := fromMap[key]
+		toValue, inTo := toMap[key]
+		switch {
+		case !inFrom:
+			patch = append(patch, patchOperation("add", keyPath, toValue))
+		case !inTo:
+			patch = append(patch, map[string]interface{}{"op": "remove", "path": keyPath})
+		default:
+			patch = jsonPatch(keyPath, fromValue, toValue, patch)
+		}
+	}
+	return patch
+}
+
+func patchOperation(op string, path string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"op":    op,
+		"path":  path,
+		"value": value,
+	}
+}
+
+// escapePointerToken escapes a key to be part of a JSON pointer (RFC 6901).
+func escapePointerToken(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/lru"
+)
+
+func TestJSONPatch(t *testing.T) {
+	from := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "1",
+			"labels": map[string]interface{}{
+				"app":                    "nginx",
+				"app.kubernetes.io/name": "nginx",
+				"tier":                   "frontend",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(1),
+			"ports":    []interface{}{int64(80)},
+		},
+	}
+	to := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"resourceVersion": "2",
+			"labels": map[string]interface{}{
+				"app":                    "nginx",
+				"app.kubernetes.io/name": "web",
+				"owner~team":             "web",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"ports":    []interface{}{int64(80), int64(443)},
+		},
+		"status": map[string]interface{}{
+			"readyReplicas": int64(1),
+		},
+	}
+
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"op": "replace", "path": "/metadata/labels/app.kubernetes.io~1name", "value": "web"},
+		map[string]interface{}{"op": "add", "path": "/metadata/labels/owner~0team", "value": "web"},
+		map[string]interface{}{"op": "remove", "path": "/metadata/labels/tier"},
+		map[string]interface{}{"op": "replace", "path": "/spec/ports", "value": []interface{}{int64(80), int64(443)}},
+		map[string]interface{}{"op": "replace", "path": "/spec/replicas", "value": int64(3)},
+		map[string]interface{}{"op": "add", "path": "/status", "value": map[string]interface{}{"readyReplicas": int64(1)}},
+	}, jsonPatch("", from, to, nil))
+
+	assert.Empty(t, jsonPatch("", from, from, nil))
+}
+
+func TestDiffObject(t *testing.T) {
+	previous := lru.New(1)
+
+	deployment := func(name string, resourceVersion string, replicas int64) *unstructured.Unstructured {
+		object := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"replicas": replicas},
+		}}
+		object.SetAPIVersion("apps/v1")
+		object.SetKind("Deployment")
+		object.SetNamespace("default")
+		object.SetName(name)
+		object.SetUID(types.UID("uid-" + name))
+		object.SetResourceVersion(resourceVersion)
+		return object
+	}
+	reference := func(name string, resourceVersion string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":            name,
+				"namespace":       "default",
+				"uid":             "uid-" + name,
+				"resourceVersion": resourceVersion,
+			},
+		}
+	}
+
+	// The first version of an object is emitted as a whole.
+	added := deployment("nginx", "1", 1)
+	body, changed := diffObject(watch.Added, added, previous)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{"type": "ADDED", "object": added.Object}, body)
+
+	// The following ones as their changes.
+	body, changed = diffObject(watch.Modified, deployment("nginx", "2", 3), previous)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{
+		"type":   "MODIFIED",
+		"object": reference("nginx", "2"),
+		"patch": []interface{}{
+			map[string]interface{}{"op": "replace", "path": "/spec/replicas", "value": int64(3)},
+		},
+	}, body)
+
+	// Versions without changes are not emitted.
+	_, changed = diffObject(watch.Modified, deployment("nginx", "3", 3), previous)
+	assert.False(t, changed)
+
+	// Objects evicted from the cache are emitted as a whole.
+	_, changed = diffObject(watch.Added, deployment("redis", "4", 1), previous)
+	assert.True(t, changed)
+	modified := deployment("nginx", "5", 3)
+	body, changed = diffObject(watch.Modified, modified, previous)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{"type": "MODIFIED", "object": modified.Object}, body)
+
+	body, changed = diffObject(watch.Deleted, deployment("nginx", "6", 3), previous)
+	assert.True(t, changed)
+	assert.Equal(t, map[string]interface{}{"type": "DELETED", "object": reference("nginx", "6")}, body)
+	assert.Equal(t, 0, previous.Len())
+}
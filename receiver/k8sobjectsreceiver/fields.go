@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// fieldPath is the path of a field of an object, e.g. `metadata.labels["app.kubernetes.io/name"]`,
+// as a list of map keys.
+type fieldPath []string
+
+func parseFieldPath(path string) (fieldPath, error) {
+	var keys fieldPath
+	rest := path
+	for rest != "" {
+		if strings.HasPrefix(rest, `["`) {
+			end := strings.Index(rest, `"]`)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid field path %q: unterminated key", path)
+			}
+			keys = append(keys, rest[2:end])
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, `.[`)
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid field path %q: empty key", path)
+			}
+			keys = append(keys, rest[:end])
+			rest = rest[end:]
+		}
+		if strings.HasPrefix(rest, ".") {
+			rest = rest[1:]
+			if rest == "" {
+				return nil, fmt.Errorf("invalid field path %q: empty key", path)
+			}
+		} else if rest != "" && !strings.HasPrefix(rest, `["`) {
+			return nil, fmt.Errorf("invalid field path %q: unexpected %q", path, rest)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("field path cannot be empty")
+	}
+	return keys, nil
+}
+
+func (p fieldPath) get(object map[string]interface{}) (interface{}, bool) {
+	var value interface{} = object
+	for _, key := range p {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func (p fieldPath) remove(object map[string]interface{}) {
+	parent, ok := p[:len(p)-1].get(object)
+	if !ok {
+		return
+	}
+	if m, ok := parent.(map[string]interface{}); ok {
+		delete(m, p[len(p)-1])
+	}
+}
+
+// putValue puts an object field value into the attributes.
+func putValue(attrs pcommon.Map, key string, value interface{}) {
+	switch v := value.(type) {
+	case string:
+		attrs.PutStr(key, v)
+	case bool:
+		attrs.PutBool(key, v)
+	case int64:
+		attrs.PutInt(key, v)
+	case float64:
+		attrs.PutDouble(key, v)
+	default:
+		//nolint:errcheck
+		attrs.PutEmpty(key).FromRaw(v)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sobjectsreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected fieldPath
+		err      string
+	}{
+		{path: "spec", expected: fieldPath{"spec"}},
+		{path: "spec.replicas", expected: fieldPath{"spec", "replicas"}},
+		{path: `metadata.labels["app.kubernetes.io/name"]`, expected: fieldPath{"metadata", "labels", "app.kubernetes.io/name"}},
+		{path: `metadata.annotations["a.b"]["c"].d`, expected: fieldPath{"metadata", "annotations", "a.b", "c", "d"}},
+		{path: `["metadata"]`, expected: fieldPath{"metadata"}},
+		{path: "", err: "field path cannot be empty"},
+		{path: "spec..replicas", err: `invalid field path "spec..replicas": empty key`},
+		{path: "spec.", err: `invalid field path "spec.": empty key`},
+		{path: `metadata.labels["app`, err: `invalid field path "metadata.labels[\"app": unterminated key`},
+		{path: `metadata.labels["app"]x`, err: `invalid field path "metadata.labels[\"app\"]x": unexpected "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path, err := parseFieldPath(tt.path)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, path)
+		})
+	}
+}
+
+func TestFieldPathGetAndRemove(t *testing.T) {
+	object := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":          "nginx",
+			"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+		},
+	}
+
+	value, ok := fieldPath{"spec", "replicas"}.get(object)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), value)
+
+	_, ok = fieldPath{"spec", "replicas", "count"}.get(object)
+	assert.False(t, ok)
+	_, ok = fieldPath{"status"}.get(object)
+	assert.False(t, ok)
+
+	fieldPath{"metadata", "managedFields"}.remove(object)
+	fieldPath{"status", "conditions"}.remove(object)
+	assert.Equal(t, map[string]interface{}{"name": "nginx"}, object["metadata"])
+}
@@ -18,6 +18,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/watch"
+	"k8s.io/utils/lru"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sobjectsreceiver/internal/metadata"
 )
@@ -92,11 +93,16 @@ func (kr *k8sobjectsreceiver) start(ctx context.Context, object *K8sObjectsConfi
 		}
 
 	case WatchMode:
+		// The last versions of the objects are shared by the watches of all the namespaces.
+		var previous *lru.Cache
+		if object.Diff.Enabled {
+			previous = lru.New(object.Diff.MaxObjects)
+		}
 		if len(object.Namespaces) == 0 {
-			go kr.startWatch(ctx, object, resource)
+			go kr.startWatch(ctx, object, resource, previous)
 		} else {
 			for _, ns := range object.Namespaces {
-				go kr.startWatch(ctx, object, resource.Namespace(ns))
+				go kr.startWatch(ctx, object, resource.Namespace(ns), previous)
 			}
 		}
 	}
@@ -139,7 +145,7 @@ func (kr *k8sobjectsreceiver) startPull(ctx context.Context, config *K8sObjectsC
 
 }
 
-func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface) {
+func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjectsConfig, resource dynamic.ResourceInterface, previous *lru.Cache) {
 
 	stopperChan := make(chan struct{})
 	kr.mu.Lock()
@@ -167,7 +173,10 @@ func (kr *k8sobjectsreceiver) startWatch(ctx context.Context, config *K8sObjects
 				kr.setting.Logger.Warn("Watch channel closed unexpectedly", zap.String("resource", config.gvr.String()))
 				return
 			}
-			logs := watchObjectsToLogData(&data, time.Now(), config)
+			logs := watchObjectsToLogData(&data, time.Now(), config, previous)
+			if logs.LogRecordCount() == 0 {
+				continue
+			}
 
 			obsCtx := kr.obsrecv.StartLogsOp(ctx)
 			err := kr.consumer.ConsumeLogs(obsCtx, logs)
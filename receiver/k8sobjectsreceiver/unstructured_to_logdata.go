@@ -11,33 +11,65 @@ import (
 	semconv "go.opentelemetry.io/collector/semconv/v1.9.0"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/lru"
 )
 
-type attrUpdaterFunc func(pcommon.Map)
+type attrUpdaterFunc func(pcommon.Map, unstructured.Unstructured)
 
-func watchObjectsToLogData(event *watch.Event, observedAt time.Time, config *K8sObjectsConfig) plog.Logs {
+// watchObjectsToLogData converts a watch event to logs. If previous is set, the changes of the object
+// since its last version are emitted instead of the object, and no logs are returned if it did not change.
+func watchObjectsToLogData(event *watch.Event, observedAt time.Time, config *K8sObjectsConfig, previous *lru.Cache) plog.Logs {
 	udata := event.Object.(*unstructured.Unstructured)
+	removeExcludedFields(udata.Object, config)
+
+	body := map[string]interface{}{
+		"type":   string(event.Type),
+		"object": udata.Object,
+	}
+	if previous != nil {
+		var changed bool
+		if body, changed = diffObject(event.Type, udata, previous); !changed {
+			return plog.NewLogs()
+		}
+	}
 	ul := unstructured.UnstructuredList{
 		Items: []unstructured.Unstructured{{
-			Object: map[string]interface{}{
-				"type":   string(event.Type),
-				"object": udata.Object,
-			},
+			Object: body,
 		}},
 	}
 
-	return unstructuredListToLogData(&ul, observedAt, config, func(attrs pcommon.Map) {
+	return unstructuredListToLogData(&ul, observedAt, config, func(attrs pcommon.Map, _ unstructured.Unstructured) {
 		objectMeta := udata.Object["metadata"].(map[string]interface{})
 		name := objectMeta["name"].(string)
 		if name != "" {
 			attrs.PutStr("event.domain", "k8s")
 			attrs.PutStr("event.name", name)
 		}
+		putFieldAttributes(attrs, udata.Object, config)
 	})
 }
 
 func pullObjectsToLogData(event *unstructured.UnstructuredList, observedAt time.Time, config *K8sObjectsConfig) plog.Logs {
-	return unstructuredListToLogData(event, observedAt, config)
+	for _, e := range event.Items {
+		removeExcludedFields(e.Object, config)
+	}
+	return unstructuredListToLogData(event, observedAt, config, func(attrs pcommon.Map, e unstructured.Unstructured) {
+		putFieldAttributes(attrs, e.Object, config)
+	})
+}
+
+func removeExcludedFields(object map[string]interface{}, config *K8sObjectsConfig) {
+	for _, path := range config.excludePaths {
+		path.remove(object)
+	}
+}
+
+func putFieldAttributes(attrs pcommon.Map, object map[string]interface{}, config *K8sObjectsConfig) {
+	for _, attribute := range config.Attributes {
+		if value, ok := attribute.path.get(object); ok && value != nil {
+			putValue(attrs, attribute.Key, value)
+		}
+	}
 }
 
 func unstructuredListToLogData(event *unstructured.UnstructuredList, observedAt time.Time, config *K8sObjectsConfig, attrUpdaters ...attrUpdaterFunc) plog.Logs {
@@ -64,7 +96,7 @@ func unstructuredListToLogData(event *unstructured.UnstructuredList, observedAt
 		attrs.PutStr("k8s.resource.name", config.gvr.Resource)
 
 		for _, attrUpdate := range attrUpdaters {
-			attrUpdate(attrs)
+			attrUpdate(attrs, e)
 		}
 
 		dest := record.Body()
@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/utils/lru"
 )
 
 func TestUnstructuredListToLogData(t *testing.T) {
@@ -113,7 +114,7 @@ func TestUnstructuredListToLogData(t *testing.T) {
 			},
 		}
 
-		logs := watchObjectsToLogData(event, time.Now(), config)
+		logs := watchObjectsToLogData(event, time.Now(), config, nil)
 
 		assert.Equal(t, logs.LogRecordCount(), 1)
 
@@ -153,7 +154,7 @@ func TestUnstructuredListToLogData(t *testing.T) {
 		}
 
 		observedAt := time.Now()
-		logs := watchObjectsToLogData(event, observedAt, config)
+		logs := watchObjectsToLogData(event, observedAt, config, nil)
 
 		assert.Equal(t, logs.LogRecordCount(), 1)
 
@@ -167,4 +168,96 @@ func TestUnstructuredListToLogData(t *testing.T) {
 		assert.Equal(t, logRecords.At(0).ObservedTimestamp().AsTime().Unix(), observedAt.Unix())
 	})
 
+	t.Run("Test excluded fields and field attributes", func(t *testing.T) {
+		config := &K8sObjectsConfig{
+			gvr: &schema.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "pods",
+			},
+			Attributes: []FieldAttributeConfig{
+				{Key: "k8s.pod.phase", path: fieldPath{"status", "phase"}},
+				{Key: "restarts", path: fieldPath{"status", "restartCount"}},
+				{Key: "app", path: fieldPath{"metadata", "labels", "app.kubernetes.io/name"}},
+				{Key: "missing", path: fieldPath{"spec", "nodeName"}},
+			},
+			excludePaths: []fieldPath{{"metadata", "managedFields"}},
+		}
+		objects := unstructured.UnstructuredList{
+			Items: []unstructured.Unstructured{{
+				Object: map[string]interface{}{
+					"kind":       "Pod",
+					"apiVersion": "v1",
+					"metadata": map[string]interface{}{
+						"name":          "nginx",
+						"labels":        map[string]interface{}{"app.kubernetes.io/name": "web"},
+						"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+					},
+					"status": map[string]interface{}{
+						"phase":        "Running",
+						"restartCount": int64(2),
+					},
+				},
+			}},
+		}
+
+		logs := pullObjectsToLogData(&objects, time.Now(), config)
+		require.Equal(t, 1, logs.LogRecordCount())
+
+		record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+		assert.Equal(t, map[string]interface{}{
+			"k8s.resource.name": "pods",
+			"k8s.pod.phase":     "Running",
+			"restarts":          int64(2),
+			"app":               "web",
+		}, record.Attributes().AsRaw())
+		metadata := record.Body().Map().AsRaw()["metadata"].(map[string]interface{})
+		assert.NotContains(t, metadata, "managedFields")
+	})
+
+	t.Run("Test diff of watch events", func(t *testing.T) {
+		config := &K8sObjectsConfig{
+			gvr: &schema.GroupVersionResource{
+				Group:    "",
+				Version:  "v1",
+				Resource: "pods",
+			},
+			excludePaths: []fieldPath{{"metadata", "managedFields"}},
+		}
+		pod := func(resourceVersion string, phase string) *watch.Event {
+			return &watch.Event{
+				Type: watch.Modified,
+				Object: &unstructured.Unstructured{
+					Object: map[string]interface{}{
+						"kind":       "Pod",
+						"apiVersion": "v1",
+						"metadata": map[string]interface{}{
+							"name":            "nginx",
+							"uid":             "uid-nginx",
+							"resourceVersion": resourceVersion,
+							"managedFields":   []interface{}{map[string]interface{}{"time": resourceVersion}},
+						},
+						"status": map[string]interface{}{
+							"phase": phase,
+						},
+					},
+				},
+			}
+		}
+		previous := lru.New(10)
+
+		logs := watchObjectsToLogData(pod("1", "Pending"), time.Now(), config, previous)
+		assert.Equal(t, 1, logs.LogRecordCount())
+
+		// Only managedFields changed.
+		logs = watchObjectsToLogData(pod("2", "Pending"), time.Now(), config, previous)
+		assert.Equal(t, 0, logs.LogRecordCount())
+
+		logs = watchObjectsToLogData(pod("3", "Running"), time.Now(), config, previous)
+		require.Equal(t, 1, logs.LogRecordCount())
+		body := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Map().AsRaw()
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"op": "replace", "path": "/status/phase", "value": "Running"},
+		}, body["patch"])
+	})
 }
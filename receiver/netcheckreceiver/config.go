@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver/internal/metadata"
+)
+
+const (
+	// defaultTimeout is the timeout of the checks without one configured.
+	defaultTimeout = 10 * time.Second
+	// defaultDNSPort is the port of the DNS servers configured without one.
+	defaultDNSPort = "53"
+)
+
+// Predefined error responses for configuration validation failures
+var (
+	errNoTargets        = errors.New("no tcp or dns targets configured")
+	errMissingEndpoint  = errors.New(`"endpoint" must be specified`)
+	errMissingHost      = errors.New(`"host" must be specified`)
+	errNegativeTimeout  = errors.New(`"timeout" must not be negative`)
+	errInvalidResolver  = errors.New(`"resolver" must be in the form of <host>[:<port>]`)
+	errUnsupportedQuery = errors.New(`"record_type" must be one of A, AAAA, CNAME, MX, NS, SRV or TXT`)
+)
+
+// Config defines the configuration for the various elements of the receiver agent.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	metadata.MetricsBuilderConfig           `mapstructure:",squash"`
+	TCPTargets                              []*tcpTargetConfig `mapstructure:"tcp"`
+	DNSTargets                              []*dnsTargetConfig `mapstructure:"dns"`
+}
+
+type tcpTargetConfig struct {
+	// Endpoint to connect to, in the form host:port.
+	Endpoint string `mapstructure:"endpoint"`
+	// Timeout of the whole check, including the banner exchange. Defaults to 10s.
+	Timeout time.Duration `mapstructure:"timeout"`
+	// Send is written to the connection once established.
+	Send string `mapstructure:"send"`
+	// Expect is a string the data read from the connection must contain.
+	Expect string `mapstructure:"expect"`
+}
+
+type dnsTargetConfig struct {
+	// Host is the name to resolve.
+	Host string `mapstructure:"host"`
+	// Resolver is the address of the DNS server to query, in the form host[:port].
+	// The system resolver is used if not set.
+	Resolver string `mapstructure:"resolver"`
+	// RecordType is the type of the records to query. Defaults to A.
+	RecordType string `mapstructure:"record_type"`
+	// ExpectedAnswers must all be part of the answers, if set.
+	ExpectedAnswers []string `mapstructure:"expected_answers"`
+	// Timeout of the query. Defaults to 10s.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *tcpTargetConfig) Validate() error {
+	var err error
+
+	if cfg.Endpoint == "" {
+		err = multierr.Append(err, errMissingEndpoint)
+	} else if _, _, splitErr := net.SplitHostPort(cfg.Endpoint); splitErr != nil {
+		err = multierr.Append(err, fmt.Errorf(`"endpoint" must be in the form of <host>:<port>: %w`, splitErr))
+	}
+
+	if cfg.Timeout < 0 {
+		err = multierr.Append(err, errNegativeTimeout)
+	}
+
+	return err
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *dnsTargetConfig) Validate() error {
+	var err error
+
+	if cfg.Host == "" {
+		err = multierr.Append(err, errMissingHost)
+	}
+
+	if cfg.Resolver != "" {
+		if _, resolverErr := resolverAddress(cfg.Resolver); resolverErr != nil {
+			err = multierr.Append(err, resolverErr)
+		}
+	}
+
+	if _, ok := lookups[cfg.recordType()]; !ok {
+		err = multierr.Append(err, errUnsupportedQuery)
+	}
+
+	if cfg.Timeout < 0 {
+		err = multierr.Append(err, errNegativeTimeout)
+	}
+
+	return err
+}
+
+func (cfg *dnsTargetConfig) recordType() string {
+	if cfg.RecordType == "" {
+		return "A"
+	}
+	return strings.ToUpper(cfg.RecordType)
+}
+
+// Validate validates the configuration by checking for missing or invalid fields
+func (cfg *Config) Validate() error {
+	var err error
+
+	if len(cfg.TCPTargets) == 0 && len(cfg.DNSTargets) == 0 {
+		err = multierr.Append(err, errNoTargets)
+	}
+
+	for _, target := range cfg.TCPTargets {
+		err = multierr.Append(err, target.Validate())
+	}
+
+	for _, target := range cfg.DNSTargets {
+		err = multierr.Append(err, target.Validate())
+	}
+
+	return err
+}
+
+// resolverAddress returns the address of the DNS server in the form host:port, the port
+// defaulting to 53.
+func resolverAddress(resolver string) (string, error) {
+	host, port, err := net.SplitHostPort(resolver)
+	if err != nil {
+		// the port is optional, and IPv6 addresses without a port may be enclosed in brackets
+		host, port = strings.TrimSuffix(strings.TrimPrefix(resolver, "["), "]"), defaultDNSPort
+		if strings.Contains(host, ":") && net.ParseIP(host) == nil {
+			return "", errInvalidResolver
+		}
+	}
+	if host == "" || strings.ContainsAny(host, " \t") {
+		return "", errInvalidResolver
+	}
+	if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+		return "", errInvalidResolver
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+func timeoutOrDefault(timeout time.Duration) time.Duration {
+	if timeout == 0 {
+		return defaultTimeout
+	}
+	return timeout
+}
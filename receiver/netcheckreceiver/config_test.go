@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver/internal/metadata"
+)
+
+func TestValidate(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{
+			desc:        "no targets",
+			cfg:         &Config{},
+			expectedErr: errNoTargets,
+		},
+		{
+			desc: "missing endpoint",
+			cfg: &Config{
+				TCPTargets: []*tcpTargetConfig{{}},
+			},
+			expectedErr: errMissingEndpoint,
+		},
+		{
+			desc: "endpoint without port",
+			cfg: &Config{
+				TCPTargets: []*tcpTargetConfig{{Endpoint: "localhost"}},
+			},
+			expectedErr: errors.New(`"endpoint" must be in the form of <host>:<port>: address localhost: missing port in address`),
+		},
+		{
+			desc: "negative timeouts",
+			cfg: &Config{
+				TCPTargets: []*tcpTargetConfig{{Endpoint: "localhost:22", Timeout: -time.Second}},
+				DNSTargets: []*dnsTargetConfig{{Host: "opentelemetry.io", Timeout: -time.Second}},
+			},
+			expectedErr: multierr.Combine(
+				errNegativeTimeout,
+				errNegativeTimeout,
+			),
+		},
+		{
+			desc: "invalid dns targets",
+			cfg: &Config{
+				DNSTargets: []*dnsTargetConfig{{Resolver: "1.1.1.1 8.8.8.8", RecordType: "PTR"}},
+			},
+			expectedErr: multierr.Combine(
+				errMissingHost,
+				errInvalidResolver,
+				errUnsupportedQuery,
+			),
+		},
+		{
+			desc: "invalid resolvers",
+			cfg: &Config{
+				DNSTargets: []*dnsTargetConfig{
+					{Host: "opentelemetry.io", Resolver: "foo:bar:baz"},
+					{Host: "opentelemetry.io", Resolver: "localhost:99999"},
+					{Host: "opentelemetry.io", Resolver: ":53"},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errInvalidResolver,
+				errInvalidResolver,
+				errInvalidResolver,
+			),
+		},
+		{
+			desc: "valid config",
+			cfg: &Config{
+				TCPTargets: []*tcpTargetConfig{{Endpoint: "localhost:22", Expect: "SSH-"}},
+				DNSTargets: []*dnsTargetConfig{{Host: "opentelemetry.io", Resolver: "1.1.1.1", RecordType: "aaaa"}},
+			},
+			expectedErr: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			actualErr := tc.cfg.Validate()
+			if tc.expectedErr != nil {
+				require.EqualError(t, actualErr, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, actualErr)
+			}
+		})
+	}
+}
+
+func TestResolverAddress(t *testing.T) {
+	for resolver, expected := range map[string]string{
+		"1.1.1.1":         "1.1.1.1:53",
+		"1.1.1.1:5353":    "1.1.1.1:5353",
+		"dns.google":      "dns.google:53",
+		"::1":             "[::1]:53",
+		"[::1]":           "[::1]:53",
+		"[::1]:5353":      "[::1]:5353",
+		"localhost:0":     "",
+		"localhost:dns":   "",
+		"localhost:":      "",
+		"1.1.1.1 8.8.8.8": "",
+	} {
+		t.Run(resolver, func(t *testing.T) {
+			address, err := resolverAddress(resolver)
+			if expected == "" {
+				require.ErrorIs(t, err, errInvalidResolver)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, expected, address)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	sub, err := cm.Sub(component.NewIDWithName(metadata.Type, "").String())
+	require.NoError(t, err)
+	require.NoError(t, component.UnmarshalConfig(sub, cfg))
+	require.NoError(t, component.ValidateConfig(cfg))
+
+	expected := factory.CreateDefaultConfig().(*Config)
+	expected.CollectionInterval = 30 * time.Second
+	expected.TCPTargets = []*tcpTargetConfig{
+		{
+			Endpoint: "localhost:6379",
+			Send:     "PING\r\n",
+			Expect:   "+PONG",
+		},
+		{
+			Endpoint: "localhost:22",
+			Timeout:  5 * time.Second,
+		},
+	}
+	expected.DNSTargets = []*dnsTargetConfig{
+		{
+			Host:            "opentelemetry.io",
+			Resolver:        "1.1.1.1:53",
+			RecordType:      "A",
+			ExpectedAnswers: []string{"104.21.0.1"},
+		},
+		{
+			Host:       "_ldap._tcp.example.com",
+			RecordType: "SRV",
+			Timeout:    2 * time.Second,
+		},
+	}
+	require.Equal(t, expected, cfg)
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// lookup queries the records of a type for host, and returns them in the
+// form they are compared to the expected answers.
+type lookup func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error)
+
+var lookups = map[string]lookup{
+	"A": func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+		return lookupIP(ctx, resolver, "ip4", host)
+	},
+	"AAAA": func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+		return lookupIP(ctx, resolver, "ip6", host)
+	},
+	"CNAME": func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+		cname, err := resolver.LookupCNAME(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return []string{normalizeName(cname)}, nil
+	},
+	"MX": func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+		mxs, err := resolver.LookupMX(ctx, host)
+		answers := make([]string, 0, len(mxs))
+		for _, mx := range mxs {
+			answers = append(answers, normalizeName(mx.Host))
+		}
+		return answers, err
+	},
+	"NS": func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+		nss, err := resolver.LookupNS(ctx, host)
+		answers := make([]string, 0, len(nss))
+		for _, ns := range nss {
+			answers = append(answers, normalizeName(ns.Host))
+		}
+		return answers, err
+	},
+	"SRV": func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+		_, srvs, err := resolver.LookupSRV(ctx, "", "", host)
+		answers := make([]string, 0, len(srvs))
+		for _, srv := range srvs {
+			answers = append(answers, net.JoinHostPort(normalizeName(srv.Target), strconv.Itoa(int(srv.Port))))
+		}
+		return answers, err
+	},
+	"TXT": func(ctx context.Context, resolver *net.Resolver, host string) ([]string, error) {
+		return resolver.LookupTXT(ctx, host)
+	},
+}
+
+func lookupIP(ctx context.Context, resolver *net.Resolver, network string, host string) ([]string, error) {
+	ips, err := resolver.LookupIP(ctx, network, host)
+	answers := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		answers = append(answers, ip.String())
+	}
+	return answers, err
+}
+
+// normalizeName removes the trailing dot of fully qualified names.
+func normalizeName(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
+
+// newResolver creates a resolver querying the DNS server at address, or the
+// system resolver if address is empty. The port defaults to 53.
+func newResolver(address string) *net.Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+	if resolved, err := resolverAddress(address); err == nil {
+		address = resolved
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// checkDNS resolves the target and checks the expected answers are part of the answers.
+func checkDNS(ctx context.Context, resolver *net.Resolver, target *dnsTargetConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, timeoutOrDefault(target.Timeout))
+	defer cancel()
+
+	answers, err := lookups[target.recordType()](ctx, resolver, target.Host)
+	if err != nil {
+		return err
+	}
+
+	for _, expected := range target.ExpectedAnswers {
+		if !containsAnswer(answers, normalizeName(expected)) {
+			return fmt.Errorf("expected answer %q not found in %v", expected, answers)
+		}
+	}
+	return nil
+}
+
+func containsAnswer(answers []string, expected string) bool {
+	for _, answer := range answers {
+		if strings.EqualFold(answer, expected) {
+			return true
+		}
+	}
+	return false
+}
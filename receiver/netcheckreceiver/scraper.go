@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver/internal/metadata"
+)
+
+type netcheckScraper struct {
+	cfg       *Config
+	resolvers []*net.Resolver
+	settings  component.TelemetrySettings
+	mb        *metadata.MetricsBuilder
+}
+
+// scrape checks all the targets concurrently and produces metrics based on the results
+func (n *netcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	var wg sync.WaitGroup
+	wg.Add(len(n.cfg.TCPTargets) + len(n.cfg.DNSTargets))
+	var mux sync.Mutex
+
+	for _, target := range n.cfg.TCPTargets {
+		go func(target *tcpTargetConfig) {
+			defer wg.Done()
+
+			now := pcommon.NewTimestampFromTime(time.Now())
+			connectDuration, err := checkTCP(ctx, target)
+
+			mux.Lock()
+			defer mux.Unlock()
+			n.mb.RecordNetcheckTCPDurationDataPoint(now, connectDuration.Milliseconds(), target.Endpoint)
+			if err != nil {
+				n.mb.RecordNetcheckTCPErrorDataPoint(now, int64(1), target.Endpoint, err.Error())
+				n.mb.RecordNetcheckTCPStatusDataPoint(now, int64(0), target.Endpoint)
+			} else {
+				n.mb.RecordNetcheckTCPStatusDataPoint(now, int64(1), target.Endpoint)
+			}
+		}(target)
+	}
+
+	for idx, target := range n.cfg.DNSTargets {
+		go func(target *dnsTargetConfig, resolver *net.Resolver) {
+			defer wg.Done()
+
+			now := pcommon.NewTimestampFromTime(time.Now())
+			start := time.Now()
+			err := checkDNS(ctx, resolver, target)
+			duration := time.Since(start)
+
+			mux.Lock()
+			defer mux.Unlock()
+			n.mb.RecordNetcheckDNSDurationDataPoint(now, duration.Milliseconds(), target.Host, target.recordType(), target.Resolver)
+			if err != nil {
+				n.mb.RecordNetcheckDNSErrorDataPoint(now, int64(1), target.Host, target.recordType(), target.Resolver, err.Error())
+				n.mb.RecordNetcheckDNSStatusDataPoint(now, int64(0), target.Host, target.recordType(), target.Resolver)
+			} else {
+				n.mb.RecordNetcheckDNSStatusDataPoint(now, int64(1), target.Host, target.recordType(), target.Resolver)
+			}
+		}(target, n.resolvers[idx])
+	}
+
+	wg.Wait()
+
+	return n.mb.Emit(), nil
+}
+
+func newScraper(conf *Config, settings receiver.CreateSettings) *netcheckScraper {
+	resolvers := make([]*net.Resolver, 0, len(conf.DNSTargets))
+	for _, target := range conf.DNSTargets {
+		resolvers = append(resolvers, newResolver(target.Resolver))
+	}
+	return &netcheckScraper{
+		cfg:       conf,
+		resolvers: resolvers,
+		settings:  settings.TelemetrySettings,
+		mb:        metadata.NewMetricsBuilder(conf.MetricsBuilderConfig, settings),
+	}
+}
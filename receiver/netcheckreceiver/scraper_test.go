@@ -0,0 +1,266 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// newTCPServer starts a server writing banner to the connections, and
+// replying with reply to the lines containing "PING".
+func newTCPServer(t *testing.T, banner string, reply string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+				if banner != "" {
+					_, _ = conn.Write([]byte(banner))
+				}
+				buf := make([]byte, 1024)
+				n, err := conn.Read(buf)
+				if err == nil && reply != "" && strings.Contains(string(buf[:n]), "PING") {
+					_, _ = conn.Write([]byte(reply))
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+// newDNSServer starts a DNS server answering the A queries for the given names.
+func newDNSServer(t *testing.T, records map[string][4]byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var parser dnsmessage.Parser
+			header, err := parser.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, err := parser.Question()
+			if err != nil {
+				continue
+			}
+
+			header.Response = true
+			header.Authoritative = true
+			builder := dnsmessage.NewBuilder(nil, header)
+			builder.EnableCompression()
+			ip, ok := records[question.Name.String()]
+			if !ok {
+				builder = dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, RCode: dnsmessage.RCodeNameError})
+			}
+			_ = builder.StartQuestions()
+			_ = builder.Question(question)
+			if ok && question.Type == dnsmessage.TypeA {
+				_ = builder.StartAnswers()
+				_ = builder.AResource(dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}, dnsmessage.AResource{A: ip})
+			}
+			msg, err := builder.Finish()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(msg, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+type dataPoint struct {
+	value int64
+	attrs map[string]any
+}
+
+// dataPoints returns the data points of the metrics by metric name.
+func dataPoints(metrics pmetric.Metrics) map[string][]dataPoint {
+	points := make(map[string][]dataPoint)
+	ms := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		m := ms.At(i)
+		var dps pmetric.NumberDataPointSlice
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dps = m.Sum().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			points[m.Name()] = append(points[m.Name()], dataPoint{value: dps.At(j).IntValue(), attrs: dps.At(j).Attributes().AsRaw()})
+		}
+	}
+	return points
+}
+
+func TestScraperTCP(t *testing.T) {
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedEndpoint := closed.Addr().String()
+	require.NoError(t, closed.Close())
+
+	testCases := []struct {
+		desc          string
+		target        func(t *testing.T) *tcpTargetConfig
+		expectedUp    int64
+		expectedError string
+	}{
+		{
+			desc: "connect only",
+			target: func(t *testing.T) *tcpTargetConfig {
+				return &tcpTargetConfig{Endpoint: newTCPServer(t, "", "")}
+			},
+			expectedUp: 1,
+		},
+		{
+			desc: "expected banner",
+			target: func(t *testing.T) *tcpTargetConfig {
+				return &tcpTargetConfig{Endpoint: newTCPServer(t, "SSH-2.0-OpenSSH_9.3\r\n", ""), Expect: "SSH-2.0"}
+			},
+			expectedUp: 1,
+		},
+		{
+			desc: "reply to sent data",
+			target: func(t *testing.T) *tcpTargetConfig {
+				return &tcpTargetConfig{Endpoint: newTCPServer(t, "", "+PONG\r\n"), Send: "PING\r\n", Expect: "+PONG"}
+			},
+			expectedUp: 1,
+		},
+		{
+			desc: "unexpected banner",
+			target: func(t *testing.T) *tcpTargetConfig {
+				return &tcpTargetConfig{Endpoint: newTCPServer(t, "220 smtp ready\r\n", ""), Expect: "SSH-2.0", Timeout: 500 * time.Millisecond}
+			},
+			expectedUp:    0,
+			expectedError: `expected "SSH-2.0" not received`,
+		},
+		{
+			desc: "connection refused",
+			target: func(t *testing.T) *tcpTargetConfig {
+				return &tcpTargetConfig{Endpoint: closedEndpoint}
+			},
+			expectedUp:    0,
+			expectedError: "connection refused",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			target := tc.target(t)
+			cfg.TCPTargets = []*tcpTargetConfig{target}
+			scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+
+			metrics, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+
+			points := dataPoints(metrics)
+			require.Len(t, points["netcheck.tcp.status"], 1)
+			assert.Equal(t, tc.expectedUp, points["netcheck.tcp.status"][0].value)
+			assert.Equal(t, target.Endpoint, points["netcheck.tcp.status"][0].attrs["tcp.endpoint"])
+			require.Len(t, points["netcheck.tcp.duration"], 1)
+			if tc.expectedError == "" {
+				assert.Empty(t, points["netcheck.tcp.error"])
+			} else {
+				require.Len(t, points["netcheck.tcp.error"], 1)
+				assert.Contains(t, points["netcheck.tcp.error"][0].attrs["error.message"], tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestScraperDNS(t *testing.T) {
+	resolver := newDNSServer(t, map[string][4]byte{
+		"app.example.com.": {10, 0, 0, 1},
+	})
+
+	testCases := []struct {
+		desc          string
+		target        *dnsTargetConfig
+		expectedUp    int64
+		expectedError string
+	}{
+		{
+			desc:       "resolved",
+			target:     &dnsTargetConfig{Host: "app.example.com", Resolver: resolver},
+			expectedUp: 1,
+		},
+		{
+			desc:       "expected answer",
+			target:     &dnsTargetConfig{Host: "app.example.com", Resolver: resolver, RecordType: "a", ExpectedAnswers: []string{"10.0.0.1"}},
+			expectedUp: 1,
+		},
+		{
+			desc:          "unexpected answer",
+			target:        &dnsTargetConfig{Host: "app.example.com", Resolver: resolver, ExpectedAnswers: []string{"10.0.0.2"}},
+			expectedUp:    0,
+			expectedError: `expected answer "10.0.0.2" not found in [10.0.0.1]`,
+		},
+		{
+			desc:          "unknown host",
+			target:        &dnsTargetConfig{Host: "db.example.com", Resolver: resolver, Timeout: time.Second},
+			expectedUp:    0,
+			expectedError: "no such host",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.DNSTargets = []*dnsTargetConfig{tc.target}
+			scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+
+			metrics, err := scraper.scrape(context.Background())
+			require.NoError(t, err)
+
+			points := dataPoints(metrics)
+			require.Len(t, points["netcheck.dns.status"], 1)
+			assert.Equal(t, tc.expectedUp, points["netcheck.dns.status"][0].value)
+			assert.Equal(t, map[string]any{
+				"dns.host":        tc.target.Host,
+				"dns.record_type": "A",
+				"dns.resolver":    resolver,
+			}, points["netcheck.dns.status"][0].attrs)
+			require.Len(t, points["netcheck.dns.duration"], 1)
+			if tc.expectedError == "" {
+				assert.Empty(t, points["netcheck.dns.error"])
+			} else {
+				require.Len(t, points["netcheck.dns.error"], 1)
+				assert.Contains(t, points["netcheck.dns.error"][0].attrs["error.message"], tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestNewResolver(t *testing.T) {
+	assert.Same(t, net.DefaultResolver, newResolver(""))
+	assert.True(t, newResolver("127.0.0.1").PreferGo)
+}
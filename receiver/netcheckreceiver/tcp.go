@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package netcheckreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/netcheckreceiver"
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"time"
+)
+
+// maxBannerSize is the maximum amount of data read from a connection while waiting for the expected string.
+const maxBannerSize = 64 * 1024
+
+// checkTCP connects to the target and exchanges its banners, if any. It returns the time it took
+// to establish the connection.
+func checkTCP(ctx context.Context, target *tcpTargetConfig) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeoutOrDefault(target.Timeout))
+	defer cancel()
+
+	var dialer net.Dialer
+	start := time.Now()
+	conn, err := dialer.DialContext(ctx, "tcp", target.Endpoint)
+	connectDuration := time.Since(start)
+	if err != nil {
+		return connectDuration, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return connectDuration, err
+		}
+	}
+
+	if target.Send != "" {
+		if _, err = conn.Write([]byte(target.Send)); err != nil {
+			return connectDuration, err
+		}
+	}
+
+	if target.Expect != "" {
+		err = expect(conn, target.Expect)
+	}
+	return connectDuration, err
+}
+
+// expect reads from the connection until the expected string is received.
+func expect(conn net.Conn, expected string) error {
+	var received []byte
+	buf := make([]byte, 4096)
+	for len(received) < maxBannerSize {
+		n, err := conn.Read(buf)
+		received = append(received, buf[:n]...)
+		if bytes.Contains(received, []byte(expected)) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("expected %q not received: %w", expected, err)
+		}
+	}
+	return fmt.Errorf("expected %q not received in the first %d bytes", expected, maxBannerSize)
+}
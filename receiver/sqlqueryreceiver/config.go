@@ -4,6 +4,7 @@
 package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -21,6 +22,12 @@ type Config struct {
 	DataSource                              string        `mapstructure:"datasource"`
 	Queries                                 []Query       `mapstructure:"queries"`
 	StorageID                               *component.ID `mapstructure:"storage"`
+	// MaxOpenConn is the maximum number of open connections to the database, shared by all the queries.
+	// There is no limit if not set.
+	MaxOpenConn int `mapstructure:"max_open_conn"`
+	// MaxIdleConn is the maximum number of idle connections to the database kept open between queries.
+	// The database/sql default of 2 is used if not set.
+	MaxIdleConn int `mapstructure:"max_idle_conn"`
 }
 
 func (c Config) Validate() error {
@@ -33,6 +40,12 @@ func (c Config) Validate() error {
 	if len(c.Queries) == 0 {
 		return errors.New("'queries' cannot be empty")
 	}
+	if c.MaxOpenConn < 0 {
+		return errors.New("'max_open_conn' cannot be negative")
+	}
+	if c.MaxIdleConn < 0 {
+		return errors.New("'max_idle_conn' cannot be negative")
+	}
 	for _, query := range c.Queries {
 		if err := query.Validate(); err != nil {
 			return err
@@ -47,6 +60,10 @@ type Query struct {
 	Logs               []LogsCfg   `mapstructure:"logs"`
 	TrackingColumn     string      `mapstructure:"tracking_column"`
 	TrackingStartValue string      `mapstructure:"tracking_start_value"`
+	// CollectionInterval overrides the collection interval of the receiver for this query.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// Timeout cancels the query once exceeded. There is no timeout if not set.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 func (q Query) Validate() error {
@@ -57,6 +74,12 @@ func (q Query) Validate() error {
 	if len(q.Logs) == 0 && len(q.Metrics) == 0 {
 		errs = multierr.Append(errs, errors.New("at least one of 'query.logs' and 'query.metrics' must not be empty"))
 	}
+	if q.CollectionInterval < 0 {
+		errs = multierr.Append(errs, errors.New("'query.collection_interval' cannot be negative"))
+	}
+	if q.Timeout < 0 {
+		errs = multierr.Append(errs, errors.New("'query.timeout' cannot be negative"))
+	}
 	for _, logs := range q.Logs {
 		if err := logs.Validate(); err != nil {
 			errs = multierr.Append(errs, err)
@@ -70,6 +93,22 @@ func (q Query) Validate() error {
 	return errs
 }
 
+// collectionInterval returns the interval between executions of the query.
+func (q Query) collectionInterval(defaultInterval time.Duration) time.Duration {
+	if q.CollectionInterval > 0 {
+		return q.CollectionInterval
+	}
+	return defaultInterval
+}
+
+// withTimeout returns a context canceled once the query times out, if it has a timeout.
+func (q Query) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if q.Timeout > 0 {
+		return context.WithTimeout(ctx, q.Timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
 type LogsCfg struct {
 	BodyColumn string `mapstructure:"body_column"`
 }
@@ -129,6 +129,47 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			fname: "config-query-settings.yaml",
+			id:    component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: 10 * time.Second,
+					InitialDelay:       time.Second,
+				},
+				Driver:      "mydriver",
+				DataSource:  "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+				MaxOpenConn: 5,
+				MaxIdleConn: 2,
+				Queries: []Query{
+					{
+						SQL: "select count(*) as count from mytable",
+						Metrics: []MetricCfg{
+							{
+								MetricName:  "val.count",
+								ValueColumn: "count",
+							},
+						},
+					},
+					{
+						SQL:                "select count(*) as count from reporting_table",
+						CollectionInterval: 5 * time.Minute,
+						Timeout:            30 * time.Second,
+						Metrics: []MetricCfg{
+							{
+								MetricName:  "reporting.count",
+								ValueColumn: "count",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			fname:        "config-invalid-negative-timeout.yaml",
+			id:           component.NewIDWithName(metadata.Type, ""),
+			errorMessage: "'query.timeout' cannot be negative",
+		},
 		{
 			fname:        "config-logs-missing-body-column.yaml",
 			id:           component.NewIDWithName(metadata.Type, ""),
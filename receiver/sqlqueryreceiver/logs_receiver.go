@@ -23,16 +23,18 @@ import (
 )
 
 type logsReceiver struct {
-	config           *Config
-	settings         receiver.CreateSettings
-	createConnection dbProviderFunc
-	createClient     clientProviderFunc
-	queryReceivers   []*logsQueryReceiver
-	nextConsumer     consumer.Logs
-
-	isStarted                bool
-	collectionIntervalTicker *time.Ticker
-	shutdownRequested        chan struct{}
+	config            *Config
+	settings          receiver.CreateSettings
+	createConnection  dbProviderFunc
+	releaseConnection dbReleaseFunc
+	createClient      clientProviderFunc
+	queryReceivers    []*logsQueryReceiver
+	nextConsumer      consumer.Logs
+	telemetry         *telemetry
+
+	isStarted                 bool
+	collectionIntervalTickers []*time.Ticker
+	shutdownRequested         chan struct{}
 
 	id            component.ID
 	storageClient storage.Client
@@ -55,13 +57,22 @@ func newLogsReceiver(
 		return nil, err
 	}
 
+	telemetry, err := newTelemetry(settings.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
 	receiver := &logsReceiver{
 		config:   config,
 		settings: settings,
 		createConnection: func() (*sql.DB, error) {
-			return sqlOpenerFunc(config.Driver, config.DataSource)
+			return dbs.acquire(config, sqlOpenerFunc)
+		},
+		releaseConnection: func() error {
+			return dbs.release(config)
 		},
 		createClient:      createClient,
+		telemetry:         telemetry,
 		nextConsumer:      nextConsumer,
 		shutdownRequested: make(chan struct{}),
 		id:                settings.ID,
@@ -110,35 +121,53 @@ func (receiver *logsReceiver) createQueryReceivers() error {
 		id := fmt.Sprintf("query-%d: %s", i, query.SQL)
 		queryReceiver := newLogsQueryReceiver(
 			id,
+			fmt.Sprintf("query-%d", i),
 			query,
 			receiver.createConnection,
+			receiver.releaseConnection,
 			receiver.createClient,
 			receiver.settings.Logger,
 			receiver.storageClient,
+			receiver.telemetry,
 		)
 		receiver.queryReceivers = append(receiver.queryReceivers, queryReceiver)
 	}
 	return nil
 }
 
+// startCollecting starts collecting the queries sharing a collection interval together.
 func (receiver *logsReceiver) startCollecting() {
-	receiver.collectionIntervalTicker = time.NewTicker(receiver.config.CollectionInterval)
-
-	go func() {
-		for {
-			select {
-			case <-receiver.collectionIntervalTicker.C:
-				receiver.collect()
-			case <-receiver.shutdownRequested:
-				return
-			}
+	var intervals []time.Duration
+	queryReceiversByInterval := make(map[time.Duration][]*logsQueryReceiver)
+	for _, queryReceiver := range receiver.queryReceivers {
+		interval := queryReceiver.query.collectionInterval(receiver.config.CollectionInterval)
+		if _, ok := queryReceiversByInterval[interval]; !ok {
+			intervals = append(intervals, interval)
 		}
-	}()
+		queryReceiversByInterval[interval] = append(queryReceiversByInterval[interval], queryReceiver)
+	}
+
+	receiver.collectionIntervalTickers = nil
+	for _, interval := range intervals {
+		ticker := time.NewTicker(interval)
+		receiver.collectionIntervalTickers = append(receiver.collectionIntervalTickers, ticker)
+
+		go func(queryReceivers []*logsQueryReceiver) {
+			for {
+				select {
+				case <-ticker.C:
+					receiver.collect(queryReceivers)
+				case <-receiver.shutdownRequested:
+					return
+				}
+			}
+		}(queryReceiversByInterval[interval])
+	}
 }
 
-func (receiver *logsReceiver) collect() {
+func (receiver *logsReceiver) collect(queryReceivers []*logsQueryReceiver) {
 	logsChannel := make(chan plog.Logs)
-	for _, queryReceiver := range receiver.queryReceivers {
+	for _, queryReceiver := range queryReceivers {
 		go func(queryReceiver *logsQueryReceiver) {
 			logs, err := queryReceiver.collect(context.Background())
 			if err != nil {
@@ -149,7 +178,7 @@ func (receiver *logsReceiver) collect() {
 	}
 
 	allLogs := plog.NewLogs()
-	for range receiver.queryReceivers {
+	for range queryReceivers {
 		logs := <-logsChannel
 		logs.ResourceLogs().MoveAndAppendTo(allLogs.ResourceLogs())
 	}
@@ -173,11 +202,11 @@ func (receiver *logsReceiver) Shutdown(ctx context.Context) error {
 
 	receiver.settings.Logger.Debug("stopping...")
 	receiver.stopCollecting()
+	var errors error
 	for _, queryReceiver := range receiver.queryReceivers {
-		queryReceiver.shutdown(ctx)
+		errors = multierr.Append(errors, queryReceiver.shutdown(ctx))
 	}
 
-	var errors error
 	if receiver.storageClient != nil {
 		errors = multierr.Append(errors, receiver.storageClient.Close(ctx))
 	}
@@ -189,18 +218,21 @@ func (receiver *logsReceiver) Shutdown(ctx context.Context) error {
 }
 
 func (receiver *logsReceiver) stopCollecting() {
-	if receiver.collectionIntervalTicker != nil {
-		receiver.collectionIntervalTicker.Stop()
+	for _, ticker := range receiver.collectionIntervalTickers {
+		ticker.Stop()
 	}
 	close(receiver.shutdownRequested)
 }
 
 type logsQueryReceiver struct {
 	id           string
+	queryID      string
 	query        Query
 	createDb     dbProviderFunc
+	releaseDb    dbReleaseFunc
 	createClient clientProviderFunc
 	logger       *zap.Logger
+	telemetry    *telemetry
 
 	db            *sql.DB
 	client        dbClient
@@ -212,19 +244,25 @@ type logsQueryReceiver struct {
 
 func newLogsQueryReceiver(
 	id string,
+	queryID string,
 	query Query,
 	dbProviderFunc dbProviderFunc,
+	dbReleaseFunc dbReleaseFunc,
 	clientProviderFunc clientProviderFunc,
 	logger *zap.Logger,
 	storageClient storage.Client,
+	telemetry *telemetry,
 ) *logsQueryReceiver {
 	queryReceiver := &logsQueryReceiver{
 		id:            id,
+		queryID:       queryID,
 		query:         query,
 		createDb:      dbProviderFunc,
+		releaseDb:     dbReleaseFunc,
 		createClient:  clientProviderFunc,
 		logger:        logger,
 		storageClient: storageClient,
+		telemetry:     telemetry,
 	}
 	queryReceiver.trackingValue = queryReceiver.query.TrackingStartValue
 	queryReceiver.trackingValueStorageKey = fmt.Sprintf("%s.%s", queryReceiver.id, "trackingValue")
@@ -268,13 +306,17 @@ func (queryReceiver *logsQueryReceiver) retrieveTrackingValue(ctx context.Contex
 func (queryReceiver *logsQueryReceiver) collect(ctx context.Context) (plog.Logs, error) {
 	logs := plog.NewLogs()
 
+	queryCtx, cancel := queryReceiver.query.withTimeout(ctx)
+	defer cancel()
 	var rows []stringMap
 	var err error
+	start := time.Now()
 	if queryReceiver.query.TrackingColumn != "" {
-		rows, err = queryReceiver.client.queryRows(ctx, queryReceiver.trackingValue)
+		rows, err = queryReceiver.client.queryRows(queryCtx, queryReceiver.trackingValue)
 	} else {
-		rows, err = queryReceiver.client.queryRows(ctx)
+		rows, err = queryReceiver.client.queryRows(queryCtx)
 	}
+	queryReceiver.telemetry.record(ctx, queryReceiver.queryID, time.Since(start), err)
 	if err != nil {
 		return logs, fmt.Errorf("error getting rows: %w", err)
 	}
@@ -310,5 +352,10 @@ func rowToLog(row stringMap, config LogsCfg, logRecord plog.LogRecord) {
 	logRecord.Body().SetStr(row[config.BodyColumn])
 }
 
-func (queryReceiver *logsQueryReceiver) shutdown(_ context.Context) {
+func (queryReceiver *logsQueryReceiver) shutdown(_ context.Context) error {
+	// The client is only set once the db has been acquired.
+	if queryReceiver.client != nil && queryReceiver.releaseDb != nil {
+		return queryReceiver.releaseDb()
+	}
+	return nil
 }
@@ -7,11 +7,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -19,6 +21,8 @@ type sqlOpenerFunc func(driverName, dataSourceName string) (*sql.DB, error)
 
 type dbProviderFunc func() (*sql.DB, error)
 
+type dbReleaseFunc func() error
+
 type clientProviderFunc func(db, string, *zap.Logger) dbClient
 
 func createLogsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) receiver.CreateLogsFunc {
@@ -41,30 +45,85 @@ func createMetricsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc c
 		consumer consumer.Metrics,
 	) (receiver.Metrics, error) {
 		sqlCfg := cfg.(*Config)
-		var opts []scraperhelper.ScraperControllerOption
+		telemetry, err := newTelemetry(settings.TelemetrySettings)
+		if err != nil {
+			return nil, err
+		}
+
+		// The queries are scraped by one controller per collection interval.
+		var intervals []time.Duration
+		optsByInterval := make(map[time.Duration][]scraperhelper.ScraperControllerOption)
 		for i, query := range sqlCfg.Queries {
 			if len(query.Metrics) == 0 {
 				continue
 			}
+			interval := query.collectionInterval(sqlCfg.CollectionInterval)
+			if _, ok := optsByInterval[interval]; !ok {
+				intervals = append(intervals, interval)
+			}
+			scrapeCfg := sqlCfg.ScraperControllerSettings
+			scrapeCfg.CollectionInterval = interval
 			id := component.NewIDWithName("sqlqueryreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
 			mp := &scraper{
 				id:        id,
+				queryID:   fmt.Sprintf("query-%d", i),
 				query:     query,
-				scrapeCfg: sqlCfg.ScraperControllerSettings,
+				scrapeCfg: scrapeCfg,
 				logger:    settings.TelemetrySettings.Logger,
 				dbProviderFunc: func() (*sql.DB, error) {
-					return sqlOpenerFunc(sqlCfg.Driver, sqlCfg.DataSource)
+					return dbs.acquire(sqlCfg, sqlOpenerFunc)
+				},
+				dbReleaseFunc: func() error {
+					return dbs.release(sqlCfg)
 				},
 				clientProviderFunc: clientProviderFunc,
+				telemetry:          telemetry,
 			}
 			opt := scraperhelper.AddScraper(mp)
-			opts = append(opts, opt)
+			optsByInterval[interval] = append(optsByInterval[interval], opt)
+		}
+		if len(intervals) == 0 {
+			intervals = append(intervals, sqlCfg.CollectionInterval)
+		}
+
+		var controllers metricsReceivers
+		for _, interval := range intervals {
+			scrapeCfg := sqlCfg.ScraperControllerSettings
+			scrapeCfg.CollectionInterval = interval
+			controller, err := scraperhelper.NewScraperControllerReceiver(
+				&scrapeCfg,
+				settings,
+				consumer,
+				optsByInterval[interval]...,
+			)
+			if err != nil {
+				return nil, err
+			}
+			controllers = append(controllers, controller)
 		}
-		return scraperhelper.NewScraperControllerReceiver(
-			&sqlCfg.ScraperControllerSettings,
-			settings,
-			consumer,
-			opts...,
-		)
+		if len(controllers) == 1 {
+			return controllers[0], nil
+		}
+		return controllers, nil
+	}
+}
+
+// metricsReceivers runs the scraper controllers of the queries with different collection intervals.
+type metricsReceivers []receiver.Metrics
+
+func (r metricsReceivers) Start(ctx context.Context, host component.Host) error {
+	for _, rcv := range r {
+		if err := rcv.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r metricsReceivers) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, rcv := range r {
+		errs = multierr.Append(errs, rcv.Shutdown(ctx))
 	}
+	return errs
 }
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -78,3 +79,78 @@ func fakeDBConnect(string, string) (*sql.DB, error) {
 func mkFakeClient(db, string, *zap.Logger) dbClient {
 	return &fakeDBClient{stringMaps: [][]stringMap{{{"foo": "111"}}}}
 }
+
+func TestCreateMetricsReceiver_PerQueryInterval(t *testing.T) {
+	createReceiver := createMetricsReceiverFunc(fakeDBConnect, mkFakeClient)
+	ctx := context.Background()
+	metric := []MetricCfg{{
+		MetricName:  "my-metric",
+		ValueColumn: "my-column",
+	}}
+	receiver, err := createReceiver(
+		ctx,
+		receivertest.NewNopCreateSettings(),
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				CollectionInterval: 10 * time.Second,
+				InitialDelay:       time.Second,
+			},
+			Driver:     "mydriver",
+			DataSource: "my-datasource",
+			Queries: []Query{
+				{SQL: "select * from foo", Metrics: metric},
+				{SQL: "select * from bar", Metrics: metric, CollectionInterval: 10 * time.Second},
+				{SQL: "select * from baz", Metrics: metric, CollectionInterval: time.Minute, Timeout: 30 * time.Second},
+			},
+		},
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	require.IsType(t, metricsReceivers{}, receiver)
+	require.Len(t, receiver, 2)
+	require.NoError(t, receiver.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, receiver.Shutdown(ctx))
+}
+
+func TestLogsReceiver_PerQueryInterval(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	receiver, err := newLogsReceiver(
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				CollectionInterval: time.Hour,
+			},
+			Driver:     "mydriver",
+			DataSource: "my-datasource",
+			Queries: []Query{
+				{SQL: "select * from foo", Logs: []LogsCfg{{BodyColumn: "foo"}}},
+				{SQL: "select * from bar", Logs: []LogsCfg{{BodyColumn: "foo"}}, CollectionInterval: 10 * time.Millisecond},
+			},
+		},
+		receivertest.NewNopCreateSettings(),
+		fakeDBConnect,
+		func(_ db, sql string, _ *zap.Logger) dbClient {
+			return staticDBClient{{"foo": sql}}
+		},
+		sink,
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, receiver.Start(ctx, componenttest.NewNopHost()))
+	require.Len(t, receiver.collectionIntervalTickers, 2)
+
+	// Only the query collected every 10ms is collected before the hourly one.
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, receiver.Shutdown(ctx))
+	for _, logs := range sink.AllLogs() {
+		assert.Equal(t, "select * from bar", logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+	}
+}
+
+// staticDBClient returns the same rows for every query.
+type staticDBClient []stringMap
+
+func (c staticDBClient) queryRows(context.Context, ...any) ([]stringMap, error) {
+	return c, nil
+}
@@ -21,11 +21,14 @@ import (
 
 type scraper struct {
 	id                 component.ID
+	queryID            string
 	query              Query
 	scrapeCfg          scraperhelper.ScraperControllerSettings
 	startTime          pcommon.Timestamp
 	clientProviderFunc clientProviderFunc
 	dbProviderFunc     dbProviderFunc
+	dbReleaseFunc      dbReleaseFunc
+	telemetry          *telemetry
 	logger             *zap.Logger
 	client             dbClient
 	db                 *sql.DB
@@ -51,7 +54,11 @@ func (s *scraper) Start(context.Context, component.Host) error {
 
 func (s *scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
 	out := pmetric.NewMetrics()
-	rows, err := s.client.queryRows(ctx)
+	queryCtx, cancel := s.query.withTimeout(ctx)
+	defer cancel()
+	start := time.Now()
+	rows, err := s.client.queryRows(queryCtx)
+	s.telemetry.record(ctx, s.queryID, time.Since(start), err)
 	if err != nil {
 		if errors.Is(err, errNullValueWarning) {
 			s.logger.Warn("problems encountered getting metric rows", zap.Error(err))
@@ -81,8 +88,9 @@ func (s *scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
 }
 
 func (s *scraper) Shutdown(_ context.Context) error {
-	if s.db != nil {
-		return s.db.Close()
+	// The client is only set once the db has been acquired.
+	if s.client != nil && s.dbReleaseFunc != nil {
+		return s.dbReleaseFunc()
 	}
 	return nil
 }
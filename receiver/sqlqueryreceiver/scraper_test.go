@@ -8,6 +8,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -480,3 +481,26 @@ func TestScraper_StartAndTS_ErrorOnParse(t *testing.T) {
 	_, err := scrpr.Scrape(context.Background())
 	assert.Error(t, err)
 }
+
+func TestScraper_Timeout(t *testing.T) {
+	scrpr := scraper{
+		client: &blockingDBClient{},
+		query: Query{
+			Timeout: 10 * time.Millisecond,
+			Metrics: []MetricCfg{{
+				MetricName:  "my.metric",
+				ValueColumn: "count",
+			}},
+		},
+	}
+	_, err := scrpr.Scrape(context.Background())
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+// blockingDBClient blocks until the query is canceled.
+type blockingDBClient struct{}
+
+func (c *blockingDBClient) queryRows(ctx context.Context, _ ...any) ([]stringMap, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
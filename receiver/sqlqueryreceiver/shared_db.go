@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"database/sql"
+	"sync"
+)
+
+// dbs holds the databases of the receivers, so that the queries of the logs and
+// metrics receivers created from the same config share a single connection pool.
+var dbs = &sharedDBs{dbs: make(map[*Config]*sharedDB)}
+
+type sharedDBs struct {
+	mu  sync.Mutex
+	dbs map[*Config]*sharedDB
+}
+
+type sharedDB struct {
+	db   *sql.DB
+	refs int
+}
+
+// acquire returns the database of the config, opening it if it is not open yet.
+// Each call must be followed by a call to release once the database is not used anymore.
+func (s *sharedDBs) acquire(cfg *Config, sqlOpenerFunc sqlOpenerFunc) (*sql.DB, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if shared, ok := s.dbs[cfg]; ok {
+		shared.refs++
+		return shared.db, nil
+	}
+
+	db, err := sqlOpenerFunc(cfg.Driver, cfg.DataSource)
+	if err != nil {
+		return nil, err
+	}
+	if db != nil {
+		if cfg.MaxOpenConn > 0 {
+			db.SetMaxOpenConns(cfg.MaxOpenConn)
+		}
+		if cfg.MaxIdleConn > 0 {
+			db.SetMaxIdleConns(cfg.MaxIdleConn)
+		}
+	}
+	s.dbs[cfg] = &sharedDB{db: db, refs: 1}
+	return db, nil
+}
+
+// release closes the database of the config once it has been released as many times as it was acquired.
+func (s *sharedDBs) release(cfg *Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shared, ok := s.dbs[cfg]
+	if !ok {
+		return nil
+	}
+	shared.refs--
+	if shared.refs > 0 {
+		return nil
+	}
+	delete(s.dbs, cfg)
+	if shared.db != nil {
+		return shared.db.Close()
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedDBs(t *testing.T) {
+	cfg := &Config{
+		Driver:      "postgres",
+		DataSource:  "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+		MaxOpenConn: 5,
+		MaxIdleConn: 3,
+	}
+	opened := 0
+	opener := func(driverName, dataSourceName string) (*sql.DB, error) {
+		opened++
+		return sql.Open(driverName, dataSourceName)
+	}
+
+	shared := &sharedDBs{dbs: make(map[*Config]*sharedDB)}
+	db1, err := shared.acquire(cfg, opener)
+	require.NoError(t, err)
+	db2, err := shared.acquire(cfg, opener)
+	require.NoError(t, err)
+	assert.Same(t, db1, db2)
+	assert.Equal(t, 1, opened)
+	assert.Equal(t, 5, db1.Stats().MaxOpenConnections)
+
+	otherCfg := &Config{Driver: cfg.Driver, DataSource: cfg.DataSource}
+	other, err := shared.acquire(otherCfg, opener)
+	require.NoError(t, err)
+	assert.NotSame(t, db1, other)
+	assert.Equal(t, 0, other.Stats().MaxOpenConnections)
+
+	require.NoError(t, shared.release(cfg))
+	require.Contains(t, shared.dbs, cfg)
+	assert.Equal(t, 1, shared.dbs[cfg].refs)
+	require.NoError(t, shared.release(cfg))
+	assert.EqualError(t, db1.Ping(), "sql: database is closed")
+	assert.NotContains(t, shared.dbs, cfg)
+	require.NoError(t, shared.release(cfg))
+	require.NoError(t, shared.release(otherCfg))
+}
+
+func TestSharedDBs_OpenError(t *testing.T) {
+	cfg := &Config{Driver: "mydriver", DataSource: "my-datasource"}
+	shared := &sharedDBs{dbs: make(map[*Config]*sharedDB)}
+	_, err := shared.acquire(cfg, func(string, string) (*sql.DB, error) {
+		return nil, errors.New("oops")
+	})
+	require.EqualError(t, err, "oops")
+	assert.Empty(t, shared.dbs)
+}
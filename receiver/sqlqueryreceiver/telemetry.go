@@ -0,0 +1,60 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver/internal/metadata"
+)
+
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+	metricSep = "_"
+)
+
+// telemetry records the internal metrics of the queries. A nil telemetry records nothing.
+type telemetry struct {
+	queryDuration metric.Float64Histogram
+	queryErrors   metric.Int64Counter
+}
+
+func newTelemetry(settings component.TelemetrySettings) (*telemetry, error) {
+	meter := settings.MeterProvider.Meter(scopeName)
+
+	var err error
+	t := &telemetry{}
+	if t.queryDuration, err = meter.Float64Histogram(
+		metadata.Type+metricSep+"receiver"+metricSep+"query_duration",
+		metric.WithDescription("Duration of the query executions"),
+		metric.WithUnit("ms"),
+	); err != nil {
+		return nil, err
+	}
+	if t.queryErrors, err = meter.Int64Counter(
+		metadata.Type+metricSep+"receiver"+metricSep+"query_errors",
+		metric.WithDescription("Number of query executions that failed, including the ones that timed out"),
+	); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// record records the duration of an execution of the query, and whether it failed.
+func (t *telemetry) record(ctx context.Context, queryID string, duration time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	attrs := metric.WithAttributes(attribute.String("query", queryID))
+	t.queryDuration.Record(ctx, float64(duration)/float64(time.Millisecond), attrs)
+	if err != nil && !errors.Is(err, errNullValueWarning) {
+		t.queryErrors.Add(ctx, 1, attrs)
+	}
+}
@@ -17,6 +17,7 @@ import (
 // Config defines configuration for StatsD receiver.
 type Config struct {
 	NetAddr               confignet.NetAddr                `mapstructure:",squash"`
+	MaxConnections        int                              `mapstructure:"max_connections"`
 	AggregationInterval   time.Duration                    `mapstructure:"aggregation_interval"`
 	EnableMetricType      bool                             `mapstructure:"enable_metric_type"`
 	IsMonotonicCounter    bool                             `mapstructure:"is_monotonic_counter"`
@@ -30,6 +31,10 @@ func (c *Config) Validate() error {
 		errs = multierr.Append(errs, fmt.Errorf("aggregation_interval must be a positive duration"))
 	}
 
+	if c.MaxConnections < 0 {
+		errs = multierr.Append(errs, fmt.Errorf("max_connections must not be negative"))
+	}
+
 	var TimerHistogramMappingMissingObjectName bool
 	for _, eachMap := range c.TimerHistogramMapping {
 
@@ -41,6 +41,7 @@ func TestLoadConfig(t *testing.T) {
 					Endpoint:  "localhost:12345",
 					Transport: "custom_transport",
 				},
+				MaxConnections:      100,
 				AggregationInterval: 70 * time.Second,
 				TimerHistogramMapping: []protocol.TimerHistogramMapping{
 					{
@@ -86,9 +87,18 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping for histogram and timing metrics: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported for histogram and timing metrics: %s"
+		negativeMaxConnectionsErr      = "max_connections must not be negative"
 	)
 
 	tests := []test{
+		{
+			name: "negativeMaxConnections",
+			cfg: &Config{
+				AggregationInterval: 10,
+				MaxConnections:      -1,
+			},
+			expectedErr: negativeMaxConnectionsErr,
+		},
 		{
 			name: "negativeAggregationInterval",
 			cfg: &Config{
@@ -12,6 +12,7 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
@@ -34,6 +35,7 @@ func NewFactory() receiver.Factory {
 		metadata.Type,
 		createDefaultConfig,
 		receiver.WithMetrics(createMetricsReceiver, metadata.MetricsStability),
+		receiver.WithLogs(createLogsReceiver, metadata.LogsStability),
 	)
 }
 
@@ -56,6 +58,51 @@ func createMetricsReceiver(
 	cfg component.Config,
 	consumer consumer.Metrics,
 ) (receiver.Metrics, error) {
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
 	c := cfg.(*Config)
-	return New(params, *c, consumer)
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv *statsdReceiver
+		rcv, err = newReceiver(params, *c)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextMetrics = consumer
+	return r, nil
 }
+
+// createLogsReceiver creates a receiver for the events and service checks
+// sent by DogStatsD clients. It shares its server with the metrics receiver
+// created with the same configuration.
+func createLogsReceiver(
+	_ context.Context,
+	params receiver.CreateSettings,
+	cfg component.Config,
+	consumer consumer.Logs,
+) (receiver.Logs, error) {
+	if consumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+
+	c := cfg.(*Config)
+	var err error
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var rcv *statsdReceiver
+		rcv, err = newReceiver(params, *c)
+		return rcv
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextLogs = consumer
+	return r, nil
+}
+
+// receivers ensures a single server is started when the receiver is part of
+// both metrics and logs pipelines.
+var receivers = sharedcomponent.NewSharedComponents()
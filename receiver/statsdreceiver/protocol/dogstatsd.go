@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+	containerIDPrefix  = "c:"
+
+	eventTitleAttr          = "statsd.event.title"
+	eventPriorityAttr       = "statsd.event.priority"
+	eventAlertTypeAttr      = "statsd.event.alert_type"
+	eventSourceTypeAttr     = "statsd.event.source_type_name"
+	eventAggregationKeyAttr = "statsd.event.aggregation_key"
+	serviceCheckNameAttr    = "statsd.service_check.name"
+	serviceCheckStatusAttr  = "statsd.service_check.status"
+)
+
+var eventSeverities = map[string]plog.SeverityNumber{
+	"info":    plog.SeverityNumberInfo,
+	"success": plog.SeverityNumberInfo,
+	"warning": plog.SeverityNumberWarn,
+	"error":   plog.SeverityNumberError,
+}
+
+var serviceCheckStatuses = []struct {
+	name     string
+	severity plog.SeverityNumber
+}{
+	{"OK", plog.SeverityNumberInfo},
+	{"WARNING", plog.SeverityNumberWarn},
+	{"CRITICAL", plog.SeverityNumberError},
+	{"UNKNOWN", plog.SeverityNumberUnspecified},
+}
+
+// logParser parses a DogStatsD event or service check into lr, and returns
+// the ID of the container it was sent from, if any.
+type logParser func(line string, lr plog.LogRecord) (containerID string, err error)
+
+func (p *StatsDParser) appendLog(line string, addr net.Addr, parse logParser) error {
+	lr := plog.NewLogRecord()
+	containerID, err := parse(line, lr)
+	if err != nil {
+		return err
+	}
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(timeNowFunc()))
+
+	key := newInstrumentsKey(addr, containerID)
+	batch, ok := p.logsByAddress[key]
+	if !ok {
+		batch = BatchLogs{
+			Info: client.Info{
+				Addr: addr,
+			},
+			Logs: plog.NewLogs(),
+		}
+		rl := batch.Logs.ResourceLogs().AppendEmpty()
+		if containerID != "" {
+			rl.Resource().Attributes().PutStr(conventions.AttributeContainerID, containerID)
+		}
+		p.setVersionAndNameScope(rl.ScopeLogs().AppendEmpty().Scope())
+		p.logsByAddress[key] = batch
+	}
+	lr.MoveTo(batch.Logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().AppendEmpty())
+	return nil
+}
+
+// GetLogs gets the DogStatsD events and service checks received since the last call.
+func (p *StatsDParser) GetLogs() []BatchLogs {
+	batchLogs := make([]BatchLogs, 0, len(p.logsByAddress))
+	for _, batch := range p.logsByAddress {
+		batchLogs = append(batchLogs, batch)
+	}
+	p.logsByAddress = make(map[instrumentsKey]BatchLogs)
+	return batchLogs
+}
+
+// parseEvent parses a DogStatsD event, whose format is:
+// _e{<title length>,<text length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert type>|s:<source type name>|k:<aggregation key>|#<tags>|c:<container id>
+func parseEvent(line string, lr plog.LogRecord) (string, error) {
+	lengths, rest, ok := strings.Cut(strings.TrimPrefix(line, eventPrefix), "}:")
+	if !ok {
+		return "", fmt.Errorf("invalid event format: %s", line)
+	}
+	titleLenStr, textLenStr, ok := strings.Cut(lengths, ",")
+	if !ok {
+		return "", fmt.Errorf("invalid event lengths: %s", lengths)
+	}
+	titleLen, err := strconv.Atoi(titleLenStr)
+	if err != nil || titleLen <= 0 {
+		return "", fmt.Errorf("invalid event title length: %s", titleLenStr)
+	}
+	textLen, err := strconv.Atoi(textLenStr)
+	if err != nil || textLen < 0 {
+		return "", fmt.Errorf("invalid event text length: %s", textLenStr)
+	}
+	end := titleLen + 1 + textLen
+	if len(rest) < end || rest[titleLen] != '|' || (len(rest) > end && rest[end] != '|') {
+		return "", fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	lr.Body().SetStr(unescapeDogStatsD(rest[titleLen+1 : end]))
+	lr.Attributes().PutStr(eventTitleAttr, rest[:titleLen])
+	alertType := "info"
+	var containerID string
+	// Splitting "" or "|..." always yields an empty first element.
+	for _, part := range strings.Split(rest[end:], "|")[1:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			timestamp, err := parseUnixTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return "", err
+			}
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+		case strings.HasPrefix(part, "h:"):
+			lr.Attributes().PutStr(conventions.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "p:"):
+			lr.Attributes().PutStr(eventPriorityAttr, strings.TrimPrefix(part, "p:"))
+		case strings.HasPrefix(part, "t:"):
+			alertType = strings.TrimPrefix(part, "t:")
+			if _, ok := eventSeverities[alertType]; !ok {
+				return "", fmt.Errorf("unsupported event alert type: %s", alertType)
+			}
+		case strings.HasPrefix(part, "s:"):
+			lr.Attributes().PutStr(eventSourceTypeAttr, strings.TrimPrefix(part, "s:"))
+		case strings.HasPrefix(part, "k:"):
+			lr.Attributes().PutStr(eventAggregationKeyAttr, strings.TrimPrefix(part, "k:"))
+		case strings.HasPrefix(part, "#"):
+			if err := putTags(lr.Attributes(), strings.TrimPrefix(part, "#")); err != nil {
+				return "", err
+			}
+		case strings.HasPrefix(part, containerIDPrefix):
+			containerID = strings.TrimPrefix(part, containerIDPrefix)
+		default:
+			return "", fmt.Errorf("unrecognized event part: %s", part)
+		}
+	}
+	lr.Attributes().PutStr(eventAlertTypeAttr, alertType)
+	lr.SetSeverityNumber(eventSeverities[alertType])
+	lr.SetSeverityText(alertType)
+
+	return containerID, nil
+}
+
+// parseServiceCheck parses a DogStatsD service check, whose format is:
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>|c:<container id>
+func parseServiceCheck(line string, lr plog.LogRecord) (string, error) {
+	parts := strings.Split(line, "|")
+	if len(parts) < 3 {
+		return "", fmt.Errorf("invalid service check format: %s", line)
+	}
+	if parts[1] == "" {
+		return "", fmt.Errorf("empty service check name")
+	}
+	status, err := strconv.Atoi(parts[2])
+	if err != nil || status < 0 || status >= len(serviceCheckStatuses) {
+		return "", fmt.Errorf("unsupported service check status: %s", parts[2])
+	}
+
+	lr.Attributes().PutStr(serviceCheckNameAttr, parts[1])
+	lr.Attributes().PutStr(serviceCheckStatusAttr, serviceCheckStatuses[status].name)
+	lr.SetSeverityNumber(serviceCheckStatuses[status].severity)
+	lr.SetSeverityText(serviceCheckStatuses[status].name)
+	lr.Body().SetStr("")
+	var containerID string
+	for _, part := range parts[3:] {
+		switch {
+		case strings.HasPrefix(part, "d:"):
+			timestamp, err := parseUnixTimestamp(strings.TrimPrefix(part, "d:"))
+			if err != nil {
+				return "", err
+			}
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+		case strings.HasPrefix(part, "h:"):
+			lr.Attributes().PutStr(conventions.AttributeHostName, strings.TrimPrefix(part, "h:"))
+		case strings.HasPrefix(part, "m:"):
+			lr.Body().SetStr(strings.ReplaceAll(unescapeDogStatsD(strings.TrimPrefix(part, "m:")), `m\:`, "m:"))
+		case strings.HasPrefix(part, "#"):
+			if err := putTags(lr.Attributes(), strings.TrimPrefix(part, "#")); err != nil {
+				return "", err
+			}
+		case strings.HasPrefix(part, containerIDPrefix):
+			containerID = strings.TrimPrefix(part, containerIDPrefix)
+		default:
+			return "", fmt.Errorf("unrecognized service check part: %s", part)
+		}
+	}
+
+	return containerID, nil
+}
+
+func putTags(attrs pcommon.Map, tagsStr string) error {
+	tags, err := parseTags(tagsStr)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		attrs.PutStr(string(tag.Key), tag.Value.AsString())
+	}
+	return nil
+}
+
+// unescapeDogStatsD restores the new lines DogStatsD clients escape in texts.
+func unescapeDogStatsD(s string) string {
+	return strings.ReplaceAll(s, `\n`, "\n")
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package protocol
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestParseEvent(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		wantBody        string
+		wantAttrs       map[string]any
+		wantSeverity    plog.SeverityNumber
+		wantTimestamp   int64
+		wantContainerID string
+		err             error
+	}{
+		{
+			name:     "title and text",
+			input:    "_e{5,4}:title|text",
+			wantBody: "text",
+			wantAttrs: map[string]any{
+				"statsd.event.title":      "title",
+				"statsd.event.alert_type": "info",
+			},
+			wantSeverity: plog.SeverityNumberInfo,
+		},
+		{
+			name:     "all fields",
+			input:    `_e{9,12}:Deploy|ed|line1\nline2|d:1656581400|h:web-1|p:low|t:error|s:jenkins|k:deploys|#env:prod,team:core|c:abc123`,
+			wantBody: "line1\nline2",
+			wantAttrs: map[string]any{
+				"statsd.event.title":            "Deploy|ed",
+				"statsd.event.alert_type":       "error",
+				"statsd.event.priority":         "low",
+				"statsd.event.source_type_name": "jenkins",
+				"statsd.event.aggregation_key":  "deploys",
+				"host.name":                     "web-1",
+				"env":                           "prod",
+				"team":                          "core",
+			},
+			wantSeverity:    plog.SeverityNumberError,
+			wantTimestamp:   time.Unix(1656581400, 0).UnixNano(),
+			wantContainerID: "abc123",
+		},
+		{
+			name:  "lengths not matching",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "invalid lengths",
+			input: "_e{5}:title|text",
+			err:   errors.New("invalid event lengths: 5"),
+		},
+		{
+			name:  "unsupported alert type",
+			input: "_e{5,4}:title|text|t:fatal",
+			err:   errors.New("unsupported event alert type: fatal"),
+		},
+		{
+			name:  "unrecognized part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized event part: x:y"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			containerID, err := parseEvent(tt.input, lr)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, lr.Body().Str())
+			assert.Equal(t, tt.wantAttrs, lr.Attributes().AsRaw())
+			assert.Equal(t, tt.wantSeverity, lr.SeverityNumber())
+			assert.Equal(t, tt.wantTimestamp, int64(lr.Timestamp()))
+			assert.Equal(t, tt.wantContainerID, containerID)
+		})
+	}
+}
+
+func TestParseServiceCheck(t *testing.T) {
+	tests := []struct {
+		name            string
+		input           string
+		wantBody        string
+		wantAttrs       map[string]any
+		wantSeverity    plog.SeverityNumber
+		wantTimestamp   int64
+		wantContainerID string
+		err             error
+	}{
+		{
+			name:  "name and status",
+			input: "_sc|db.can_connect|0",
+			wantAttrs: map[string]any{
+				"statsd.service_check.name":   "db.can_connect",
+				"statsd.service_check.status": "OK",
+			},
+			wantSeverity: plog.SeverityNumberInfo,
+		},
+		{
+			name:     "all fields",
+			input:    `_sc|db.can_connect|2|d:1656581400|h:db-1|#env:prod|m:connection refused\nm\: retrying|c:abc123`,
+			wantBody: "connection refused\nm: retrying",
+			wantAttrs: map[string]any{
+				"statsd.service_check.name":   "db.can_connect",
+				"statsd.service_check.status": "CRITICAL",
+				"host.name":                   "db-1",
+				"env":                         "prod",
+			},
+			wantSeverity:    plog.SeverityNumberError,
+			wantTimestamp:   time.Unix(1656581400, 0).UnixNano(),
+			wantContainerID: "abc123",
+		},
+		{
+			name:  "missing status",
+			input: "_sc|db.can_connect",
+			err:   errors.New("invalid service check format: _sc|db.can_connect"),
+		},
+		{
+			name:  "unsupported status",
+			input: "_sc|db.can_connect|4",
+			err:   errors.New("unsupported service check status: 4"),
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty service check name"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lr := plog.NewLogRecord()
+			containerID, err := parseServiceCheck(tt.input, lr)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantBody, lr.Body().Str())
+			assert.Equal(t, tt.wantAttrs, lr.Attributes().AsRaw())
+			assert.Equal(t, tt.wantSeverity, lr.SeverityNumber())
+			assert.Equal(t, tt.wantTimestamp, int64(lr.Timestamp()))
+			assert.Equal(t, tt.wantContainerID, containerID)
+		})
+	}
+}
+
+func TestStatsDParser_GetLogs(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	assert.NoError(t, p.Aggregate("_e{5,4}:title|text|c:abc123", addr))
+	assert.NoError(t, p.Aggregate("_sc|db.can_connect|1|c:abc123", addr))
+	assert.NoError(t, p.Aggregate("requests:1|c", addr))
+	assert.Error(t, p.Aggregate("_sc|db.can_connect|9", addr))
+
+	batches := p.GetLogs()
+	require.Len(t, batches, 1)
+	assert.Equal(t, addr, batches[0].Info.Addr)
+	rl := batches[0].Logs.ResourceLogs().At(0)
+	assert.Equal(t, map[string]any{"container.id": "abc123"}, rl.Resource().Attributes().AsRaw())
+	assert.Equal(t, receiverName, rl.ScopeLogs().At(0).Scope().Name())
+	lrs := rl.ScopeLogs().At(0).LogRecords()
+	require.Equal(t, 2, lrs.Len())
+	assert.Equal(t, "text", lrs.At(0).Body().Str())
+	assert.Equal(t, plog.SeverityNumberWarn, lrs.At(1).SeverityNumber())
+	assert.Equal(t, time.Unix(711, 0).UnixNano(), int64(lrs.At(1).ObservedTimestamp()))
+
+	assert.Empty(t, p.GetLogs())
+	assert.Len(t, p.GetMetrics(), 1)
+}
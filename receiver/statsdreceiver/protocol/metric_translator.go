@@ -137,6 +137,17 @@ func buildHistogramMetric(desc statsDMetricDescription, histogram histogramMetri
 	}
 }
 
+func buildSetMetric(desc statsDMetricDescription, values map[string]struct{}, timeNow time.Time, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	dp := nm.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(int64(len(values)))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+}
+
 func (s statsDMetric) counterValue() int64 {
 	x := s.asFloat
 	// Note statds counters are always represented as integers.
@@ -166,6 +177,14 @@ func (s statsDMetric) sampleValue() sampleValue {
 	}
 }
 
+// timestampOr returns the timestamp sent along with the metric, or timeNow if there is none.
+func (s statsDMetric) timestampOr(timeNow time.Time) time.Time {
+	if s.timestamp.IsZero() {
+		return timeNow
+	}
+	return s.timestamp
+}
+
 type dualSorter struct {
 	values, weights []float64
 }
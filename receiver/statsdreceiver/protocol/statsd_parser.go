@@ -16,6 +16,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -37,12 +38,17 @@ const (
 	GaugeType     MetricType = "g"
 	HistogramType MetricType = "h"
 	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
+	// SetType and DistributionType are DogStatsD extensions.
+	SetType          MetricType = "s"
+	DistributionType MetricType = "d"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	SetTypeName          TypeName = "set"
+	DistributionTypeName TypeName = "distribution"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -75,31 +81,48 @@ var defaultObserverCategory = ObserverCategory{
 
 // StatsDParser supports the Parse method for parsing StatsD messages with Tags.
 type StatsDParser struct {
-	instrumentsByAddress map[netAddr]*instruments
+	instrumentsByAddress map[instrumentsKey]*instruments
+	logsByAddress        map[instrumentsKey]BatchLogs
 	enableMetricType     bool
 	isMonotonicCounter   bool
 	timerEvents          ObserverCategory
 	histogramEvents      ObserverCategory
+	distributionEvents   ObserverCategory
 	lastIntervalTime     time.Time
 	BuildInfo            component.BuildInfo
 }
 
+// instrumentsKey identifies the source of the received lines: the address of the
+// client and, for DogStatsD clients, the ID of the container they run in.
+type instrumentsKey struct {
+	addr        netAddr
+	containerID string
+}
+
+func newInstrumentsKey(addr net.Addr, containerID string) instrumentsKey {
+	return instrumentsKey{addr: newNetAddr(addr), containerID: containerID}
+}
+
 type instruments struct {
 	addr                   net.Addr
+	containerID            string
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
 	summaries              map[statsDMetricDescription]summaryMetric
 	histograms             map[statsDMetricDescription]histogramMetric
+	sets                   map[statsDMetricDescription]map[string]struct{}
 	timersAndDistributions []pmetric.ScopeMetrics
 }
 
-func newInstruments(addr net.Addr) *instruments {
+func newInstruments(addr net.Addr, containerID string) *instruments {
 	return &instruments{
-		addr:       addr,
-		gauges:     make(map[statsDMetricDescription]pmetric.ScopeMetrics),
-		counters:   make(map[statsDMetricDescription]pmetric.ScopeMetrics),
-		summaries:  make(map[statsDMetricDescription]summaryMetric),
-		histograms: make(map[statsDMetricDescription]histogramMetric),
+		addr:        addr,
+		containerID: containerID,
+		gauges:      make(map[statsDMetricDescription]pmetric.ScopeMetrics),
+		counters:    make(map[statsDMetricDescription]pmetric.ScopeMetrics),
+		summaries:   make(map[statsDMetricDescription]summaryMetric),
+		histograms:  make(map[statsDMetricDescription]histogramMetric),
+		sets:        make(map[statsDMetricDescription]map[string]struct{}),
 	}
 }
 
@@ -122,9 +145,14 @@ type histogramMetric struct {
 type statsDMetric struct {
 	description statsDMetricDescription
 	asFloat     float64
-	addition    bool
-	unit        string
-	sampleRate  float64
+	// setValue is the raw value of set metrics, which are not necessarily numbers.
+	setValue   string
+	addition   bool
+	unit       string
+	sampleRate float64
+	// containerID and timestamp are only set by DogStatsD clients.
+	containerID string
+	timestamp   time.Time
 }
 
 type statsDMetricDescription struct {
@@ -143,20 +171,30 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case SetType:
+		return SetTypeName
+	case DistributionType:
+		return DistributionTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
 
 func (p *StatsDParser) resetState(when time.Time) {
 	p.lastIntervalTime = when
-	p.instrumentsByAddress = make(map[netAddr]*instruments)
+	p.instrumentsByAddress = make(map[instrumentsKey]*instruments)
 }
 
 func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error {
 	p.resetState(timeNowFunc())
+	p.logsByAddress = make(map[instrumentsKey]BatchLogs)
 
 	p.histogramEvents = defaultObserverCategory
 	p.timerEvents = defaultObserverCategory
+	// Distributions are always aggregated into exponential histograms, as the Datadog agent does.
+	p.distributionEvents = ObserverCategory{
+		method:          HistogramObserver,
+		histogramConfig: expoHistogramConfig(HistogramConfig{}),
+	}
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).validate()
@@ -194,6 +232,9 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 			Metrics: pmetric.NewMetrics(),
 		}
 		rm := batch.Metrics.ResourceMetrics().AppendEmpty()
+		if instrument.containerID != "" {
+			rm.Resource().Attributes().PutStr(conventions.AttributeContainerID, instrument.containerID)
+		}
 		for _, metric := range instrument.gauges {
 			p.copyMetricAndScope(rm, metric)
 		}
@@ -234,6 +275,13 @@ func (p *StatsDParser) GetMetrics() []BatchMetrics {
 			)
 		}
 
+		for desc, values := range instrument.sets {
+			ilm := rm.ScopeMetrics().AppendEmpty()
+			p.setVersionAndNameScope(ilm.Scope())
+
+			buildSetMetric(desc, values, now, ilm)
+		}
+
 		batchMetrics = append(batchMetrics, batch)
 	}
 	p.resetState(now)
@@ -259,22 +307,32 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 		return p.histogramEvents
 	case TimingType:
 		return p.timerEvents
-	case CounterType, GaugeType:
+	case DistributionType:
+		return p.distributionEvents
+	case CounterType, GaugeType, SetType:
 	}
 	return defaultObserverCategory
 }
 
-// Aggregate for each metric line.
+// Aggregate for each metric line. DogStatsD events and service checks are
+// not aggregated, they are kept as logs until GetLogs is called.
 func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
+	switch {
+	case strings.HasPrefix(line, eventPrefix):
+		return p.appendLog(line, addr, parseEvent)
+	case strings.HasPrefix(line, serviceCheckPrefix):
+		return p.appendLog(line, addr, parseServiceCheck)
+	}
+
 	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
 	if err != nil {
 		return err
 	}
 
-	addrKey := newNetAddr(addr)
+	addrKey := newInstrumentsKey(addr, parsedMetric.containerID)
 	instrument, ok := p.instrumentsByAddress[addrKey]
 	if !ok {
-		instrument = newInstruments(addr)
+		instrument = newInstruments(addr, parsedMetric.containerID)
 		p.instrumentsByAddress[addrKey] = instrument
 	}
 
@@ -282,16 +340,24 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 	case GaugeType:
 		_, ok := instrument.gauges[parsedMetric.description]
 		if !ok {
-			instrument.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timeNowFunc())
+			instrument.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, parsedMetric.timestampOr(timeNowFunc()))
 		} else {
 			if parsedMetric.addition {
 				point := instrument.gauges[parsedMetric.description].Metrics().At(0).Gauge().DataPoints().At(0)
 				point.SetDoubleValue(point.DoubleValue() + parsedMetric.gaugeValue())
 			} else {
-				instrument.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timeNowFunc())
+				instrument.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, parsedMetric.timestampOr(timeNowFunc()))
 			}
 		}
 
+	case SetType:
+		values, ok := instrument.sets[parsedMetric.description]
+		if !ok {
+			values = make(map[string]struct{})
+			instrument.sets[parsedMetric.description] = values
+		}
+		values[parsedMetric.setValue] = struct{}{}
+
 	case CounterType:
 		_, ok := instrument.counters[parsedMetric.description]
 		if !ok {
@@ -301,11 +367,11 @@ func (p *StatsDParser) Aggregate(line string, addr net.Addr) error {
 			point.SetIntValue(point.IntValue() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
+	case TimingType, HistogramType, DistributionType:
 		category := p.observerCategoryFor(parsedMetric.description.metricType)
 		switch category.method {
 		case GaugeObserver:
-			instrument.timersAndDistributions = append(instrument.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
+			instrument.timersAndDistributions = append(instrument.timersAndDistributions, buildGaugeMetric(parsedMetric, parsedMetric.timestampOr(timeNowFunc())))
 		case SummaryObserver:
 			raw := parsedMetric.sampleValue()
 			if existing, ok := instrument.summaries[parsedMetric.description]; !ok {
@@ -372,7 +438,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, SetType, DistributionType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -394,27 +460,32 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.SplitN(tagSet, ":", 2)
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				k := tagParts[0]
-				v := tagParts[1]
-				kvs = append(kvs, attribute.String(k, v))
+			tags, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
+			}
+			kvs = append(kvs, tags...)
+		case strings.HasPrefix(part, containerIDPrefix):
+			result.containerID = strings.TrimPrefix(part, containerIDPrefix)
+		case strings.HasPrefix(part, "T"):
+			timestamp, err := parseUnixTimestamp(strings.TrimPrefix(part, "T"))
+			if err != nil {
+				return result, err
 			}
+			result.timestamp = timestamp
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+
+	if result.description.metricType == SetType {
+		result.setValue = valueStr
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
 	// add metric_type dimension for all metrics
@@ -431,6 +502,26 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 	return result, nil
 }
 
+func parseTags(tagsStr string) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+	for _, tagSet := range strings.Split(tagsStr, ",") {
+		tagParts := strings.SplitN(tagSet, ":", 2)
+		if len(tagParts) != 2 {
+			return nil, fmt.Errorf("invalid tag format: %s", tagParts)
+		}
+		kvs = append(kvs, attribute.String(tagParts[0], tagParts[1]))
+	}
+	return kvs, nil
+}
+
+func parseUnixTimestamp(s string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse timestamp: %s", s)
+	}
+	return time.Unix(seconds, 0), nil
+}
+
 type netAddr struct {
 	Network string
 	String  string
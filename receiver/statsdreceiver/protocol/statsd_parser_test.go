@@ -656,7 +656,7 @@ func TestStatsDParser_Aggregate(t *testing.T) {
 			assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
 			p.lastIntervalTime = time.Unix(611, 0)
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := newInstrumentsKey(addr, "")
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -730,7 +730,7 @@ func TestStatsDParser_AggregateByAddress(t *testing.T) {
 				}
 			}
 			for i, addr := range tt.addresses {
-				addrKey := newNetAddr(addr)
+				addrKey := newInstrumentsKey(addr, "")
 				assert.Equal(t, tt.expectedGauges[i], p.instrumentsByAddress[addrKey].gauges)
 			}
 		})
@@ -793,7 +793,7 @@ func TestStatsDParser_AggregateWithMetricType(t *testing.T) {
 			assert.NoError(t, p.Initialize(true, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
 			p.lastIntervalTime = time.Unix(611, 0)
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := newInstrumentsKey(addr, "")
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -843,7 +843,7 @@ func TestStatsDParser_AggregateWithIsMonotonicCounter(t *testing.T) {
 			assert.NoError(t, p.Initialize(false, true, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
 			p.lastIntervalTime = time.Unix(611, 0)
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := newInstrumentsKey(addr, "")
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -940,7 +940,7 @@ func TestStatsDParser_AggregateTimerWithSummary(t *testing.T) {
 			p := &StatsDParser{}
 			assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "summary"}, {StatsdType: "histogram", ObserverType: "summary"}}))
 			addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-			addrKey := newNetAddr(addr)
+			addrKey := newInstrumentsKey(addr, "")
 			for _, line := range tt.input {
 				err = p.Aggregate(line, addr)
 			}
@@ -962,8 +962,8 @@ func TestStatsDParser_Initialize(t *testing.T) {
 		attrs:      *attribute.EmptySet(),
 	}
 	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
-	addrKey := newNetAddr(addr)
-	instrument := newInstruments(addr)
+	addrKey := newInstrumentsKey(addr, "")
+	instrument := newInstruments(addr, "")
 	instrument.gauges[teststatsdDMetricdescription] = pmetric.ScopeMetrics{}
 	p.instrumentsByAddress[addrKey] = instrument
 	assert.Equal(t, 1, len(p.instrumentsByAddress))
@@ -975,7 +975,7 @@ func TestStatsDParser_Initialize(t *testing.T) {
 func TestStatsDParser_GetMetricsWithMetricType(t *testing.T) {
 	p := &StatsDParser{}
 	assert.NoError(t, p.Initialize(true, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}}))
-	instrument := newInstruments(nil)
+	instrument := newInstruments(nil, "")
 	instrument.gauges[testDescription("statsdTestMetric1", "g",
 		[]string{"mykey", "metric_type"}, []string{"myvalue", "gauge"})] = buildGaugeMetric(testStatsDMetric("testGauge1", 1, false, "g", 0, []string{"mykey", "metric_type"}, []string{"myvalue", "gauge"}), time.Unix(711, 0))
 	instrument.gauges[testDescription("statsdTestMetric1", "g",
@@ -990,7 +990,7 @@ func TestStatsDParser_GetMetricsWithMetricType(t *testing.T) {
 			weights: []float64{1, 1, 1, 1},
 		},
 	}
-	p.instrumentsByAddress[netAddr{}] = instrument
+	p.instrumentsByAddress[instrumentsKey{}] = instrument
 	metrics := p.GetMetrics()[0].Metrics
 	assert.Equal(t, 5, metrics.ResourceMetrics().At(0).ScopeMetrics().Len())
 }
@@ -1335,3 +1335,138 @@ func TestStatsDParser_AggregateTimerWithHistogram(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseMessageToMetricDogStatsD(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		wantMetric statsDMetric
+		err        error
+	}{
+		{
+			name:  "set",
+			input: "test.metric:user-42|s|#key:value",
+			wantMetric: statsDMetric{
+				description: testDescription("test.metric", "s", []string{"key"}, []string{"value"}),
+				setValue:    "user-42",
+			},
+		},
+		{
+			name:  "distribution",
+			input: "test.metric:42.5|d|@0.5",
+			wantMetric: statsDMetric{
+				description: statsDMetricDescription{name: "test.metric", metricType: "d"},
+				asFloat:     42.5,
+				sampleRate:  0.5,
+			},
+		},
+		{
+			name:  "container id and timestamp",
+			input: "test.metric:42|g|#key:value|c:abc123|T1656581400",
+			wantMetric: statsDMetric{
+				description: testDescription("test.metric", "g", []string{"key"}, []string{"value"}),
+				asFloat:     42,
+				containerID: "abc123",
+				timestamp:   time.Unix(1656581400, 0),
+			},
+		},
+		{
+			name:  "invalid timestamp",
+			input: "test.metric:42|g|Tnow",
+			err:   errors.New("parse timestamp: now"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMessageToMetric(tt.input, false)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantMetric, got)
+			}
+		})
+	}
+}
+
+func TestStatsDParser_AggregateSetsAndDistributions(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	p.lastIntervalTime = time.Unix(611, 0)
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	for _, line := range []string{
+		"users:alice|s|#mykey:myvalue",
+		"users:bob|s|#mykey:myvalue",
+		"users:alice|s|#mykey:myvalue",
+		"latency:1|d|#mykey:myvalue",
+		"latency:1|d|#mykey:myvalue",
+		"latency:4|d|@0.5|#mykey:myvalue",
+	} {
+		assert.NoError(t, p.Aggregate(line, addr))
+	}
+
+	batches := p.GetMetrics()
+	require.Len(t, batches, 1)
+	require.Equal(t, 2, batches[0].Metrics.MetricCount())
+	sms := batches[0].Metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		m := sms.At(i).Metrics().At(0)
+		switch m.Name() {
+		case "users":
+			require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+			dp := m.Gauge().DataPoints().At(0)
+			assert.Equal(t, int64(2), dp.IntValue())
+			assert.Equal(t, time.Unix(711, 0).UnixNano(), int64(dp.Timestamp()))
+			assert.Equal(t, map[string]any{"mykey": "myvalue"}, dp.Attributes().AsRaw())
+		case "latency":
+			require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+			dp := m.ExponentialHistogram().DataPoints().At(0)
+			assert.Equal(t, uint64(4), dp.Count())
+			assert.Equal(t, float64(10), dp.Sum())
+			assert.Equal(t, float64(1), dp.Min())
+			assert.Equal(t, float64(4), dp.Max())
+		default:
+			t.Errorf("unexpected metric %q", m.Name())
+		}
+	}
+}
+
+func TestStatsDParser_AggregateByContainerID(t *testing.T) {
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	assert.NoError(t, p.Aggregate("requests:1|c|c:container-a", addr))
+	assert.NoError(t, p.Aggregate("requests:2|c|c:container-a", addr))
+	assert.NoError(t, p.Aggregate("requests:5|c|c:container-b", addr))
+	assert.NoError(t, p.Aggregate("requests:7|c", addr))
+
+	values := map[string]int64{}
+	for _, batch := range p.GetMetrics() {
+		assert.Equal(t, addr, batch.Info.Addr)
+		rm := batch.Metrics.ResourceMetrics().At(0)
+		containerID := ""
+		if v, ok := rm.Resource().Attributes().Get("container.id"); ok {
+			containerID = v.Str()
+		}
+		values[containerID] = rm.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue()
+	}
+	assert.Equal(t, map[string]int64{"container-a": 3, "container-b": 5, "": 7}, values)
+}
+
+func TestStatsDParser_GaugeTimestamp(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, nil))
+	addr, _ := net.ResolveUDPAddr("udp", "1.2.3.4:5678")
+	assert.NoError(t, p.Aggregate("temperature:21.5|g|T650", addr))
+
+	dp := p.GetMetrics()[0].Metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0)
+	assert.Equal(t, time.Unix(650, 0).UnixNano(), int64(dp.Timestamp()))
+}
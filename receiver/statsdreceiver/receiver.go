@@ -16,24 +16,44 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
 )
 
-var _ receiver.Metrics = (*statsdReceiver)(nil)
+const (
+	scopeName = "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver"
+	metricSep = "_"
+)
+
+var (
+	_ receiver.Metrics = (*statsdReceiver)(nil)
+	_ receiver.Logs    = (*statsdReceiver)(nil)
+)
 
-// statsdReceiver implements the receiver.Metrics for StatsD protocol.
+// statsdReceiver implements the receiver.Metrics for StatsD protocol, and the
+// receiver.Logs for the events and service checks of the DogStatsD protocol.
 type statsdReceiver struct {
 	settings receiver.CreateSettings
 	config   *Config
 
-	server       transport.Server
-	reporter     transport.Reporter
-	parser       protocol.Parser
-	nextConsumer consumer.Metrics
-	cancel       context.CancelFunc
+	server   transport.Server
+	reporter transport.Reporter
+	parser   protocol.Parser
+	// nextMetrics and nextLogs are set depending on the pipelines the receiver is part of.
+	nextMetrics consumer.Metrics
+	nextLogs    consumer.Logs
+	cancel      context.CancelFunc
+
+	linesReceived  metric.Int64Counter
+	linesParsed    metric.Int64Counter
+	linesMalformed metric.Int64Counter
+	// transportAttr identifies the transport the lines were received with.
+	transportAttr metric.AddOption
 }
 
 // New creates the StatsD receiver with the given parameters.
@@ -46,38 +66,86 @@ func New(
 		return nil, component.ErrNilNextConsumer
 	}
 
+	r, err := newReceiver(set, config)
+	if err != nil {
+		return nil, err
+	}
+	r.nextMetrics = nextConsumer
+	return r, nil
+}
+
+func newReceiver(set receiver.CreateSettings, config Config) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
 
-	rep, err := newReporter(set)
+	rep, err := newReporter(set, transportName(config))
 	if err != nil {
 		return nil, err
 	}
 
 	r := &statsdReceiver{
-		settings:     set,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		reporter:     rep,
+		settings: set,
+		config:   &config,
+		reporter: rep,
 		parser: &protocol.StatsDParser{
 			BuildInfo: set.BuildInfo,
 		},
+		transportAttr: metric.WithAttributes(attribute.String("transport", transportName(config))),
+	}
+
+	if err = r.createTelemetry(); err != nil {
+		return nil, err
 	}
 	return r, nil
 }
 
+func (r *statsdReceiver) createTelemetry() error {
+	meter := r.settings.MeterProvider.Meter(scopeName)
+
+	var err error
+	if r.linesReceived, err = meter.Int64Counter(
+		metadata.Type+metricSep+"receiver"+metricSep+"lines_received",
+		metric.WithDescription("Number of StatsD lines received"),
+	); err != nil {
+		return err
+	}
+	if r.linesParsed, err = meter.Int64Counter(
+		metadata.Type+metricSep+"receiver"+metricSep+"lines_parsed",
+		metric.WithDescription("Number of StatsD lines successfully parsed"),
+	); err != nil {
+		return err
+	}
+	r.linesMalformed, err = meter.Int64Counter(
+		metadata.Type+metricSep+"receiver"+metricSep+"lines_malformed",
+		metric.WithDescription("Number of StatsD lines that could not be parsed"),
+	)
+	return err
+}
+
+func transportName(config Config) string {
+	if config.NetAddr.Transport == "" {
+		return defaultTransport
+	}
+	return strings.ToLower(config.NetAddr.Transport)
+}
+
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
-	switch strings.ToLower(config.NetAddr.Transport) {
-	case "", "udp":
+	switch transportName(config) {
+	case "udp":
 		return transport.NewUDPServer(config.NetAddr.Endpoint)
+	case "tcp":
+		return transport.NewTCPServer(config.NetAddr.Endpoint, config.MaxConnections)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint)
+	case "unix":
+		return transport.NewUnixServer(config.NetAddr.Endpoint, config.MaxConnections)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q", config.NetAddr.Transport)
 }
 
-// Start starts a UDP server that can process StatsD messages.
+// Start starts a server that can process StatsD messages over the configured transport.
 func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	ctx, r.cancel = context.WithCancel(ctx)
 	server, err := buildTransportServer(*r.config)
@@ -96,7 +164,7 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 		return err
 	}
 	go func() {
-		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.parser, r.nextMetrics, r.reporter, transferChan); err != nil {
 			if !errors.Is(err, net.ErrClosed) {
 				host.ReportFatalError(err)
 			}
@@ -106,17 +174,34 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 		for {
 			select {
 			case <-ticker.C:
+				// Both are always collected, so that the parser state is reset.
 				batchMetrics := r.parser.GetMetrics()
-				for _, batch := range batchMetrics {
-					batchCtx := client.NewContext(ctx, batch.Info)
+				batchLogs := r.parser.GetLogs()
+				if r.nextMetrics != nil {
+					for _, batch := range batchMetrics {
+						batchCtx := client.NewContext(ctx, batch.Info)
+
+						if err := r.Flush(batchCtx, batch.Metrics, r.nextMetrics); err != nil {
+							r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+						}
+					}
+				}
+				if r.nextLogs != nil {
+					for _, batch := range batchLogs {
+						batchCtx := client.NewContext(ctx, batch.Info)
 
-					if err := r.Flush(batchCtx, batch.Metrics, r.nextConsumer); err != nil {
-						r.reporter.OnDebugf("Error flushing metrics", zap.Error(err))
+						if err := r.nextLogs.ConsumeLogs(batchCtx, batch.Logs); err != nil {
+							r.reporter.OnDebugf("Error flushing logs", zap.Error(err))
+						}
 					}
 				}
-			case metric := <-transferChan:
-				if err := r.parser.Aggregate(metric.Raw, metric.Addr); err != nil {
+			case line := <-transferChan:
+				r.linesReceived.Add(ctx, 1, r.transportAttr)
+				if err := r.parser.Aggregate(line.Raw, line.Addr); err != nil {
+					r.linesMalformed.Add(ctx, 1, r.transportAttr)
 					r.reporter.OnDebugf("Error aggregating metric", zap.Error(err))
+				} else {
+					r.linesParsed.Add(ctx, 1, r.transportAttr)
 				}
 			case <-ctx.Done():
 				ticker.Stop()
@@ -18,6 +18,7 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
@@ -141,6 +142,24 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 				return c
 			},
 		},
+		{
+			name: "tcp with 4s interval",
+			configFn: func() *Config {
+				return &Config{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  defaultBindEndpoint,
+						Transport: "tcp",
+					},
+					MaxConnections:      10,
+					AggregationInterval: 4 * time.Second,
+				}
+			},
+			clientFn: func(t *testing.T) *client.StatsD {
+				c, err := client.NewStatsD(client.TCP, host, port)
+				require.NoError(t, err)
+				return c
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -197,3 +216,47 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsdReceiver_MetricsAndLogs(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = addr
+	cfg.AggregationInterval = time.Second
+
+	factory := NewFactory()
+	metricsSink := new(consumertest.MetricsSink)
+	metricsRcv, err := factory.CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, metricsSink)
+	require.NoError(t, err)
+	logsSink := new(consumertest.LogsSink)
+	logsRcv, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, logsSink)
+	require.NoError(t, err)
+	assert.Same(t, metricsRcv, logsRcv)
+
+	require.NoError(t, metricsRcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, logsRcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, metricsRcv.Shutdown(context.Background()))
+		assert.NoError(t, logsRcv.Shutdown(context.Background()))
+	}()
+
+	conn, err := net.Dial("udp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("users:alice|s|c:abc123\n_e{6,8}:Deploy|finished|t:success\n_sc|db.can_connect|2|m:refused\n"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return metricsSink.DataPointCount() == 1 && logsSink.LogRecordCount() == 2
+	}, 10*time.Second, 100*time.Millisecond)
+
+	rm := metricsSink.AllMetrics()[0].ResourceMetrics().At(0)
+	containerID, ok := rm.Resource().Attributes().Get("container.id")
+	require.True(t, ok)
+	assert.Equal(t, "abc123", containerID.Str())
+	assert.Equal(t, int64(1), rm.ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).IntValue())
+
+	lrs := logsSink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, "finished", lrs.At(0).Body().Str())
+	assert.Equal(t, "refused", lrs.At(1).Body().Str())
+	assert.Equal(t, plog.SeverityNumberError, lrs.At(1).SeverityNumber())
+}
@@ -24,10 +24,10 @@ type reporter struct {
 
 var _ transport.Reporter = (*reporter)(nil)
 
-func newReporter(set receiver.CreateSettings) (transport.Reporter, error) {
+func newReporter(set receiver.CreateSettings, transportType string) (transport.Reporter, error) {
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             set.ID,
-		Transport:              "tcp",
+		Transport:              transportType,
 		ReceiverCreateSettings: set,
 	})
 	if err != nil {
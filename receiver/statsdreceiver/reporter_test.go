@@ -23,7 +23,7 @@ func TestReporterObservability(t *testing.T) {
 		require.NoError(t, tt.Shutdown(context.Background()))
 	}()
 
-	reporter, err := newReporter(tt.ToReceiverCreateSettings())
+	reporter, err := newReporter(tt.ToReceiverCreateSettings(), "tcp")
 	require.NoError(t, err)
 
 	ctx := reporter.OnDataReceived(context.Background())
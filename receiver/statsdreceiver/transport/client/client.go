@@ -24,6 +24,10 @@ const (
 	TCP Transport = iota
 	// UDP Transport
 	UDP
+	// Unix Transport, a stream-oriented Unix domain socket
+	Unix
+	// Unixgram Transport, a datagram-oriented Unix domain socket
+	Unixgram
 )
 
 // NewStatsD creates a new StatsD instance to support the need for testing
@@ -33,7 +37,22 @@ func NewStatsD(transport Transport, host string, port int) (*StatsD, error) {
 		Host: host,
 		Port: port,
 	}
-	err := statsd.connect(transport)
+	err := statsd.connect(transport, fmt.Sprintf("%s:%d", host, port))
+	if err != nil {
+		return nil, err
+	}
+
+	return statsd, nil
+}
+
+// NewUnixStatsD creates a new StatsD instance connected to the Unix domain
+// socket at the given path to support the need for testing the statsdreceiver
+// package and is not intended/tested to be used in production.
+func NewUnixStatsD(transport Transport, path string) (*StatsD, error) {
+	statsd := &StatsD{
+		Host: path,
+	}
+	err := statsd.connect(transport, path)
 	if err != nil {
 		return nil, err
 	}
@@ -42,18 +61,28 @@ func NewStatsD(transport Transport, host string, port int) (*StatsD, error) {
 }
 
 // connect populates the StatsD.Conn
-func (s *StatsD) connect(transport Transport) error {
+func (s *StatsD) connect(transport Transport, address string) error {
 	if cl, ok := s.Conn.(io.Closer); ok {
 		cl.Close()
 	}
 
-	address := fmt.Sprintf("%s:%d", s.Host, s.Port)
-
 	var err error
 	switch transport {
 	case TCP:
-		// TODO: implement TCP support
-		return fmt.Errorf("TCP unsupported")
+		s.Conn, err = net.Dial("tcp", address)
+		if err != nil {
+			return err
+		}
+	case Unix:
+		s.Conn, err = net.Dial("unix", address)
+		if err != nil {
+			return err
+		}
+	case Unixgram:
+		s.Conn, err = net.Dial("unixgram", address)
+		if err != nil {
+			return err
+		}
 	case UDP:
 		var udpAddr *net.UDPAddr
 		udpAddr, err = net.ResolveUDPAddr("udp", address)
@@ -83,7 +112,7 @@ func (s *StatsD) Disconnect() error {
 
 // SendMetric sends the input metric to the StatsD connection.
 func (s *StatsD) SendMetric(metric Metric) error {
-	_, err := fmt.Fprint(s.Conn, metric.String())
+	_, err := fmt.Fprintln(s.Conn, metric.String())
 	if err != nil {
 		return err
 	}
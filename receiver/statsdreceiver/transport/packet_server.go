@@ -0,0 +1,144 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+type packetServer struct {
+	packetConn net.PacketConn
+	// socketPath is only set for Unix domain sockets, which are removed on Close.
+	socketPath string
+	reporter   Reporter
+}
+
+var _ (Server) = (*packetServer)(nil)
+
+// NewUDPServer creates a transport.Server using UDP as its transport.
+func NewUDPServer(addr string) (Server, error) {
+	packetConn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	u := packetServer{
+		packetConn: packetConn,
+	}
+	return &u, nil
+}
+
+// NewUnixgramServer creates a transport.Server using a datagram-oriented
+// Unix domain socket created at the given path as its transport.
+func NewUnixgramServer(path string) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	packetConn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := packetServer{
+		packetConn: packetConn,
+		socketPath: path,
+	}
+	return &u, nil
+}
+
+func (u *packetServer) ListenAndServe(
+	parser protocol.Parser,
+	_ consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- Metric,
+) error {
+	if parser == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	u.reporter = reporter
+
+	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
+	for {
+		n, addr, err := u.packetConn.ReadFrom(buf)
+		if addr == nil {
+			// Clients of Unix domain sockets are usually not bound to an address.
+			addr = u.packetConn.LocalAddr()
+		}
+		if n > 0 {
+			bufCopy := make([]byte, n)
+			copy(bufCopy, buf)
+			u.handlePacket(bufCopy, addr, transferChan)
+		}
+		if err != nil {
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				strings.ToUpper(u.packetConn.LocalAddr().Network()),
+				u.packetConn.LocalAddr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+	}
+}
+
+func (u *packetServer) Close() error {
+	err := u.packetConn.Close()
+	if u.socketPath != "" {
+		if rmErr := os.Remove(u.socketPath); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
+}
+
+func (u *packetServer) handlePacket(
+	data []byte,
+	addr net.Addr,
+	transferChan chan<- Metric,
+) {
+	buf := bytes.NewBuffer(data)
+	for {
+		bytes, err := buf.ReadBytes((byte)('\n'))
+		if errors.Is(err, io.EOF) {
+			if len(bytes) == 0 {
+				// Completed without errors.
+				break
+			}
+		}
+		line := strings.TrimSpace(string(bytes))
+		if line != "" {
+			transferChan <- Metric{line, addr}
+		}
+	}
+}
+
+// removeStaleSocket removes the Unix domain socket left at path by a previous run,
+// as it would otherwise prevent listening on it.
+func removeStaleSocket(path string) error {
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return &os.PathError{Op: "listen", Path: path, Err: errors.New("file exists and is not a socket")}
+	}
+	return os.Remove(path)
+}
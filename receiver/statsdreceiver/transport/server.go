@@ -20,7 +20,9 @@ var errNilListenAndServeParameters = errors.New("no parameter of ListenAndServe
 type Server interface {
 	// ListenAndServe is a blocking call that starts to listen for client messages
 	// on the specific transport, and prepares the message to be processed by
-	// the Parser and passed to the next consumer.
+	// the Parser. The received lines are aggregated and passed to the next
+	// consumer by the receiver, so mc isn't used by the servers of this package,
+	// and may be nil when the receiver is only part of logs pipelines.
 	ListenAndServe(
 		p protocol.Parser,
 		mc consumer.Metrics,
@@ -4,7 +4,9 @@
 package transport
 
 import (
+	"io"
 	"net"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -23,46 +25,82 @@ import (
 func Test_Server_ListenAndServe(t *testing.T) {
 	tests := []struct {
 		name          string
-		buildServerFn func(addr string) (Server, error)
-		buildClientFn func(host string, port int) (*client.StatsD, error)
+		buildServerFn func(t *testing.T) (Server, string)
+		buildClientFn func(addr string) (*client.StatsD, error)
 	}{
 		{
-			name:          "udp",
-			buildServerFn: NewUDPServer,
-			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+			name: "udp",
+			buildServerFn: func(t *testing.T) (Server, string) {
+				addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+
+				// Endpoint should be free.
+				ln0, err := net.ListenPacket("udp", addr)
+				require.NoError(t, err)
+				require.NotNil(t, ln0)
+
+				// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
+				ln1, err := net.ListenPacket("udp", addr)
+				require.Error(t, err)
+				require.Nil(t, ln1)
+
+				// Unbind the local address so the mock UDP service can use it
+				ln0.Close()
+
+				srv, err := NewUDPServer(addr)
+				require.NoError(t, err)
+				return srv, addr
+			},
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
 				return client.NewStatsD(client.UDP, host, port)
 			},
 		},
+		{
+			name: "tcp",
+			buildServerFn: func(t *testing.T) (Server, string) {
+				addr := testutil.GetAvailableLocalAddress(t)
+				srv, err := NewTCPServer(addr, 0)
+				require.NoError(t, err)
+				return srv, addr
+			},
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
+				return client.NewStatsD(client.TCP, host, port)
+			},
+		},
+		{
+			name: "unix",
+			buildServerFn: func(t *testing.T) (Server, string) {
+				path := filepath.Join(t.TempDir(), "statsd.sock")
+				srv, err := NewUnixServer(path, 0)
+				require.NoError(t, err)
+				return srv, path
+			},
+			buildClientFn: func(path string) (*client.StatsD, error) {
+				return client.NewUnixStatsD(client.Unix, path)
+			},
+		},
+		{
+			name: "unixgram",
+			buildServerFn: func(t *testing.T) (Server, string) {
+				path := filepath.Join(t.TempDir(), "statsd.sock")
+				srv, err := NewUnixgramServer(path)
+				require.NoError(t, err)
+				return srv, path
+			},
+			buildClientFn: func(path string) (*client.StatsD, error) {
+				return client.NewUnixStatsD(client.Unixgram, path)
+			},
+		},
 	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
-
-			// Endpoint should be free.
-			ln0, err := net.ListenPacket("udp", addr)
-			require.NoError(t, err)
-			require.NotNil(t, ln0)
-
-			// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
-			ln1, err := net.ListenPacket("udp", addr)
-			require.Error(t, err)
-			require.Nil(t, ln1)
-
-			// Unbind the local address so the mock UDP service can use it
-			ln0.Close()
-
-			srv, err := tt.buildServerFn(addr)
-			require.NoError(t, err)
+			srv, addr := tt.buildServerFn(t)
 			require.NotNil(t, srv)
 
-			host, portStr, err := net.SplitHostPort(addr)
-			require.NoError(t, err)
-			port, err := strconv.Atoi(portStr)
-			require.NoError(t, err)
-
-			mc := new(consumertest.MetricsSink)
 			p := &protocol.StatsDParser{}
-			require.NoError(t, err)
+			mc := new(consumertest.MetricsSink)
 			mr := NewMockReporter(1)
 			transferChan := make(chan Metric, 10)
 
@@ -75,7 +113,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 
 			runtime.Gosched()
 
-			gc, err := tt.buildClientFn(host, port)
+			gc, err := tt.buildClientFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, gc)
 			err = gc.SendMetric(client.Metric{
@@ -85,6 +123,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 			})
 			assert.NoError(t, err)
 			runtime.Gosched()
+
 			err = gc.Disconnect()
 			assert.NoError(t, err)
 
@@ -102,3 +141,61 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_StreamServer_MaxConnections(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 1)
+	require.NoError(t, err)
+
+	transferChan := make(chan Metric, 10)
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, new(consumertest.MetricsSink), NewMockReporter(0), transferChan))
+	}()
+
+	conn1, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = conn1.Write([]byte("test.metric:1|c\n"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(transferChan) == 1
+	}, 10*time.Second, 10*time.Millisecond)
+
+	// The second connection is closed by the server right away.
+	conn2, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, conn2.SetReadDeadline(time.Now().Add(10*time.Second)))
+	_, err = conn2.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+	require.NoError(t, conn2.Close())
+
+	// Lines split over several writes are still received on the first connection.
+	_, err = conn1.Write([]byte("test.metric:2|c\ntest.met"))
+	require.NoError(t, err)
+	_, err = conn1.Write([]byte("ric:3|c\n"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return len(transferChan) == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	require.NoError(t, conn1.Close())
+
+	require.NoError(t, srv.Close())
+	wgListenAndServe.Wait()
+
+	close(transferChan)
+	var lines []string
+	for m := range transferChan {
+		lines = append(lines, m.Raw)
+	}
+	assert.Equal(t, []string{"test.metric:1|c", "test.metric:2|c", "test.metric:3|c"}, lines)
+}
+
+func splitHostPort(t *testing.T, addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
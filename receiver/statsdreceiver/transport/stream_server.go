@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// maxLineSize is the maximum size of a line received over a stream connection,
+// matching the maximum size of a packet received over UDP.
+const maxLineSize = 65527
+
+type streamServer struct {
+	listener net.Listener
+	// maxConnections is the maximum number of concurrent connections, 0 meaning unlimited.
+	maxConnections int
+	reporter       Reporter
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+var _ (Server) = (*streamServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport. Messages
+// are delimited by newlines. New connections are closed right away once
+// maxConnections connections are open, unless maxConnections is 0.
+func NewTCPServer(addr string, maxConnections int) (Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStreamServer(listener, maxConnections), nil
+}
+
+// NewUnixServer creates a transport.Server using a stream-oriented Unix domain
+// socket created at the given path as its transport. Messages are delimited by
+// newlines. New connections are closed right away once maxConnections
+// connections are open, unless maxConnections is 0.
+func NewUnixServer(path string, maxConnections int) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStreamServer(listener, maxConnections), nil
+}
+
+func newStreamServer(listener net.Listener, maxConnections int) *streamServer {
+	return &streamServer{
+		listener:       listener,
+		maxConnections: maxConnections,
+		conns:          make(map[net.Conn]struct{}),
+	}
+}
+
+func (s *streamServer) ListenAndServe(
+	parser protocol.Parser,
+	_ consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- Metric,
+) error {
+	if parser == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	s.reporter = reporter
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.reporter.OnDebugf("%s Transport (%s) - Accept error: %v",
+				strings.ToUpper(s.listener.Addr().Network()),
+				s.listener.Addr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+
+		if !s.track(conn) {
+			continue
+		}
+		go s.handleConn(conn, transferChan)
+	}
+}
+
+// track registers the connection, or closes it if the server is closed
+// or the maximum number of connections is reached.
+func (s *streamServer) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		_ = conn.Close()
+		return false
+	}
+	if s.maxConnections > 0 && len(s.conns) >= s.maxConnections {
+		s.reporter.OnDebugf("%s Transport (%s) - Connection from %v refused: max connections (%d) reached",
+			strings.ToUpper(s.listener.Addr().Network()),
+			s.listener.Addr(),
+			conn.RemoteAddr(),
+			s.maxConnections)
+		_ = conn.Close()
+		return false
+	}
+
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *streamServer) handleConn(conn net.Conn, transferChan chan<- Metric) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+		s.wg.Done()
+	}()
+
+	addr := conn.RemoteAddr()
+	if addr == nil {
+		// Clients of Unix domain sockets are usually not bound to an address.
+		addr = s.listener.Addr()
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			transferChan <- Metric{line, addr}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		s.reporter.OnDebugf("%s Transport (%s) - Read error from %v: %v",
+			strings.ToUpper(s.listener.Addr().Network()),
+			s.listener.Addr(),
+			addr,
+			err)
+	}
+}
+
+// Close stops accepting connections, closes the open ones and waits for
+// the lines already received to be handed over.
+func (s *streamServer) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	err := s.listener.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}
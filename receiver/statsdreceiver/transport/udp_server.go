@@ -1,96 +0,0 @@
-// Copyright The OpenTelemetry Authors
-// SPDX-License-Identifier: Apache-2.0
-
-package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
-
-import (
-	"bytes"
-	"errors"
-	"io"
-	"net"
-	"strings"
-
-	"go.opentelemetry.io/collector/consumer"
-
-	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
-)
-
-type udpServer struct {
-	packetConn net.PacketConn
-	reporter   Reporter
-}
-
-var _ (Server) = (*udpServer)(nil)
-
-// NewUDPServer creates a transport.Server using UDP as its transport.
-func NewUDPServer(addr string) (Server, error) {
-	packetConn, err := net.ListenPacket("udp", addr)
-	if err != nil {
-		return nil, err
-	}
-
-	u := udpServer{
-		packetConn: packetConn,
-	}
-	return &u, nil
-}
-
-func (u *udpServer) ListenAndServe(
-	parser protocol.Parser,
-	nextConsumer consumer.Metrics,
-	reporter Reporter,
-	transferChan chan<- Metric,
-) error {
-	if parser == nil || nextConsumer == nil || reporter == nil {
-		return errNilListenAndServeParameters
-	}
-
-	u.reporter = reporter
-
-	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
-	for {
-		n, addr, err := u.packetConn.ReadFrom(buf)
-		if n > 0 {
-			bufCopy := make([]byte, n)
-			copy(bufCopy, buf)
-			u.handlePacket(bufCopy, addr, transferChan)
-		}
-		if err != nil {
-			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
-				u.packetConn.LocalAddr(),
-				err)
-			var netErr net.Error
-			if errors.As(err, &netErr) {
-				if netErr.Timeout() {
-					continue
-				}
-			}
-			return err
-		}
-	}
-}
-
-func (u *udpServer) Close() error {
-	return u.packetConn.Close()
-}
-
-func (u *udpServer) handlePacket(
-	data []byte,
-	addr net.Addr,
-	transferChan chan<- Metric,
-) {
-	buf := bytes.NewBuffer(data)
-	for {
-		bytes, err := buf.ReadBytes((byte)('\n'))
-		if errors.Is(err, io.EOF) {
-			if len(bytes) == 0 {
-				// Completed without errors.
-				break
-			}
-		}
-		line := strings.TrimSpace(string(bytes))
-		if line != "" {
-			transferChan <- Metric{line, addr}
-		}
-	}
-}
@@ -5,16 +5,40 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.uber.org/multierr"
 )
 
+// The ways to split a request body into log records.
+const (
+	splitLine      = "line"
+	splitJSONArray = "json_array"
+	splitNone      = "none"
+)
+
+// The supported signature algorithms and encodings.
+const (
+	algorithmSHA1   = "sha1"
+	algorithmSHA256 = "sha256"
+	algorithmSHA512 = "sha512"
+
+	encodingHex    = "hex"
+	encodingBase64 = "base64"
+)
+
 var (
 	errMissingEndpointFromConfig   = errors.New("missing receiver server endpoint from config")
 	errReadTimeoutExceedsMaxValue  = errors.New("The duration specified for read_timeout exceeds the maximum allowed value of 10s")
 	errWriteTimeoutExceedsMaxValue = errors.New("The duration specified for write_timeout exceeds the maximum allowed value of 10s")
+	errMissingPath                 = errors.New("missing path in paths")
+	errMissingSignatureHeader      = errors.New("missing header in signature")
+	errMissingSignatureSecret      = errors.New("missing secret in signature")
+	errNegativeMaxRequestBodySize  = errors.New("max_request_body_size must not be negative")
 )
 
 // Config defines configuration for the Generic Webhook receiver.
@@ -24,6 +48,45 @@ type Config struct {
 	WriteTimeout                  string                   `mapstructure:"write_timeout"` // wait time for writing request response in ms. Default is twenty seconds.
 	Path                          string                   `mapstructure:"path"`          // path for data collection. Default is <host>:<port>/services/collector
 	HealthPath                    string                   `mapstructure:"health_path"`   // path for health check api. Default is /services/collector/health
+	RouteSettings                 `mapstructure:",squash"` // settings of the requests received on path
+	Paths                         []PathConfig             `mapstructure:"paths"` // additional paths, each with its own settings
+}
+
+// PathConfig defines an additional path for data collection.
+type PathConfig struct {
+	Path          string `mapstructure:"path"`
+	RouteSettings `mapstructure:",squash"`
+}
+
+// RouteSettings defines how the requests received on a path are authenticated
+// and converted to log records.
+type RouteSettings struct {
+	// Attributes are added to the log records.
+	Attributes map[string]string `mapstructure:"attributes"`
+	// HeaderAttributes maps the names of request headers to the attributes the
+	// header values are added to on the log records.
+	HeaderAttributes map[string]string `mapstructure:"header_attributes"`
+	// Split is how the request body is split into log records: line, for one
+	// log record per line, json_array, for one log record per element of a
+	// JSON array, or none, for one log record per request. Default is line.
+	Split string `mapstructure:"split"`
+	// Signature enables the verification of the HMAC signature of the requests.
+	Signature *SignatureConfig `mapstructure:"signature"`
+}
+
+// SignatureConfig defines the verification of the HMAC signature of the
+// request bodies, as sent by GitHub or PagerDuty.
+type SignatureConfig struct {
+	// Header is the name of the header containing the signature.
+	Header string `mapstructure:"header"`
+	// Algorithm is the hash algorithm of the HMAC: sha1, sha256 or sha512. Default is sha256.
+	Algorithm string `mapstructure:"algorithm"`
+	// Secret is the key of the HMAC.
+	Secret configopaque.String `mapstructure:"secret"`
+	// Prefix is the prefix of the signature in the header, such as "sha256=".
+	Prefix string `mapstructure:"prefix"`
+	// Encoding is the encoding of the signature: hex or base64. Default is hex.
+	Encoding string `mapstructure:"encoding"`
 }
 
 func (cfg *Config) Validate() error {
@@ -59,5 +122,63 @@ func (cfg *Config) Validate() error {
 		}
 	}
 
+	if cfg.MaxRequestBodySize < 0 {
+		errs = multierr.Append(errs, errNegativeMaxRequestBodySize)
+	}
+
+	paths := map[string]bool{cfg.Path: true}
+	errs = multierr.Append(errs, cfg.RouteSettings.validate())
+	for _, pc := range cfg.Paths {
+		if pc.Path == "" {
+			errs = multierr.Append(errs, errMissingPath)
+			continue
+		}
+		if paths[pc.Path] || pc.Path == cfg.HealthPath {
+			errs = multierr.Append(errs, fmt.Errorf("duplicate path %q", pc.Path))
+		}
+		paths[pc.Path] = true
+		errs = multierr.Append(errs, pc.RouteSettings.validate())
+	}
+
+	return errs
+}
+
+// maxLineSize returns the maximum size of the lines the request bodies are split into.
+func (cfg *Config) maxLineSize() int {
+	if cfg.MaxRequestBodySize <= 0 || cfg.MaxRequestBodySize > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(cfg.MaxRequestBodySize)
+}
+
+func (rs *RouteSettings) validate() error {
+	var errs error
+
+	switch rs.Split {
+	case "", splitLine, splitJSONArray, splitNone:
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("invalid split %q, must be one of %s, %s or %s", rs.Split, splitLine, splitJSONArray, splitNone))
+	}
+
+	if rs.Signature == nil {
+		return errs
+	}
+	if rs.Signature.Header == "" {
+		errs = multierr.Append(errs, errMissingSignatureHeader)
+	}
+	if rs.Signature.Secret == "" {
+		errs = multierr.Append(errs, errMissingSignatureSecret)
+	}
+	switch rs.Signature.Algorithm {
+	case "", algorithmSHA1, algorithmSHA256, algorithmSHA512:
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("invalid signature algorithm %q, must be one of %s, %s or %s", rs.Signature.Algorithm, algorithmSHA1, algorithmSHA256, algorithmSHA512))
+	}
+	switch rs.Signature.Encoding {
+	case "", encodingHex, encodingBase64:
+	default:
+		errs = multierr.Append(errs, fmt.Errorf("invalid signature encoding %q, must be one of %s or %s", rs.Signature.Encoding, encodingHex, encodingBase64))
+	}
+
 	return errs
 }
@@ -93,7 +93,8 @@ func TestLoadConfig(t *testing.T) {
 
 	expect := &Config{
 		HTTPServerSettings: confighttp.HTTPServerSettings{
-			Endpoint: "localhost:8080",
+			Endpoint:           "localhost:8080",
+			MaxRequestBodySize: 1048576,
 		},
 		ReadTimeout:  "500ms",
 		WriteTimeout: "500ms",
@@ -109,3 +110,115 @@ func TestLoadConfig(t *testing.T) {
 
 	require.Equal(t, expect, conf)
 }
+
+func TestLoadConfigPaths(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+	cmNoStr, err := cm.Sub(component.NewIDWithName(metadata.Type, "paths").String())
+	require.NoError(t, err)
+
+	expect := &Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint:           "localhost:8080",
+			MaxRequestBodySize: defaultMaxRequestBodySize,
+		},
+		ReadTimeout:  defaultReadTimeout,
+		WriteTimeout: defaultWriteTimeout,
+		Path:         "/events",
+		HealthPath:   defaultHealthPath,
+		RouteSettings: RouteSettings{
+			Attributes: map[string]string{"webhook.source": "generic"},
+		},
+		Paths: []PathConfig{
+			{
+				Path: "/github",
+				RouteSettings: RouteSettings{
+					Attributes:       map[string]string{"webhook.source": "github"},
+					HeaderAttributes: map[string]string{"X-GitHub-Event": "github.event"},
+					Split:            splitJSONArray,
+					Signature: &SignatureConfig{
+						Header: "X-Hub-Signature-256",
+						Prefix: "sha256=",
+						Secret: "my-secret",
+					},
+				},
+			},
+			{
+				Path: "/alertmanager",
+				RouteSettings: RouteSettings{
+					Attributes: map[string]string{"webhook.source": "alertmanager"},
+					Split:      splitNone,
+				},
+			},
+		},
+	}
+
+	conf := NewFactory().CreateDefaultConfig()
+	require.NoError(t, component.UnmarshalConfig(cmNoStr, conf))
+	require.NoError(t, component.ValidateConfig(conf))
+	require.Equal(t, expect, conf)
+}
+
+func TestValidateRouteSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc   string
+		modify func(cfg *Config)
+		expect string
+	}{
+		{
+			desc:   "Invalid split",
+			modify: func(cfg *Config) { cfg.Split = "xml" },
+			expect: `invalid split "xml", must be one of line, json_array or none`,
+		},
+		{
+			desc:   "Negative max request body size",
+			modify: func(cfg *Config) { cfg.MaxRequestBodySize = -1 },
+			expect: errNegativeMaxRequestBodySize.Error(),
+		},
+		{
+			desc:   "Missing path",
+			modify: func(cfg *Config) { cfg.Paths = []PathConfig{{}} },
+			expect: errMissingPath.Error(),
+		},
+		{
+			desc:   "Duplicate path",
+			modify: func(cfg *Config) { cfg.Paths = []PathConfig{{Path: "/other"}, {Path: "/other"}} },
+			expect: `duplicate path "/other"`,
+		},
+		{
+			desc:   "Path of health check",
+			modify: func(cfg *Config) { cfg.Paths = []PathConfig{{Path: defaultHealthPath}} },
+			expect: `duplicate path "/health_check"`,
+		},
+		{
+			desc:   "Missing signature header and secret",
+			modify: func(cfg *Config) { cfg.Signature = &SignatureConfig{} },
+			expect: errMissingSignatureHeader.Error() + "; " + errMissingSignatureSecret.Error(),
+		},
+		{
+			desc: "Invalid signature algorithm and encoding",
+			modify: func(cfg *Config) {
+				cfg.Paths = []PathConfig{{
+					Path: "/other",
+					RouteSettings: RouteSettings{
+						Signature: &SignatureConfig{Header: "X-Signature", Secret: "secret", Algorithm: "md5", Encoding: "base32"},
+					},
+				}}
+			},
+			expect: `invalid signature algorithm "md5", must be one of sha1, sha256 or sha512; invalid signature encoding "base32", must be one of hex or base64`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:0"
+			test.modify(cfg)
+			require.EqualError(t, cfg.Validate(), test.expect)
+		})
+	}
+}
@@ -4,7 +4,7 @@
 package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
 
 import (
-	"bufio"
+	"bytes"
 	"compress/gzip"
 	"context"
 	"errors"
@@ -42,6 +42,23 @@ type eventReceiver struct {
 	shutdownWG  sync.WaitGroup
 	obsrecv     *obsreport.Receiver
 	gzipPool    *sync.Pool
+	// routes contains the route of path, followed by those of paths.
+	routes []*route
+}
+
+// route handles the requests received on a path.
+type route struct {
+	path     string
+	settings *RouteSettings
+	verifier *signatureVerifier
+}
+
+func newRoute(path string, settings *RouteSettings) *route {
+	rt := &route{path: path, settings: settings}
+	if settings.Signature != nil {
+		rt.verifier = newSignatureVerifier(settings.Signature)
+	}
+	return rt
 }
 
 func newLogsReceiver(params receiver.CreateSettings, cfg Config, consumer consumer.Logs) (receiver.Logs, error) {
@@ -77,6 +94,11 @@ func newLogsReceiver(params receiver.CreateSettings, cfg Config, consumer consum
 		gzipPool:    &sync.Pool{New: func() interface{} { return new(gzip.Reader) }},
 	}
 
+	er.routes = append(er.routes, newRoute(er.cfg.Path, &er.cfg.RouteSettings))
+	for i := range er.cfg.Paths {
+		er.routes = append(er.routes, newRoute(er.cfg.Paths[i].Path, &er.cfg.Paths[i].RouteSettings))
+	}
+
 	return er, nil
 }
 
@@ -97,6 +119,12 @@ func (er *eventReceiver) Start(_ context.Context, host component.Host) error {
 	router := httprouter.New()
 
 	router.POST(er.cfg.Path, er.handleReq)
+	for _, rt := range er.routes[1:] {
+		rt := rt
+		router.POST(rt.path, func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+			er.handleRouteReq(w, r, rt)
+		})
+	}
 	router.GET(er.cfg.HealthPath, er.handleHealthCheck)
 
 	// webhook server standup and configuration
@@ -143,8 +171,13 @@ func (er *eventReceiver) Shutdown(_ context.Context) error {
 	return err
 }
 
-// handleReq handles incoming request from webhook. On success returns a 200 response code to the webhook
+// handleReq handles incoming request from webhook on path. On success returns a 200 response code to the webhook
 func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	er.handleRouteReq(w, r, er.routes[0])
+}
+
+// handleRouteReq handles incoming request from webhook on the path of the route.
+func (er *eventReceiver) handleRouteReq(w http.ResponseWriter, r *http.Request, rt *route) {
 	ctx := r.Context()
 	ctx = er.obsrecv.StartLogsOp(ctx)
 
@@ -165,7 +198,27 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 		er.failBadReq(ctx, w, http.StatusBadRequest, errEmptyResponseBody)
 	}
 
+	// the body is limited before it is read, so that unauthenticated requests are never fully read into
+	// memory before their signature is verified.
+	if er.cfg.MaxRequestBodySize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, er.cfg.MaxRequestBodySize)
+	}
+
 	bodyReader := r.Body
+	// the signature is computed on the body as sent, before decompression.
+	if rt.verifier != nil {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			er.failBadReq(ctx, w, bodyErrorStatus(err), err)
+			return
+		}
+		if err = rt.verifier.verify(r.Header, body); err != nil {
+			er.failBadReq(ctx, w, http.StatusUnauthorized, err)
+			return
+		}
+		bodyReader = io.NopCloser(bytes.NewReader(body))
+	}
+
 	// gzip encoded case
 	if encoding == "gzip" || encoding == "x-gzip" {
 		reader := er.gzipPool.Get().(*gzip.Reader)
@@ -182,11 +235,16 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 	}
 
 	// finish reading the body into a log
-	sc := bufio.NewScanner(bodyReader)
-	ld, numLogs := reqToLog(sc, r.URL.Query(), er.cfg, er.settings)
-	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
-
+	bodies, err := splitBody(bodyReader, rt.settings.Split, er.cfg.maxLineSize())
 	_ = bodyReader.Close()
+	if err != nil {
+		er.failBadReq(ctx, w, bodyErrorStatus(err), err)
+		er.obsrecv.EndLogsOp(ctx, metadata.Type, 0, err)
+		return
+	}
+
+	ld, numLogs := reqToLog(bodies, r.URL.Query(), r.Header, rt.settings, er.settings)
+	consumerErr := er.logConsumer.ConsumeLogs(ctx, ld)
 
 	if consumerErr != nil {
 		er.failBadReq(ctx, w, http.StatusInternalServerError, consumerErr)
@@ -197,6 +255,15 @@ func (er *eventReceiver) handleReq(w http.ResponseWriter, r *http.Request, _ htt
 	}
 }
 
+// bodyErrorStatus returns the status code of the responses to the requests whose body failed to be read.
+func bodyErrorStatus(err error) int {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
 // Simple healthcheck endpoint.
 func (er *eventReceiver) handleHealthCheck(w http.ResponseWriter, _ *http.Request, _ httprouter.Params) {
 	w.Header().Add("Content-Type", "application/json")
@@ -4,10 +4,18 @@
 package webhookeventreceiver
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"context"
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- SHA-1 signatures are supported
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"hash"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -227,3 +235,232 @@ func TestHealthCheck(t *testing.T) {
 	response := w.Result()
 	require.Equal(t, http.StatusOK, response.StatusCode)
 }
+
+func TestMaxRequestBodySize(t *testing.T) {
+	longLine := strings.Repeat("a", 2*bufio.MaxScanTokenSize)
+	tests := []struct {
+		desc      string
+		body      string
+		signature *SignatureConfig
+		status    int
+	}{
+		{
+			desc:   "Lines longer than the default buffer of the scanner",
+			body:   longLine + "\nlog",
+			status: http.StatusOK,
+		},
+		{
+			desc:   "Body too large",
+			body:   longLine + "\n" + longLine,
+			status: http.StatusRequestEntityTooLarge,
+		},
+		{
+			desc:      "Signed body too large",
+			body:      longLine + longLine,
+			signature: &SignatureConfig{Header: "X-Signature", Secret: "secret"},
+			status:    http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:0"
+			cfg.MaxRequestBodySize = int64(3 * bufio.MaxScanTokenSize)
+			cfg.Signature = test.signature
+			require.NoError(t, cfg.Validate())
+
+			sink := new(consumertest.LogsSink)
+			receiver, err := newLogsReceiver(receivertest.NewNopCreateSettings(), *cfg, sink)
+			require.NoError(t, err, "Failed to create receiver")
+			r := receiver.(*eventReceiver)
+
+			req := httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(test.body))
+			w := httptest.NewRecorder()
+			r.handleReq(w, req, httprouter.ParamsFromContext(context.Background()))
+
+			require.Equal(t, test.status, w.Result().StatusCode)
+			if test.status == http.StatusOK {
+				require.Equal(t, 2, sink.LogRecordCount())
+			}
+		})
+	}
+}
+
+func TestSignatureVerification(t *testing.T) {
+	body := `{"action": "opened"}`
+	sign := func(secret string, newHash func() hash.Hash, encode func([]byte) string) string {
+		mac := hmac.New(newHash, []byte(secret))
+		mac.Write([]byte(body))
+		return encode(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		desc      string
+		signature *SignatureConfig
+		header    http.Header
+		status    int
+	}{
+		{
+			desc:      "Valid signature with prefix",
+			signature: &SignatureConfig{Header: "X-Hub-Signature-256", Secret: "secret", Prefix: "sha256="},
+			header:    http.Header{"X-Hub-Signature-256": []string{"sha256=" + sign("secret", sha256.New, hex.EncodeToString)}},
+			status:    http.StatusOK,
+		},
+		{
+			desc:      "Valid base64 sha1 signature",
+			signature: &SignatureConfig{Header: "X-Signature", Secret: "secret", Algorithm: algorithmSHA1, Encoding: encodingBase64},
+			header:    http.Header{"X-Signature": []string{sign("secret", sha1.New, base64.StdEncoding.EncodeToString)}},
+			status:    http.StatusOK,
+		},
+		{
+			desc:      "Valid sha512 signature",
+			signature: &SignatureConfig{Header: "X-Signature", Secret: "secret", Algorithm: algorithmSHA512},
+			header:    http.Header{"X-Signature": []string{sign("secret", sha512.New, hex.EncodeToString)}},
+			status:    http.StatusOK,
+		},
+		{
+			desc:      "Valid signature among several",
+			signature: &SignatureConfig{Header: "X-PagerDuty-Signature", Secret: "secret", Prefix: "v1="},
+			header: http.Header{"X-Pagerduty-Signature": []string{
+				"v1=" + sign("other", sha256.New, hex.EncodeToString) + ",v1=" + sign("secret", sha256.New, hex.EncodeToString),
+			}},
+			status: http.StatusOK,
+		},
+		{
+			desc:      "No valid signature among several",
+			signature: &SignatureConfig{Header: "X-PagerDuty-Signature", Secret: "secret", Prefix: "v1="},
+			header: http.Header{"X-Pagerduty-Signature": []string{
+				"v1=" + sign("other", sha256.New, hex.EncodeToString) + ", v1=not hex",
+			}},
+			status: http.StatusUnauthorized,
+		},
+		{
+			desc:      "Missing signature",
+			signature: &SignatureConfig{Header: "X-Hub-Signature-256", Secret: "secret", Prefix: "sha256="},
+			status:    http.StatusUnauthorized,
+		},
+		{
+			desc:      "Wrong secret",
+			signature: &SignatureConfig{Header: "X-Hub-Signature-256", Secret: "secret", Prefix: "sha256="},
+			header:    http.Header{"X-Hub-Signature-256": []string{"sha256=" + sign("other", sha256.New, hex.EncodeToString)}},
+			status:    http.StatusUnauthorized,
+		},
+		{
+			desc:      "Missing prefix",
+			signature: &SignatureConfig{Header: "X-Hub-Signature-256", Secret: "secret", Prefix: "sha256="},
+			header:    http.Header{"X-Hub-Signature-256": []string{sign("secret", sha256.New, hex.EncodeToString)}},
+			status:    http.StatusUnauthorized,
+		},
+		{
+			desc:      "Invalid encoding",
+			signature: &SignatureConfig{Header: "X-Signature", Secret: "secret"},
+			header:    http.Header{"X-Signature": []string{"not hex"}},
+			status:    http.StatusUnauthorized,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:0"
+			cfg.Signature = test.signature
+			require.NoError(t, cfg.Validate())
+
+			sink := new(consumertest.LogsSink)
+			receiver, err := newLogsReceiver(receivertest.NewNopCreateSettings(), *cfg, sink)
+			require.NoError(t, err, "Failed to create receiver")
+			r := receiver.(*eventReceiver)
+
+			req := httptest.NewRequest("POST", "http://localhost/events", strings.NewReader(body))
+			for k, v := range test.header {
+				req.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			r.handleReq(w, req, httprouter.ParamsFromContext(context.Background()))
+
+			require.Equal(t, test.status, w.Result().StatusCode)
+			if test.status == http.StatusOK {
+				require.Equal(t, 1, sink.LogRecordCount())
+			} else {
+				require.Equal(t, 0, sink.LogRecordCount())
+			}
+		})
+	}
+}
+
+func TestMultiplePaths(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = "localhost:0"
+	cfg.Attributes = map[string]string{"webhook.source": "default"}
+	cfg.Paths = []PathConfig{
+		{
+			Path: "/alertmanager",
+			RouteSettings: RouteSettings{
+				Attributes: map[string]string{"webhook.source": "alertmanager"},
+				Split:      splitNone,
+			},
+		},
+		{
+			Path: "/github",
+			RouteSettings: RouteSettings{
+				Attributes:       map[string]string{"webhook.source": "github"},
+				HeaderAttributes: map[string]string{"X-GitHub-Event": "github.event"},
+				Split:            splitJSONArray,
+				Signature:        &SignatureConfig{Header: "X-Hub-Signature-256", Secret: "secret", Prefix: "sha256="},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.LogsSink)
+	receiver, err := newLogsReceiver(receivertest.NewNopCreateSettings(), *cfg, sink)
+	require.NoError(t, err, "Failed to create receiver")
+	r := receiver.(*eventReceiver)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()), "Failed to start receiver")
+	defer func() {
+		require.NoError(t, r.Shutdown(context.Background()), "Failed to shutdown receiver")
+	}()
+
+	send := func(req *http.Request) int {
+		w := httptest.NewRecorder()
+		r.server.Handler.ServeHTTP(w, req)
+		return w.Result().StatusCode
+	}
+
+	require.Equal(t, http.StatusOK, send(httptest.NewRequest("POST", "http://localhost/events", strings.NewReader("log1\nlog2"))))
+	require.Equal(t, http.StatusOK, send(httptest.NewRequest("POST", "http://localhost/alertmanager", strings.NewReader("{\n\"alerts\": []\n}"))))
+
+	githubBody := `[{"action": "opened"}, {"action": "closed"}]`
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(githubBody))
+	req := httptest.NewRequest("POST", "http://localhost/github", strings.NewReader(githubBody))
+	req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	req.Header.Set("X-GitHub-Event", "issues")
+	require.Equal(t, http.StatusOK, send(req))
+
+	// The signature is only required on the path it is configured on.
+	require.Equal(t, http.StatusUnauthorized, send(httptest.NewRequest("POST", "http://localhost/github", strings.NewReader(githubBody))))
+	require.Equal(t, http.StatusBadRequest, send(func() *http.Request {
+		req := httptest.NewRequest("POST", "http://localhost/github", strings.NewReader("not json"))
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write([]byte("not json"))
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		return req
+	}()))
+
+	var records []map[string]interface{}
+	for _, ld := range sink.AllLogs() {
+		logRecords := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < logRecords.Len(); i++ {
+			attributes := logRecords.At(i).Attributes().AsRaw()
+			attributes["body"] = logRecords.At(i).Body().Str()
+			records = append(records, attributes)
+		}
+	}
+	require.Equal(t, []map[string]interface{}{
+		{"body": "log1", "webhook.source": "default"},
+		{"body": "log2", "webhook.source": "default"},
+		{"body": "{\n\"alerts\": []\n}", "webhook.source": "alertmanager"},
+		{"body": `{"action":"opened"}`, "webhook.source": "github", "github.event": "issues"},
+		{"body": `{"action":"closed"}`, "webhook.source": "github", "github.event": "issues"},
+	}, records)
+}
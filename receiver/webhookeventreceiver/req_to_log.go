@@ -5,6 +5,10 @@ package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/url"
 
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -13,9 +17,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver/internal/metadata"
 )
 
-func reqToLog(sc *bufio.Scanner,
+func reqToLog(bodies []string,
 	query url.Values,
-	_ *Config,
+	header http.Header,
+	rs *RouteSettings,
 	settings receiver.CreateSettings) (plog.Logs, int) {
 	log := plog.NewLogs()
 	resourceLog := log.ResourceLogs().AppendEmpty()
@@ -27,15 +32,60 @@ func reqToLog(sc *bufio.Scanner,
 	scopeLog.Scope().Attributes().PutStr("source", settings.ID.String())
 	scopeLog.Scope().Attributes().PutStr("receiver", metadata.Type)
 
-	for sc.Scan() {
+	for _, body := range bodies {
 		logRecord := scopeLog.LogRecords().AppendEmpty()
-		line := sc.Text()
-		logRecord.Body().SetStr(line)
+		logRecord.Body().SetStr(body)
+		for k, v := range rs.Attributes {
+			logRecord.Attributes().PutStr(k, v)
+		}
+		for name, k := range rs.HeaderAttributes {
+			if v := header.Get(name); v != "" {
+				logRecord.Attributes().PutStr(k, v)
+			}
+		}
 	}
 
 	return log, scopeLog.LogRecords().Len()
 }
 
+// splitBody splits the request body into the bodies of the log records.
+func splitBody(body io.Reader, split string, maxLineSize int) ([]string, error) {
+	switch split {
+	case splitNone:
+		b, err := io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(b)}, nil
+	case splitJSONArray:
+		var elements []json.RawMessage
+		if err := json.NewDecoder(body).Decode(&elements); err != nil {
+			return nil, err
+		}
+		bodies := make([]string, 0, len(elements))
+		for _, element := range elements {
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, element); err != nil {
+				return nil, err
+			}
+			bodies = append(bodies, buf.String())
+		}
+		return bodies, nil
+	default:
+		var bodies []string
+		sc := bufio.NewScanner(body)
+		bufSize := bufio.MaxScanTokenSize
+		if maxLineSize < bufSize {
+			bufSize = maxLineSize
+		}
+		sc.Buffer(make([]byte, 0, bufSize), maxLineSize)
+		for sc.Scan() {
+			bodies = append(bodies, sc.Text())
+		}
+		return bodies, sc.Err()
+	}
+}
+
 // append query parameters and webhook source as resource attributes
 func appendMetadata(resourceLog plog.ResourceLogs, query url.Values) {
 	for k := range query {
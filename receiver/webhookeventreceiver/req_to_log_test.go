@@ -8,7 +8,9 @@ import (
 	"bytes"
 	"io"
 	"log"
+	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -21,17 +23,16 @@ func TestReqToLog(t *testing.T) {
 	defaultConfig := createDefaultConfig().(*Config)
 
 	tests := []struct {
-		desc  string
-		sc    *bufio.Scanner
-		query url.Values
-		tt    func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings)
+		desc   string
+		body   io.Reader
+		query  url.Values
+		header http.Header
+		rs     *RouteSettings
+		tt     func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings)
 	}{
 		{
 			desc: "Valid query valid event",
-			sc: func() *bufio.Scanner {
-				reader := io.NopCloser(bytes.NewReader([]byte("this is a: log")))
-				return bufio.NewScanner(reader)
-			}(),
+			body: io.NopCloser(bytes.NewReader([]byte("this is a: log"))),
 			query: func() url.Values {
 				v, err := url.ParseQuery(`qparam1=hello&qparam2=world`)
 				if err != nil {
@@ -62,10 +63,7 @@ func TestReqToLog(t *testing.T) {
 		},
 		{
 			desc: "Query is empty",
-			sc: func() *bufio.Scanner {
-				reader := io.NopCloser(bytes.NewReader([]byte("this is a: log")))
-				return bufio.NewScanner(reader)
-			}(),
+			body: io.NopCloser(bytes.NewReader([]byte("this is a: log"))),
 			tt: func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings) {
 				require.Equal(t, 1, reqLen)
 
@@ -76,12 +74,106 @@ func TestReqToLog(t *testing.T) {
 				require.Equal(t, 2, scopeLogsScope.Attributes().Len())
 			},
 		},
+		{
+			desc: "JSON array with attributes",
+			body: strings.NewReader(`[{"event": "opened", "id": 1}, {"event": "closed", "id": 2}]`),
+			header: http.Header{
+				"X-Github-Event":    []string{"issues"},
+				"X-Github-Delivery": []string{"72d3162e"},
+			},
+			rs: &RouteSettings{
+				Split:      splitJSONArray,
+				Attributes: map[string]string{"webhook.source": "github"},
+				HeaderAttributes: map[string]string{
+					"X-GitHub-Event":    "github.event",
+					"X-GitHub-Hook":     "github.hook",
+					"X-GitHub-Delivery": "github.delivery",
+				},
+			},
+			tt: func(t *testing.T, reqLog plog.Logs, reqLen int, settings receiver.CreateSettings) {
+				require.Equal(t, 2, reqLen)
+
+				logRecords := reqLog.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				require.Equal(t, `{"event":"opened","id":1}`, logRecords.At(0).Body().Str())
+				require.Equal(t, `{"event":"closed","id":2}`, logRecords.At(1).Body().Str())
+				for i := 0; i < logRecords.Len(); i++ {
+					require.Equal(t, map[string]interface{}{
+						"webhook.source":  "github",
+						"github.event":    "issues",
+						"github.delivery": "72d3162e",
+					}, logRecords.At(i).Attributes().AsRaw())
+				}
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
-			reqLog, reqLen := reqToLog(test.sc, test.query, defaultConfig, receivertest.NewNopCreateSettings())
+			rs := test.rs
+			if rs == nil {
+				rs = &defaultConfig.RouteSettings
+			}
+			bodies, err := splitBody(test.body, rs.Split, bufio.MaxScanTokenSize)
+			require.NoError(t, err)
+			reqLog, reqLen := reqToLog(bodies, test.query, test.header, rs, receivertest.NewNopCreateSettings())
 			test.tt(t, reqLog, reqLen, receivertest.NewNopCreateSettings())
 		})
 	}
 }
+
+func TestSplitBody(t *testing.T) {
+	tests := []struct {
+		desc   string
+		body   string
+		split  string
+		expect []string
+		err    string
+	}{
+		{
+			desc:   "Lines",
+			body:   "{\"a\": 1}\n{\"b\": 2}\n",
+			split:  splitLine,
+			expect: []string{`{"a": 1}`, `{"b": 2}`},
+		},
+		{
+			desc:   "Lines by default",
+			body:   "log1\nlog2",
+			expect: []string{"log1", "log2"},
+		},
+		{
+			desc:  "Line too long",
+			body:  "log1\nthis line is too long",
+			split: splitLine,
+			err:   "token too long",
+		},
+		{
+			desc:   "JSON array",
+			body:   "[\n  {\"a\": 1},\n  \"text\",\n  [2, 3]\n]",
+			split:  splitJSONArray,
+			expect: []string{`{"a":1}`, `"text"`, `[2,3]`},
+		},
+		{
+			desc:  "Not a JSON array",
+			body:  `{"a": 1}`,
+			split: splitJSONArray,
+			err:   "cannot unmarshal object",
+		},
+		{
+			desc:   "None",
+			body:   "log1\nlog2",
+			split:  splitNone,
+			expect: []string{"log1\nlog2"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			bodies, err := splitBody(strings.NewReader(test.body), test.split, 16)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expect, bodies)
+		})
+	}
+}
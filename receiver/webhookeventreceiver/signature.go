@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhookeventreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/webhookeventreceiver"
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 -- SHA-1 is still used by some webhook sources to sign their payloads
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+var (
+	errMissingSignature = errors.New("missing request signature")
+	errInvalidSignature = errors.New("invalid request signature")
+)
+
+// signatureVerifier verifies the HMAC signature of the request bodies.
+type signatureVerifier struct {
+	header string
+	prefix string
+	secret []byte
+	hash   func() hash.Hash
+	decode func(string) ([]byte, error)
+}
+
+func newSignatureVerifier(cfg *SignatureConfig) *signatureVerifier {
+	v := &signatureVerifier{
+		header: cfg.Header,
+		prefix: cfg.Prefix,
+		secret: []byte(cfg.Secret),
+		hash:   sha256.New,
+		decode: hex.DecodeString,
+	}
+	switch cfg.Algorithm {
+	case algorithmSHA1:
+		v.hash = sha1.New
+	case algorithmSHA512:
+		v.hash = sha512.New
+	}
+	if cfg.Encoding == encodingBase64 {
+		v.decode = base64.StdEncoding.DecodeString
+	}
+	return v
+}
+
+// verify checks that one of the signatures in the request header is the HMAC of the body. The header
+// may contain several comma-separated signatures, e.g. PagerDuty sends one per secret while it is rotated.
+func (v *signatureVerifier) verify(header http.Header, body []byte) error {
+	value := header.Get(v.header)
+	if value == "" {
+		return errMissingSignature
+	}
+
+	mac := hmac.New(v.hash, v.secret)
+	mac.Write(body)
+	expected := mac.Sum(nil)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if !strings.HasPrefix(entry, v.prefix) {
+			continue
+		}
+		signature, err := v.decode(strings.TrimPrefix(entry, v.prefix))
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(signature, expected) {
+			return nil
+		}
+	}
+	return errInvalidSignature
+}